@@ -13,12 +13,28 @@ type Config struct {
 	DBConfig        DBConfig        `mapstructure:"database"`
 	RabbitMQConfig  RabbitMQConfig  `mapstructure:"rabbitmq"`
 	AuthTokenConfig AuthTokenConfig `mapstructure:"auth-token"`
+
+	LoginProtectionConfig LoginProtectionConfig `mapstructure:"login-protection"`
+	VerificationConfig    VerificationConfig    `mapstructure:"verification"`
+	NotifierConfig        NotifierConfig        `mapstructure:"notifier"`
+	OIDCConfig            OIDCConfig            `mapstructure:"oidc"`
+	TwoFactorConfig       TwoFactorConfig       `mapstructure:"two-factor"`
+	PricingConfig         PricingConfig         `mapstructure:"pricing"`
+	StorageConfig         StorageConfig         `mapstructure:"storage"`
+	ProductImportConfig   ProductImportConfig   `mapstructure:"product-import"`
+	ReviewConfig          ReviewConfig          `mapstructure:"review"`
+	ShopMemberConfig      ShopMemberConfig      `mapstructure:"shop-member"`
+	StorefrontConfig      StorefrontConfig      `mapstructure:"storefront"`
 }
 
 type ServerConfig struct {
 	Host string `mapstructure:"host"`
 	Port int    `mapstructure:"port"`
 	Env  string `mapstructure:"env"`
+
+	// TrustedProxies are the CIDRs of the reverse proxies allowed to set
+	// X-Forwarded-For, the client IP is the peer address when empty
+	TrustedProxies []string `mapstructure:"trusted-proxies"`
 }
 
 type RedisConfig struct {
@@ -43,8 +59,130 @@ type RabbitMQConfig struct {
 }
 
 type AuthTokenConfig struct {
-	Duration  time.Duration `mapstructure:"duration"`
-	SecretKey string        `mapstructure:"secretkey"`
+	Duration        time.Duration `mapstructure:"duration"`
+	RefreshDuration time.Duration `mapstructure:"refresh-duration"`
+	SecretKey       string        `mapstructure:"secretkey"`
+
+	// ActiveKeyID selects the key used to sign new tokens, HS256 with SecretKey when empty
+	ActiveKeyID string             `mapstructure:"active-key-id"`
+	Keys        []SigningKeyConfig `mapstructure:"keys"`
+
+	// LegacyHS256Until is a RFC 3339 time until which HS256 tokens without a kid are
+	// still accepted once ActiveKeyID is set, it lets sessions issued before the switch
+	// to an asymmetric key run out. Such tokens are rejected when it is empty
+	LegacyHS256Until string `mapstructure:"legacy-hs256-until"`
+}
+
+// SigningKeyConfig describes a RS256 or EdDSA key, keys that are rotated out
+// only need a public key to keep verifying the tokens they signed
+type SigningKeyConfig struct {
+	ID             string `mapstructure:"id"`
+	Algorithm      string `mapstructure:"algorithm"`
+	PrivateKeyPath string `mapstructure:"private-key-path"`
+	PublicKeyPath  string `mapstructure:"public-key-path"`
+}
+
+// LoginProtectionConfig durations are in seconds, like AuthTokenConfig
+type LoginProtectionConfig struct {
+	MaxAttempts   int           `mapstructure:"max-attempts"`
+	AttemptWindow time.Duration `mapstructure:"attempt-window"`
+	LockDuration  time.Duration `mapstructure:"lock-duration"`
+	BackoffBase   time.Duration `mapstructure:"backoff-base"`
+	BackoffMax    time.Duration `mapstructure:"backoff-max"`
+}
+
+// VerificationConfig durations are in seconds, like AuthTokenConfig. MaxAttempts
+// wrong codes are allowed per CodeDuration, however many codes are sent meanwhile
+type VerificationConfig struct {
+	CodeDuration       time.Duration `mapstructure:"code-duration"`
+	MaxAttempts        int           `mapstructure:"max-attempts"`
+	ResendCooldown     time.Duration `mapstructure:"resend-cooldown"`
+	ResetTokenDuration time.Duration `mapstructure:"reset-token-duration"`
+}
+
+type NotifierConfig struct {
+	Driver   string `mapstructure:"driver"`
+	FilePath string `mapstructure:"file-path"`
+}
+
+// OIDCConfig StateDuration is in seconds, like AuthTokenConfig
+type OIDCConfig struct {
+	StateDuration time.Duration        `mapstructure:"state-duration"`
+	Providers     []OIDCProviderConfig `mapstructure:"providers"`
+}
+
+// OIDCProviderConfig endpoints are discovered from the issuer when left empty
+type OIDCProviderConfig struct {
+	Name                  string   `mapstructure:"name"`
+	Issuer                string   `mapstructure:"issuer"`
+	ClientID              string   `mapstructure:"client-id"`
+	ClientSecret          string   `mapstructure:"client-secret"`
+	RedirectURL           string   `mapstructure:"redirect-url"`
+	Scopes                []string `mapstructure:"scopes"`
+	AuthorizationEndpoint string   `mapstructure:"authorization-endpoint"`
+	TokenEndpoint         string   `mapstructure:"token-endpoint"`
+	JWKSURI               string   `mapstructure:"jwks-uri"`
+}
+
+// TwoFactorConfig ChallengeDuration is in seconds, like AuthTokenConfig
+type TwoFactorConfig struct {
+	Issuer            string        `mapstructure:"issuer"`
+	ChallengeDuration time.Duration `mapstructure:"challenge-duration"`
+	MaxAttempts       int           `mapstructure:"max-attempts"`
+	RecoveryCodeCount int           `mapstructure:"recovery-code-count"`
+}
+
+// PricingConfig SchedulerInterval is in seconds, like AuthTokenConfig
+type PricingConfig struct {
+	DefaultCurrency   string        `mapstructure:"default-currency"`
+	SchedulerInterval time.Duration `mapstructure:"scheduler-interval"`
+}
+
+// StorageConfig MaxUploadSize is in bytes, ThumbnailSize is the longest side of a
+// thumbnail in pixels. BaseURL is the public URL the stored files are served from
+type StorageConfig struct {
+	Driver        string          `mapstructure:"driver"`
+	LocalPath     string          `mapstructure:"local-path"`
+	BaseURL       string          `mapstructure:"base-url"`
+	MaxUploadSize int64           `mapstructure:"max-upload-size"`
+	ThumbnailSize int             `mapstructure:"thumbnail-size"`
+	S3            S3StorageConfig `mapstructure:"s3"`
+}
+
+// S3StorageConfig works with any S3-compatible endpoint, e.g. a local MinIO,
+// which usually needs path style addressing
+type S3StorageConfig struct {
+	Endpoint  string `mapstructure:"endpoint"`
+	Region    string `mapstructure:"region"`
+	Bucket    string `mapstructure:"bucket"`
+	AccessKey string `mapstructure:"access-key"`
+	SecretKey string `mapstructure:"secret-key"`
+	PathStyle bool   `mapstructure:"path-style"`
+}
+
+// ProductImportConfig MaxFileSize is in bytes, files with more rows than
+// SyncRowLimit are imported by the import worker
+type ProductImportConfig struct {
+	MaxFileSize  int64 `mapstructure:"max-file-size"`
+	MaxRows      int   `mapstructure:"max-rows"`
+	SyncRowLimit int   `mapstructure:"sync-row-limit"`
+}
+
+// ReviewConfig HideFlagThreshold is the number of flags that hides a review until
+// it is moderated, zero keeps flagged reviews visible
+type ReviewConfig struct {
+	HideFlagThreshold int `mapstructure:"hide-flag-threshold"`
+}
+
+// ShopMemberConfig InvitationDuration is in seconds, like AuthTokenConfig
+type ShopMemberConfig struct {
+	InvitationDuration time.Duration `mapstructure:"invitation-duration"`
+}
+
+// StorefrontConfig CacheDuration is in seconds, like AuthTokenConfig, zero disables
+// the storefront cache
+type StorefrontConfig struct {
+	CacheDuration time.Duration `mapstructure:"cache-duration"`
 }
 
 func GetConfig() *Config {
@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"net/http"
+	"simcomm-monolith/util"
+
+	"github.com/labstack/echo/v4"
+)
+
+type JWKSHandler struct {
+	keySet *util.KeySet
+}
+
+func RegisterJWKSHandler(e *echo.Echo, keySet *util.KeySet) {
+	handler := &JWKSHandler{
+		keySet: keySet,
+	}
+	e.GET(".well-known/jwks.json", handler.GetJWKS)
+}
+
+// GetJWKS handles fetching the token verification keys
+// @Summary Get JSON Web Key Set
+// @Description Retrieve the public keys used to verify access tokens
+// @Tags auth
+// @Produce json
+// @Success 200 {object} util.JWKS
+// @Router /.well-known/jwks.json [get]
+func (h *JWKSHandler) GetJWKS(c echo.Context) error {
+	return c.JSON(http.StatusOK, h.keySet.JWKS())
+}
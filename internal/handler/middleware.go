@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"simcomm-monolith/config"
+	"simcomm-monolith/internal/model"
+	"simcomm-monolith/internal/repository"
+	"simcomm-monolith/util"
+
+	"github.com/labstack/echo/v4"
+)
+
+const contextKeyAuthUser = "auth_user"
+
+// publicPaths are routes that can be accessed without a bearer token
+var publicPaths = map[string]bool{
+	"/health":           true,
+	"/swagger/*":        true,
+	"/ecommerce/login":  true,
+	"/ecommerce/signup": true,
+
+	"/ecommerce/token/refresh": true,
+	"/.well-known/jwks.json":   true,
+
+	"/ecommerce/verification/send":   true,
+	"/ecommerce/verification/verify": true,
+	"/ecommerce/password/forgot":     true,
+	"/ecommerce/password/reset":      true,
+
+	"/ecommerce/login/2fa": true,
+
+	"/ecommerce/oidc/:provider/login":    true,
+	"/ecommerce/oidc/:provider/callback": true,
+
+	"/uploads/*": true,
+
+	"/storefront/:shopID": true,
+}
+
+// newIPExtractor returns how c.RealIP finds the client IP, X-Forwarded-For is only
+// trusted when the request comes through one of the trusted proxies, otherwise
+// any client could pick the IP its failed logins are counted against
+func newIPExtractor(cfg config.ServerConfig) (echo.IPExtractor, error) {
+	if len(cfg.TrustedProxies) == 0 {
+		return echo.ExtractIPDirect(), nil
+	}
+
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, cidr := range cfg.TrustedProxies {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("trusted proxy %v : %w", cidr, err)
+		}
+		options = append(options, echo.TrustIPRange(ipNet))
+	}
+	return echo.ExtractIPFromXFFHeader(options...), nil
+}
+
+// AuthMiddleware validates the bearer token against its Redis session and
+// injects the authenticated user into the echo and request context
+func AuthMiddleware(keySet *util.KeySet, redisRepo repository.RedisRepository) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if publicPaths[c.Path()] {
+				return next(c)
+			}
+
+			token, err := util.ExtractBearerToken(c.Request().Header.Get(echo.HeaderAuthorization))
+			if err != nil {
+				return c.JSON(http.StatusUnauthorized, model.Response{Message: err.Error()})
+			}
+
+			claims, err := keySet.VerifyToken(token)
+			if err != nil {
+				return c.JSON(http.StatusUnauthorized, model.Response{Message: err.Error()})
+			}
+
+			ctx := c.Request().Context()
+			session, err := redisRepo.GetSession(ctx, claims.StandardClaims.Id)
+			if err != nil || session.UserID != claims.ID {
+				return c.JSON(http.StatusUnauthorized, model.Response{Message: util.ErrInvalidToken})
+			}
+
+			authUser := util.AuthUser{
+				ID:      session.UserID,
+				Roles:   session.Roles,
+				TokenID: session.TokenID,
+			}
+			c.Set(contextKeyAuthUser, authUser)
+			c.SetRequest(c.Request().WithContext(util.SetAuthUser(ctx, authUser)))
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"simcomm-monolith/config"
+	"simcomm-monolith/internal/model"
+	"simcomm-monolith/internal/repository/mocks"
+	"simcomm-monolith/util"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func testKeySet(t *testing.T) *util.KeySet {
+	t.Helper()
+	keySet, err := util.NewKeySet(config.AuthTokenConfig{SecretKey: "test-secret"})
+	require.NoError(t, err)
+	return keySet
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	keySet := testKeySet(t)
+	token, err := keySet.GenerateToken(1, []string{util.RoleCustomer}, "token-1", 900)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		path          string
+		authorization string
+		setup         func(redisRepo *mocks.RedisRepository)
+		wantStatus    int
+		wantUserID    int
+	}{
+		{
+			name:       "public path needs no token",
+			path:       "/ecommerce/login",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "missing bearer token",
+			path:       "/users",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:          "malformed token",
+			path:          "/users",
+			authorization: "Bearer not-a-token",
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:          "revoked session",
+			path:          "/users",
+			authorization: "Bearer " + token,
+			setup: func(redisRepo *mocks.RedisRepository) {
+				redisRepo.On("GetSession", mock.Anything, "token-1").Return(nil, errors.New("redis: nil"))
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:          "session of another user",
+			path:          "/users",
+			authorization: "Bearer " + token,
+			setup: func(redisRepo *mocks.RedisRepository) {
+				redisRepo.On("GetSession", mock.Anything, "token-1").Return(&model.Session{TokenID: "token-1", UserID: 2}, nil)
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:          "valid session injects the user",
+			path:          "/users",
+			authorization: "Bearer " + token,
+			setup: func(redisRepo *mocks.RedisRepository) {
+				redisRepo.On("GetSession", mock.Anything, "token-1").Return(&model.Session{
+					TokenID: "token-1",
+					UserID:  1,
+					Roles:   []string{util.RoleCustomer},
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantUserID: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			redisRepo := &mocks.RedisRepository{}
+			if tt.setup != nil {
+				tt.setup(redisRepo)
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.authorization != "" {
+				req.Header.Set(echo.HeaderAuthorization, tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath(tt.path)
+
+			var authUser util.AuthUser
+			handler := AuthMiddleware(keySet, redisRepo)(func(c echo.Context) error {
+				authUser, _ = util.GetAuthUser(c.Request().Context())
+				return c.NoContent(http.StatusOK)
+			})
+			require.NoError(t, handler(c))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantUserID, authUser.ID)
+			redisRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestNewIPExtractor(t *testing.T) {
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		remoteAddr     string
+		forwardedFor   string
+		wantIP         string
+	}{
+		{"direct ignores forwarded header", nil, "203.0.113.7:4000", "198.51.100.1", "203.0.113.7"},
+		{"trusted proxy forwards the client", []string{"10.0.0.0/8"}, "10.0.0.2:4000", "198.51.100.1", "198.51.100.1"},
+		{"untrusted peer cannot spoof", []string{"10.0.0.0/8"}, "203.0.113.7:4000", "198.51.100.1", "203.0.113.7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			extractor, err := newIPExtractor(config.ServerConfig{TrustedProxies: tt.trustedProxies})
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/ecommerce/login", nil)
+			req.RemoteAddr = tt.remoteAddr
+			req.Header.Set(echo.HeaderXForwardedFor, tt.forwardedFor)
+			assert.Equal(t, tt.wantIP, extractor(req))
+		})
+	}
+
+	_, err := newIPExtractor(config.ServerConfig{TrustedProxies: []string{"not-a-cidr"}})
+	assert.Error(t, err)
+}
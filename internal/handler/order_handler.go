@@ -22,6 +22,7 @@ func RegisterOrderHandler(e *echo.Echo, svc service.OrderService) {
 	e.POST("orders", handler.CreateOrder)
 	e.GET("orders/:id", handler.GetOrder)
 	e.PUT("orders/:id", handler.UpdateOrder)
+	e.PUT("orders/:id/status", handler.UpdateOrderStatus)
 	e.DELETE("orders/:id", handler.DeleteOrder)
 }
 
@@ -31,13 +32,14 @@ func NewOrderHandler(service service.OrderService) *OrderHandler {
 
 // CreateOrder Create order
 // @Summary      Create Order
-// @Description  Create Order
+// @Description  Create Order, the buyer must have a verified email or phone
 // @Tags         orders
 // @Accept       json
 // @Produce      json
 // @Param order body model.Order true "Order details"
 // @Success      201  {object}  model.Response
 // @Failure      400  {object}  model.Response
+// @Failure      403  {object}  model.Response
 // @Failure      500  {object}  model.Response
 // @Router       /orders [post]
 func (h *OrderHandler) CreateOrder(c echo.Context) error {
@@ -48,7 +50,7 @@ func (h *OrderHandler) CreateOrder(c echo.Context) error {
 
 	ctx := c.Request().Context()
 	if err := h.service.Create(ctx, &order); err != nil {
-		return c.JSON(http.StatusInternalServerError, model.Response{Message: err.Error()})
+		return serviceErrorResponse(c, err)
 	}
 
 	return c.JSON(http.StatusCreated, model.Response{Message: "success", Data: order})
@@ -56,12 +58,13 @@ func (h *OrderHandler) CreateOrder(c echo.Context) error {
 
 // GetOrder handles fetching an order by ID
 // @Summary Get an order by ID
-// @Description Retrieve an order by its ID
+// @Description Retrieve an order by its ID, only the buyer, members of the shop of the order and admins can read it
 // @Tags orders
 // @Produce json
 // @Param id path int true "Order ID"
 // @Success 200 {object}  model.Response
 // @Failure 400 {object}  model.Response
+// @Failure 403 {object}  model.Response
 // @Failure 404 {object}  model.Response
 // @Router /orders/{id} [get]
 func (h *OrderHandler) GetOrder(c echo.Context) error {
@@ -73,7 +76,7 @@ func (h *OrderHandler) GetOrder(c echo.Context) error {
 	ctx := c.Request().Context()
 	order, err := h.service.Get(ctx, id)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, model.Response{Message: "Order not found"})
+		return serviceErrorResponse(c, err)
 	}
 
 	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: order})
@@ -81,17 +84,18 @@ func (h *OrderHandler) GetOrder(c echo.Context) error {
 
 // GetAllOrders handles fetching all order
 // @Summary Get all order
-// @Description Retrieve all order in the system
+// @Description Retrieve the orders placed by the user and the orders of the shops the user is a member of, admins retrieve every order
 // @Tags orders
 // @Produce json
 // @Success 200 {object}  model.Response
+// @Failure 403 {object}  model.Response
 // @Failure 500 {object}  model.Response
 // @Router /orders [get]
 func (h *OrderHandler) GetAllOrders(c echo.Context) error {
 	ctx := c.Request().Context()
 	orders, err := h.service.GetAll(ctx)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, model.Response{Message: err.Error()})
+		return serviceErrorResponse(c, err)
 	}
 
 	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: orders})
@@ -99,7 +103,7 @@ func (h *OrderHandler) GetAllOrders(c echo.Context) error {
 
 // UpdateOrder handles updating an existing order
 // @Summary Update an existing order
-// @Description Update order details
+// @Description Update order details, the status is kept and only moves through the status endpoint
 // @Tags orders
 // @Accept json
 // @Produce json
@@ -107,6 +111,7 @@ func (h *OrderHandler) GetAllOrders(c echo.Context) error {
 // @Param order body model.Order true "Order details"
 // @Success 200 {object} model.Order
 // @Failure 400 {object} model.Response
+// @Failure 404 {object} model.Response
 // @Failure 500 {object} model.Response
 // @Router /orders/{id} [put]
 func (h *OrderHandler) UpdateOrder(c echo.Context) error {
@@ -123,7 +128,41 @@ func (h *OrderHandler) UpdateOrder(c echo.Context) error {
 
 	ctx := c.Request().Context()
 	if err := h.service.Update(ctx, &order); err != nil {
-		return c.JSON(http.StatusInternalServerError, model.Response{Message: err.Error()})
+		return serviceErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: order})
+}
+
+// UpdateOrderStatus handles moving an order along its fulfilment
+// @Summary Update the status of an order
+// @Description Ship, complete or cancel an order, only members of the shop of the order and admins can change its status
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param id path int true "Order ID"
+// @Param status body model.OrderStatusRequest true "shipped, completed or cancelled"
+// @Success 200 {object} model.Response
+// @Failure 400 {object} model.Response
+// @Failure 403 {object} model.Response
+// @Failure 404 {object} model.Response
+// @Failure 409 {object} model.Response
+// @Router /orders/{id}/status [put]
+func (h *OrderHandler) UpdateOrderStatus(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+
+	var req model.OrderStatusRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid input"})
+	}
+
+	ctx := c.Request().Context()
+	order, err := h.service.UpdateStatus(ctx, id, req)
+	if err != nil {
+		return serviceErrorResponse(c, err)
 	}
 
 	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: order})
@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"net/http"
+	"simcomm-monolith/internal/model"
+	"simcomm-monolith/util"
+
+	"github.com/labstack/echo/v4"
+)
+
+var anyRole = []string{util.RoleCustomer, util.RoleSeller, util.RoleWarehouseStaff}
+
+// shopScoped routes act on a resource of a shop, the services let the members of the
+// shop in by their shop role so the user roles do not matter
+var shopScoped = anyRole
+
+// routePolicies maps "METHOD /path" to the roles allowed to access it.
+// Admin is allowed on every route, routes missing from this map are admin only.
+// Resource ownership is checked in the services against the shop membership,
+// e.g. warehouse stored products through the membership of the warehouse shop.
+var routePolicies = map[string][]string{
+	"POST /ecommerce/logout": anyRole,
+
+	"GET /users/me":          anyRole,
+	"PATCH /users/me":        anyRole,
+	"PUT /users/me/password": anyRole,
+
+	"DELETE /users/me":          anyRole,
+	"POST /users/me/deactivate": anyRole,
+	"GET /users/me/export":      anyRole,
+
+	"POST /users/me/2fa/totp":           anyRole,
+	"POST /users/me/2fa/totp/confirm":   anyRole,
+	"DELETE /users/me/2fa/totp":         anyRole,
+	"POST /users/me/2fa/recovery-codes": anyRole,
+
+	"GET /users/me/addresses":               anyRole,
+	"POST /users/me/addresses":              anyRole,
+	"PUT /users/me/addresses/:addressID":    anyRole,
+	"DELETE /users/me/addresses/:addressID": anyRole,
+
+	"GET /users":                    {},
+	"POST /users":                   {},
+	"GET /users/:id":                {},
+	"PUT /users/:id":                {},
+	"DELETE /users/:id":             {},
+	"POST /users/:id/roles":         {},
+	"DELETE /users/:id/roles/:role": {},
+	"POST /users/:id/unlock":        {},
+	"POST /users/:id/deactivate":    {},
+	"POST /users/:id/reactivate":    {},
+
+	"GET /products":        anyRole,
+	"GET /products/:id":    anyRole,
+	"POST /products":       {util.RoleSeller},
+	"PUT /products/:id":    shopScoped,
+	"DELETE /products/:id": shopScoped,
+
+	"POST /products/:id/image":         shopScoped,
+	"GET /products/:id/status-history": {util.RoleSeller},
+
+	"POST /products/import":       {util.RoleSeller},
+	"GET /products/import/:jobID": {util.RoleSeller},
+	"GET /products/export":        {util.RoleSeller},
+
+	"GET /products/:id/variants":               anyRole,
+	"POST /products/:id/variants":              shopScoped,
+	"PUT /products/:id/variants/:variantID":    shopScoped,
+	"DELETE /products/:id/variants/:variantID": shopScoped,
+
+	"GET /search": anyRole,
+
+	"GET /products/:id/reviews":   anyRole,
+	"GET /products/:id/rating":    anyRole,
+	"GET /shops/:id/rating":       anyRole,
+	"POST /reviews":               {util.RoleCustomer},
+	"PUT /reviews/:id/reply":      shopScoped,
+	"POST /reviews/:id/flags":     anyRole,
+	"GET /reviews/flagged":        {},
+	"PUT /reviews/:id/moderation": {},
+
+	"GET /categories":        anyRole,
+	"GET /categories/:id":    anyRole,
+	"POST /categories":       {},
+	"PUT /categories/:id":    {},
+	"DELETE /categories/:id": {},
+
+	"GET /shops":        anyRole,
+	"GET /shops/:id":    anyRole,
+	"POST /shops":       {util.RoleSeller},
+	"PUT /shops/:id":    shopScoped,
+	"DELETE /shops/:id": shopScoped,
+
+	"POST /shops/:id/image":         shopScoped,
+	"GET /shops/:id/status-history": shopScoped,
+
+	"GET /shops/:id/members":                      shopScoped,
+	"PUT /shops/:id/members/:userID":              shopScoped,
+	"DELETE /shops/:id/members/:userID":           shopScoped,
+	"GET /shops/:id/invitations":                  shopScoped,
+	"POST /shops/:id/invitations":                 shopScoped,
+	"DELETE /shops/:id/invitations/:invitationID": shopScoped,
+	"POST /shops/invitations/accept":              anyRole,
+
+	"GET /shops/:id/products":   anyRole,
+	"GET /shop-products":        {}, // spans every shop, members use the shop-scoped listing
+	"GET /shop-products/:id":    anyRole,
+	"POST /shop-products":       shopScoped,
+	"PUT /shop-products/:id":    shopScoped,
+	"DELETE /shop-products/:id": shopScoped,
+
+	"GET /shop-products/:id/status-history": shopScoped,
+	"POST /shops/transfer":                  shopScoped,
+
+	"GET /shop-products/:id/prices":             anyRole,
+	"POST /shop-products/:id/prices":            shopScoped,
+	"DELETE /shop-products/:id/prices/:priceID": shopScoped,
+
+	"GET /warehouses":        {}, // spans every shop, members use the shop-scoped listing
+	"GET /warehouses/:id":    shopScoped,
+	"POST /warehouses":       shopScoped,
+	"PUT /warehouses/:id":    shopScoped,
+	"DELETE /warehouses/:id": shopScoped,
+
+	"POST /warehouses/:id/image":         shopScoped,
+	"GET /warehouses/:id/status-history": shopScoped,
+
+	"GET /shops/:id/warehouses":           shopScoped,
+	"GET /warehouses/:id/stored-products": shopScoped,
+
+	"GET /warehouse-stored-products":        {}, // spans every shop, members use the shop-scoped listing
+	"GET /warehouse-stored-products/:id":    shopScoped,
+	"POST /warehouse-stored-products":       shopScoped,
+	"PUT /warehouse-stored-products/:id":    shopScoped,
+	"DELETE /warehouse-stored-products/:id": shopScoped,
+
+	"GET /orders":            anyRole,
+	"GET /orders/:id":        anyRole,
+	"POST /orders":           {util.RoleCustomer},
+	"PUT /orders/:id":        {},
+	"PUT /orders/:id/status": shopScoped,
+	"DELETE /orders/:id":     {},
+}
+
+// AuthorizationMiddleware rejects requests whose authenticated user does not
+// hold a role allowed by routePolicies, it must run after AuthMiddleware
+func AuthorizationMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if publicPaths[c.Path()] {
+				return next(c)
+			}
+
+			authUser, ok := util.GetAuthUser(c.Request().Context())
+			if !ok {
+				return c.JSON(http.StatusUnauthorized, model.Response{Message: util.ErrInvalidToken})
+			}
+
+			if authUser.HasRole(util.RoleAdmin) {
+				return next(c)
+			}
+
+			roles := routePolicies[c.Request().Method+" "+c.Path()]
+			if !authUser.HasRole(roles...) {
+				return c.JSON(http.StatusForbidden, model.Response{Message: util.ErrForbidden})
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"simcomm-monolith/util"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthorizationMiddleware(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		roles      []string
+		anonymous  bool
+		wantStatus int
+	}{
+		{"public path", http.MethodPost, "/ecommerce/signup", nil, true, http.StatusOK},
+		{"missing auth user", http.MethodGet, "/products", nil, true, http.StatusUnauthorized},
+		{"customer reads products", http.MethodGet, "/products", []string{util.RoleCustomer}, false, http.StatusOK},
+		{"customer cannot create products", http.MethodPost, "/products", []string{util.RoleCustomer}, false, http.StatusForbidden},
+		{"seller creates products", http.MethodPost, "/products", []string{util.RoleSeller}, false, http.StatusOK},
+		{"customer member of a shop stores products", http.MethodPost, "/warehouse-stored-products", []string{util.RoleCustomer}, false, http.StatusOK},
+		{"customer cannot create shops", http.MethodPost, "/shops", []string{util.RoleCustomer}, false, http.StatusForbidden},
+		{"warehouse staff stores products", http.MethodPost, "/warehouse-stored-products", []string{util.RoleWarehouseStaff}, false, http.StatusOK},
+		{"customer accepts a shop invitation", http.MethodPost, "/shops/invitations/accept", []string{util.RoleCustomer}, false, http.StatusOK},
+		{"seller cannot list every shop product", http.MethodGet, "/shop-products", []string{util.RoleSeller}, false, http.StatusForbidden},
+		{"seller lists the products of a shop", http.MethodGet, "/shops/:id/products", []string{util.RoleSeller}, false, http.StatusOK},
+		{"warehouse staff cannot list every warehouse", http.MethodGet, "/warehouses", []string{util.RoleWarehouseStaff}, false, http.StatusForbidden},
+		{"warehouse staff cannot list every stored product", http.MethodGet, "/warehouse-stored-products", []string{util.RoleWarehouseStaff}, false, http.StatusForbidden},
+		{"warehouse staff lists the stock of a warehouse", http.MethodGet, "/warehouses/:id/stored-products", []string{util.RoleWarehouseStaff}, false, http.StatusOK},
+		{"admin only route", http.MethodGet, "/users", []string{util.RoleSeller}, false, http.StatusForbidden},
+		{"unknown route is admin only", http.MethodGet, "/unknown", []string{util.RoleCustomer}, false, http.StatusForbidden},
+		{"admin on any route", http.MethodGet, "/unknown", []string{util.RoleAdmin}, false, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if !tt.anonymous {
+				req = req.WithContext(util.SetAuthUser(req.Context(), util.AuthUser{ID: 1, Roles: tt.roles}))
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath(tt.path)
+
+			handler := AuthorizationMiddleware()(func(c echo.Context) error {
+				return c.NoContent(http.StatusOK)
+			})
+			require.NoError(t, handler(c))
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
@@ -1,11 +1,16 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"path/filepath"
 	"strconv"
+	"strings"
 
 	"simcomm-monolith/internal/model"
 	"simcomm-monolith/internal/service"
+	"simcomm-monolith/util"
 
 	"github.com/labstack/echo/v4"
 )
@@ -23,6 +28,23 @@ func RegisterProductHandler(e *echo.Echo, svc service.ProductService) {
 	e.GET("products/:id", handler.GetProduct)
 	e.PUT("products/:id", handler.UpdateProduct)
 	e.DELETE("products/:id", handler.DeleteProduct)
+	e.POST("products/:id/image", handler.UploadProductImage)
+	e.GET("products/:id/status-history", handler.GetProductStatusHistory)
+	e.GET("products/:id/variants", handler.GetProductVariants)
+	e.POST("products/:id/variants", handler.CreateProductVariant)
+	e.PUT("products/:id/variants/:variantID", handler.UpdateProductVariant)
+	e.DELETE("products/:id/variants/:variantID", handler.DeleteProductVariant)
+
+	e.POST("products/import", handler.ImportProducts)
+	e.GET("products/import/:jobID", handler.GetProductImport)
+	e.GET("products/export", handler.ExportProducts)
+	e.GET("search", handler.SearchProducts)
+
+	e.GET("categories", handler.GetCategoryTree)
+	e.POST("categories", handler.CreateCategory)
+	e.GET("categories/:id", handler.GetCategory)
+	e.PUT("categories/:id", handler.UpdateCategory)
+	e.DELETE("categories/:id", handler.DeleteCategory)
 }
 
 func NewProductHandler(service service.ProductService) *ProductHandler {
@@ -48,7 +70,7 @@ func (h *ProductHandler) CreateProduct(c echo.Context) error {
 
 	ctx := c.Request().Context()
 	if err := h.service.Create(ctx, &product); err != nil {
-		return c.JSON(http.StatusInternalServerError, model.Response{Message: err.Error()})
+		return serviceErrorResponse(c, err)
 	}
 
 	return c.JSON(http.StatusCreated, model.Response{Message: "success", Data: product})
@@ -99,7 +121,7 @@ func (h *ProductHandler) GetAllProducts(c echo.Context) error {
 
 // UpdateProduct handles updating an existing product
 // @Summary Update an existing product
-// @Description Update product details
+// @Description Update product details, only the creator of the product, managers of its shop and admins can update it
 // @Tags products
 // @Accept json
 // @Produce json
@@ -107,6 +129,7 @@ func (h *ProductHandler) GetAllProducts(c echo.Context) error {
 // @Param product body model.Product true "Product details"
 // @Success 200 {object} model.Product
 // @Failure 400 {object} model.Response
+// @Failure 403 {object} model.Response
 // @Failure 500 {object} model.Response
 // @Router /products/{id} [put]
 func (h *ProductHandler) UpdateProduct(c echo.Context) error {
@@ -123,7 +146,7 @@ func (h *ProductHandler) UpdateProduct(c echo.Context) error {
 
 	ctx := c.Request().Context()
 	if err := h.service.Update(ctx, &product); err != nil {
-		return c.JSON(http.StatusInternalServerError, model.Response{Message: err.Error()})
+		return serviceErrorResponse(c, err)
 	}
 
 	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: product})
@@ -131,11 +154,13 @@ func (h *ProductHandler) UpdateProduct(c echo.Context) error {
 
 // DeleteProduct handles deleting an product by ID
 // @Summary Delete an product by ID
-// @Description Remove an product from the system by its ID
+// @Description Remove an product from the system by its ID, only the creator of the product, managers of its shop and admins can delete it
 // @Tags products
 // @Param id path int true "Product ID"
 // @Success 204
 // @Failure 400 {object} model.Response
+// @Failure 403 {object} model.Response
+// @Failure 404 {object} model.Response
 // @Failure 500 {object} model.Response
 // @Router /products/{id} [delete]
 func (h *ProductHandler) DeleteProduct(c echo.Context) error {
@@ -146,8 +171,479 @@ func (h *ProductHandler) DeleteProduct(c echo.Context) error {
 
 	ctx := c.Request().Context()
 	if err := h.service.Delete(ctx, id); err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusNoContent, model.Response{Message: "success"})
+}
+
+// CreateCategory Create category
+// @Summary      Create Category
+// @Description  Create a category with the attributes its products must have, admin only
+// @Tags         categories
+// @Accept       json
+// @Produce      json
+// @Param category body model.Category true "Category details"
+// @Success      201  {object}  model.Response
+// @Failure      400  {object}  model.Response
+// @Failure      404  {object}  model.Response
+// @Failure      409  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /categories [post]
+func (h *ProductHandler) CreateCategory(c echo.Context) error {
+	var category model.Category
+	if err := c.Bind(&category); err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid input"})
+	}
+
+	ctx := c.Request().Context()
+	if err := h.service.CategoryServiceCreate(ctx, &category); err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, model.Response{Message: "success", Data: category})
+}
+
+// GetCategory handles fetching a category by ID
+// @Summary Get a category by ID
+// @Description Retrieve a category with its path and every attribute it defines or inherits
+// @Tags categories
+// @Produce json
+// @Param id path int true "Category ID"
+// @Success 200 {object}  model.Response
+// @Failure 400 {object}  model.Response
+// @Failure 404 {object}  model.Response
+// @Router /categories/{id} [get]
+func (h *ProductHandler) GetCategory(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+
+	ctx := c.Request().Context()
+	schema, err := h.service.CategoryServiceGet(ctx, id)
+	if err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: schema})
+}
+
+// GetCategoryTree handles fetching the category tree
+// @Summary Get the category tree
+// @Description Retrieve the root categories with their sub categories
+// @Tags categories
+// @Produce json
+// @Success 200 {object}  model.Response
+// @Failure 500 {object}  model.Response
+// @Router /categories [get]
+func (h *ProductHandler) GetCategoryTree(c echo.Context) error {
+	ctx := c.Request().Context()
+	tree, err := h.service.CategoryServiceGetTree(ctx)
+	if err != nil {
 		return c.JSON(http.StatusInternalServerError, model.Response{Message: err.Error()})
 	}
 
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: tree})
+}
+
+// UpdateCategory handles updating an existing category
+// @Summary Update an existing category
+// @Description Update category details, admin only
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param id path int true "Category ID"
+// @Param category body model.Category true "Category details"
+// @Success 200 {object} model.Response
+// @Failure 400 {object} model.Response
+// @Failure 404 {object} model.Response
+// @Failure 409 {object} model.Response
+// @Failure 500 {object} model.Response
+// @Router /categories/{id} [put]
+func (h *ProductHandler) UpdateCategory(c echo.Context) error {
+	var category model.Category
+	if err := c.Bind(&category); err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid input"})
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+	category.ID = id
+
+	ctx := c.Request().Context()
+	if err := h.service.CategoryServiceUpdate(ctx, &category); err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: category})
+}
+
+// DeleteCategory handles deleting a category by ID
+// @Summary Delete a category by ID
+// @Description Remove a category without sub categories or products, admin only
+// @Tags categories
+// @Param id path int true "Category ID"
+// @Success 204
+// @Failure 400 {object} model.Response
+// @Failure 404 {object} model.Response
+// @Failure 409 {object} model.Response
+// @Failure 500 {object} model.Response
+// @Router /categories/{id} [delete]
+func (h *ProductHandler) DeleteCategory(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+
+	ctx := c.Request().Context()
+	if err := h.service.CategoryServiceDelete(ctx, id); err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusNoContent, model.Response{Message: "success"})
+}
+
+// GetProductVariants handles fetching the variants of a product
+// @Summary Get the variants of a product
+// @Description Retrieve the variants of a product with their stock across every warehouse
+// @Tags products
+// @Produce json
+// @Param id path int true "Product ID"
+// @Success 200 {object}  model.Response
+// @Failure 400 {object}  model.Response
+// @Failure 404 {object}  model.Response
+// @Router /products/{id}/variants [get]
+func (h *ProductHandler) GetProductVariants(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+
+	ctx := c.Request().Context()
+	variants, err := h.service.VariantServiceGetByProductID(ctx, id)
+	if err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: variants})
+}
+
+// CreateProductVariant Create product variant
+// @Summary      Create Product Variant
+// @Description  Create a variant with its own SKU, its options must match the product category
+// @Tags         products
+// @Accept       json
+// @Produce      json
+// @Param id path int true "Product ID"
+// @Param variant body model.ProductVariant true "Variant details"
+// @Success      201  {object}  model.Response
+// @Failure      400  {object}  model.Response
+// @Failure      404  {object}  model.Response
+// @Failure      409  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /products/{id}/variants [post]
+func (h *ProductHandler) CreateProductVariant(c echo.Context) error {
+	var variant model.ProductVariant
+	if err := c.Bind(&variant); err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid input"})
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+	variant.ProductID = id
+
+	ctx := c.Request().Context()
+	if err := h.service.VariantServiceCreate(ctx, &variant); err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, model.Response{Message: "success", Data: variant})
+}
+
+// UpdateProductVariant handles updating an existing product variant
+// @Summary Update an existing product variant
+// @Description Update product variant details
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param variantID path int true "Variant ID"
+// @Param variant body model.ProductVariant true "Variant details"
+// @Success 200 {object} model.Response
+// @Failure 400 {object} model.Response
+// @Failure 404 {object} model.Response
+// @Failure 409 {object} model.Response
+// @Failure 500 {object} model.Response
+// @Router /products/{id}/variants/{variantID} [put]
+func (h *ProductHandler) UpdateProductVariant(c echo.Context) error {
+	var variant model.ProductVariant
+	if err := c.Bind(&variant); err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid input"})
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+	variantID, err := strconv.Atoi(c.Param("variantID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+	variant.ID = variantID
+	variant.ProductID = id
+
+	ctx := c.Request().Context()
+	if err := h.service.VariantServiceUpdate(ctx, &variant); err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: variant})
+}
+
+// DeleteProductVariant handles deleting a product variant
+// @Summary Delete a product variant
+// @Description Remove a product variant that is not listed in any shop
+// @Tags products
+// @Param id path int true "Product ID"
+// @Param variantID path int true "Variant ID"
+// @Success 204
+// @Failure 400 {object} model.Response
+// @Failure 404 {object} model.Response
+// @Failure 409 {object} model.Response
+// @Failure 500 {object} model.Response
+// @Router /products/{id}/variants/{variantID} [delete]
+func (h *ProductHandler) DeleteProductVariant(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+	variantID, err := strconv.Atoi(c.Param("variantID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+
+	ctx := c.Request().Context()
+	if err := h.service.VariantServiceDelete(ctx, id, variantID); err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
 	return c.JSON(http.StatusNoContent, model.Response{Message: "success"})
 }
+
+// SearchProducts handles searching shop listings
+// @Summary Search products
+// @Description Full-text search over product name, code and attributes of shop listings with facet counts
+// @Tags products
+// @Produce json
+// @Param q query string false "Search text"
+// @Param category_id query int false "Category ID, includes its sub categories"
+// @Param shop_id query int false "Shop ID"
+// @Param min_price query int false "Minimum price in minor units"
+// @Param max_price query int false "Maximum price in minor units"
+// @Param currency query string false "Currency of the price range"
+// @Param in_stock query bool false "Only listings in stock, or out of stock when false"
+// @Param sort query string false "relevance, price_asc, price_desc or newest"
+// @Param page query int false "Page, starts at 1"
+// @Param limit query int false "Hits per page, at most 100"
+// @Success 200 {object} model.Response
+// @Failure 400 {object} model.Response
+// @Failure 404 {object} model.Response
+// @Failure 500 {object} model.Response
+// @Router /search [get]
+func (h *ProductHandler) SearchProducts(c echo.Context) error {
+	var req model.ProductSearchRequest
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &req); err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid input"})
+	}
+
+	ctx := c.Request().Context()
+	result, err := h.service.Search(ctx, req)
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: result})
+}
+
+// UploadProductImage handles uploading the product image
+// @Summary Upload a product image
+// @Description Upload a JPEG, PNG or GIF image as multipart form file "image", a thumbnail is generated and the previous image is replaced
+// @Tags products
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param image formData file true "Image file"
+// @Success 200 {object} model.Response
+// @Failure 400 {object} model.Response
+// @Failure 404 {object} model.Response
+// @Failure 413 {object} model.Response
+// @Failure 415 {object} model.Response
+// @Failure 500 {object} model.Response
+// @Router /products/{id}/image [post]
+func (h *ProductHandler) UploadProductImage(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+
+	file, err := openImageUpload(c)
+	if err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+	defer file.Close()
+
+	ctx := c.Request().Context()
+	image, err := h.service.UploadImage(ctx, id, file)
+	if err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: image})
+}
+
+// ImportProducts handles importing products from a CSV or JSON Lines file
+// @Summary Import products
+// @Description Upsert products by code from multipart form file "file", small files are imported right away and large files in the background. Every invalid row is reported in the import job
+// @Tags products
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV or JSON Lines file"
+// @Param format query string false "csv or jsonl, taken from the file extension when empty"
+// @Success 201 {object} model.Response
+// @Success 202 {object} model.Response
+// @Failure 400 {object} model.Response
+// @Failure 413 {object} model.Response
+// @Failure 500 {object} model.Response
+// @Router /products/import [post]
+func (h *ProductHandler) ImportProducts(c echo.Context) error {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		if errors.Is(err, echo.ErrStatusRequestEntityTooLarge) {
+			return c.JSON(http.StatusRequestEntityTooLarge, model.Response{Message: util.ErrImportTooLarge})
+		}
+		return c.JSON(http.StatusBadRequest, model.Response{Message: util.ErrImportFileRequired})
+	}
+
+	format := strings.ToLower(c.QueryParam("format"))
+	if format == "" {
+		format = importFormatOf(fileHeader.Filename)
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: util.ErrImportFileRequired})
+	}
+	defer file.Close()
+
+	ctx := c.Request().Context()
+	job, err := h.service.ImportServiceCreate(ctx, format, file)
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	if job.Status != model.ImportStatusCompleted {
+		return c.JSON(http.StatusAccepted, model.Response{Message: "success", Data: job})
+	}
+	return c.JSON(http.StatusCreated, model.Response{Message: "success", Data: job})
+}
+
+// GetProductImport handles fetching the report of a product import
+// @Summary Get a product import
+// @Description Retrieve the status and per-row errors of a product import
+// @Tags products
+// @Produce json
+// @Param jobID path int true "Import job ID"
+// @Success 200 {object} model.Response
+// @Failure 400 {object} model.Response
+// @Failure 404 {object} model.Response
+// @Router /products/import/{jobID} [get]
+func (h *ProductHandler) GetProductImport(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("jobID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+
+	ctx := c.Request().Context()
+	job, err := h.service.ImportServiceGet(ctx, id)
+	if err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: job})
+}
+
+// ExportProducts handles exporting every product
+// @Summary Export products
+// @Description Download every product as CSV or JSON Lines, in the format accepted by the import
+// @Tags products
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Param format query string false "csv (default) or jsonl"
+// @Success 200 {file} file
+// @Failure 400 {object} model.Response
+// @Router /products/export [get]
+func (h *ProductHandler) ExportProducts(c echo.Context) error {
+	format := strings.ToLower(c.QueryParam("format"))
+	if format == "" {
+		format = model.ImportFormatCSV
+	}
+
+	contentType := "text/csv"
+	switch format {
+	case model.ImportFormatCSV:
+	case model.ImportFormatJSONL:
+		contentType = "application/x-ndjson"
+	default:
+		return c.JSON(http.StatusBadRequest, model.Response{Message: util.ErrInvalidImportFormat})
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, contentType)
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=products.%v", format))
+	res.WriteHeader(http.StatusOK)
+
+	// the status is already sent, a failed export ends with a truncated file
+	ctx := c.Request().Context()
+	return h.service.ImportServiceExport(ctx, format, res)
+}
+
+func importFormatOf(filename string) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return model.ImportFormatCSV
+	case ".jsonl", ".ndjson":
+		return model.ImportFormatJSONL
+	}
+	return ""
+}
+
+// GetProductStatusHistory handles fetching the status changes of a product
+// @Summary Get the status history of a product
+// @Description Retrieve who changed the status of a product and when, oldest first
+// @Tags products
+// @Produce json
+// @Param id path int true "Product ID"
+// @Success 200 {object} model.Response
+// @Failure 400 {object} model.Response
+// @Failure 403 {object} model.Response
+// @Failure 404 {object} model.Response
+// @Router /products/{id}/status-history [get]
+func (h *ProductHandler) GetProductStatusHistory(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+
+	ctx := c.Request().Context()
+	changes, err := h.service.GetStatusHistory(ctx, id)
+	if err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: changes})
+}
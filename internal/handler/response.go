@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"net/http"
+	"simcomm-monolith/internal/model"
+	"simcomm-monolith/util"
+
+	"github.com/labstack/echo/v4"
+)
+
+// errorStatus maps known service errors to their HTTP status code
+func errorStatus(err error) int {
+	switch err.Error() {
+	case util.ErrForbidden:
+		return http.StatusForbidden
+	case util.ErrUserNotFound, util.ErrShopNotFound, util.ErrShopProductNotFound, util.ErrAddressNotFound,
+		util.ErrUnknownOIDCProvider, util.ErrPriceNotFound, util.ErrCategoryNotFound,
+		util.ErrProductNotFound, util.ErrVariantNotFound, util.ErrWarehouseNotFound, util.ErrImportJobNotFound,
+		util.ErrOrderNotFound, util.ErrReviewNotFound, util.ErrShopMemberNotFound, util.ErrShopInvitationNotFound:
+		return http.StatusNotFound
+	case util.ErrInvalidRole, util.ErrInvalidVerificationCode, util.ErrInvalidResetToken, util.ErrInvalidPassword,
+		util.ErrShippingAddressRequired, util.ErrInvalidOIDCLogin, util.ErrInvalidTwoFactorCode, util.ErrInvalidLoginChallenge,
+		util.ErrTwoFactorAlreadyEnabled, util.ErrTwoFactorNotEnabled, util.ErrInvalidPrice, util.ErrUnsupportedCurrency,
+		util.ErrPriceNotSet, util.ErrCurrencyMismatch, util.ErrOrderItemsRequired, util.ErrInvalidOrderItem,
+		util.ErrOrderTotalTooLarge, util.ErrShopProductNotAvailable, util.ErrInvalidCategoryParent, util.ErrVariantRequired,
+		util.ErrInvalidImage, util.ErrImageRequired, util.ErrImportFileRequired, util.ErrInvalidImportFormat,
+		util.ErrInvalidStatus, util.ErrInvalidInitialStatus, util.ErrInvalidShopInvitation:
+		return http.StatusBadRequest
+	case util.ErrCategoryInUse, util.ErrCategorySlugExists, util.ErrVariantInUse, util.ErrProductListedWithoutVariant,
+		util.ErrSKUAlreadyExists, util.ErrDuplicateVariantOptions, util.ErrInvalidStatusTransition, util.ErrOrderNotCompleted,
+		util.ErrReviewAlreadyExists, util.ErrReviewAlreadyFlagged, util.ErrShopMemberAlreadyExists, util.ErrShopOwnerMembership:
+		return http.StatusConflict
+	case util.ErrContactNotVerified, util.ErrAccountDeactivated, util.ErrShopInvitationMismatch:
+		return http.StatusForbidden
+	case util.ErrImageTooLarge, util.ErrImportTooLarge:
+		return http.StatusRequestEntityTooLarge
+	case util.ErrUnsupportedImageType:
+		return http.StatusUnsupportedMediaType
+	case util.ErrTooManyLoginAttempts, util.ErrVerificationCooldown:
+		return http.StatusTooManyRequests
+	case util.ErrAccountLocked:
+		return http.StatusLocked
+	}
+	return http.StatusInternalServerError
+}
+
+// validationErrorResponse responds with every invalid field of a request
+func validationErrorResponse(c echo.Context, err error) error {
+	if errs, ok := err.(model.ValidationErrors); ok {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "validation failed", Data: errs})
+	}
+	return c.JSON(http.StatusBadRequest, model.Response{Message: err.Error()})
+}
+
+// serviceErrorResponse responds with the invalid fields of a validation error,
+// or with the status of a known service error
+func serviceErrorResponse(c echo.Context, err error) error {
+	if _, ok := err.(model.ValidationErrors); ok {
+		return validationErrorResponse(c, err)
+	}
+	return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+}
@@ -9,6 +9,7 @@ import (
 	"simcomm-monolith/config"
 	"simcomm-monolith/internal/repository"
 	"simcomm-monolith/internal/service"
+	"simcomm-monolith/migrations"
 	"simcomm-monolith/util"
 	"time"
 
@@ -26,6 +27,9 @@ import (
 // @description Swagger for User Service
 // @host localhost:6022
 // @BasePath /
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
 func SetupServer() {
 	// Echo instance
 	e := echo.New()
@@ -48,42 +52,71 @@ func SetupServer() {
 	e.GET("/health", health)
 
 	cfg := config.GetConfig()
+	ipExtractor, err := newIPExtractor(cfg.ServerConfig)
+	if err != nil {
+		log.Fatalf("invalid trusted proxies : %v", err)
+	}
+	e.IPExtractor = ipExtractor
+
 	if cfg.ServerConfig.Env == "dev" {
 		e.GET("/swagger/*", echoSwagger.WrapHandler)
 	}
 
 	db := util.GetDB(cfg)
+	if err := migrations.Migrate(db); err != nil {
+		log.Fatalf("failed to migrate the database : %v", err)
+	}
 	redisClient := util.GetRedisClient(cfg)
 	rabbitMQConnection := util.GetRabbitMQConnection(cfg.RabbitMQConfig)
 	tpQueue := repository.NewQueueDeclare(rabbitMQConnection, "transfer_product")
 	rtpQueue := repository.NewQueueDeclare(rabbitMQConnection, "revert_transfer_product")
+	importQueue := repository.NewQueueDeclare(rabbitMQConnection, "product_import")
 
 	var queues []repository.Queue
-	queues = append(queues, tpQueue, rtpQueue)
+	queues = append(queues, tpQueue, rtpQueue, importQueue)
 
 	userRepo := repository.NewPostgreUserRepository(db)
+	shopRepo := repository.NewPostgreShopRepository(db)
+	orderRepo := repository.NewPostgreOrderRepository(db)
 	redisRepo := repository.NewRedisRepository(redisClient, cfg)
-	svc := service.NewUserService(userRepo, redisRepo, cfg)
+	keySet := util.GetKeySet(cfg)
+	e.Use(AuthMiddleware(keySet, redisRepo))
+	e.Use(AuthorizationMiddleware())
+	e.Use(UploadBodyLimitMiddleware(cfg))
+	RegisterJWKSHandler(e, keySet)
+
+	notifier := repository.NewNotifier(cfg.NotifierConfig)
+	oidcProviders := repository.NewOIDCProviders(cfg.OIDCConfig)
+	svc := service.NewUserService(userRepo, shopRepo, orderRepo, redisRepo, notifier, oidcProviders, keySet, cfg)
 	RegisterUserHandler(e, svc)
 
+	storage := repository.NewStorage(cfg.StorageConfig)
+	if cfg.StorageConfig.Driver == "local" || cfg.StorageConfig.Driver == "" {
+		e.Static("/uploads/", cfg.StorageConfig.LocalPath)
+	}
+
 	productRepo := repository.NewPostgreProductRepository(db)
-	productSvc := service.NewProductService(productRepo, redisRepo, cfg)
+	productSvc := service.NewProductService(productRepo, shopRepo, redisRepo, storage, importQueue, cfg)
+	importQueue.AddReceiver(context.Background(), productSvc.ProcessImportQueue)
 	RegisterProductHandler(e, productSvc)
 
 	warehouseRepo := repository.NewPostgreWarehouseRepository(db)
-	warehouseSvc := service.NewWarehouseService(warehouseRepo, redisRepo, rtpQueue, cfg)
+	warehouseSvc := service.NewWarehouseService(warehouseRepo, shopRepo, redisRepo, storage, rtpQueue, cfg)
 	tpQueue.AddReceiver(context.Background(), warehouseSvc.ProcessTPQueue)
 	RegisterWarehouseHandler(e, warehouseSvc)
 
-	shopRepo := repository.NewPostgreShopRepository(db)
-	shopSvc := service.NewShopService(warehouseSvc, shopRepo, redisRepo, tpQueue, cfg)
+	shopSvc := service.NewShopService(warehouseSvc, shopRepo, productRepo, userRepo, redisRepo, storage, notifier, tpQueue, cfg)
 	rtpQueue.AddReceiver(context.Background(), shopSvc.ProcessRTPQueue)
+	go shopSvc.RunPriceScheduler(context.Background())
 	RegisterShopHandler(e, shopSvc)
 
-	orderRepo := repository.NewPostgreOrderRepository(db)
-	orderSvc := service.NewOrderService(orderRepo, redisRepo, cfg)
+	orderSvc := service.NewOrderService(orderRepo, userRepo, shopRepo, productRepo, redisRepo, cfg)
 	RegisterOrderHandler(e, orderSvc)
 
+	reviewRepo := repository.NewPostgreReviewRepository(db)
+	reviewSvc := service.NewReviewService(reviewRepo, orderRepo, shopRepo, productRepo, cfg)
+	RegisterReviewHandler(e, reviewSvc)
+
 	// Start server
 	// e.Logger.Fatal(e.Start(fmt.Sprintf("%v", cfg.ServerConfig.Host) + ":" + fmt.Sprintf("%v", cfg.ServerConfig.Port)))
 
@@ -0,0 +1,275 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"simcomm-monolith/internal/model"
+	"simcomm-monolith/internal/service"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ReviewHandler struct {
+	service service.ReviewService
+}
+
+func RegisterReviewHandler(e *echo.Echo, svc service.ReviewService) {
+	handler := &ReviewHandler{
+		service: svc,
+	}
+	e.GET("products/:id/reviews", handler.GetProductReviews)
+	e.GET("products/:id/rating", handler.GetProductRating)
+	e.GET("shops/:id/rating", handler.GetShopRating)
+
+	e.POST("reviews", handler.CreateReview)
+	e.GET("reviews/flagged", handler.GetFlaggedReviews)
+	e.PUT("reviews/:id/reply", handler.ReplyReview)
+	e.POST("reviews/:id/flags", handler.FlagReview)
+	e.PUT("reviews/:id/moderation", handler.ModerateReview)
+}
+
+func NewReviewHandler(service service.ReviewService) *ReviewHandler {
+	return &ReviewHandler{service: service}
+}
+
+// CreateReview handles reviewing a line of a completed order
+// @Summary Create Review
+// @Description Review a line of a completed order of the authenticated user, each line is reviewed once
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param review body model.ReviewRequest true "Order line, rating from 1 to 5 and comment"
+// @Success 201 {object} model.Response
+// @Failure 400 {object} model.Response
+// @Failure 403 {object} model.Response
+// @Failure 404 {object} model.Response
+// @Failure 409 {object} model.Response
+// @Router /reviews [post]
+func (h *ReviewHandler) CreateReview(c echo.Context) error {
+	var req model.ReviewRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid input"})
+	}
+
+	ctx := c.Request().Context()
+	review, err := h.service.Create(ctx, req)
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, model.Response{Message: "success", Data: review})
+}
+
+// GetProductReviews handles listing the reviews of a product
+// @Summary Get the reviews of a product
+// @Description List the reviews of a product with its rating, hidden reviews are left out
+// @Tags reviews
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param shop_id query int false "Shop ID"
+// @Param variant_id query int false "Variant ID"
+// @Param rating query int false "Only reviews with this rating"
+// @Param min_rating query int false "Only reviews with at least this rating"
+// @Param with_comment query bool false "Only reviews with a comment, or without when false"
+// @Param with_reply query bool false "Only reviews with a reply, or without when false"
+// @Param sort query string false "newest, oldest, rating_desc or rating_asc"
+// @Param page query int false "Page, starts at 1"
+// @Param limit query int false "Reviews per page, at most 100"
+// @Success 200 {object} model.Response
+// @Failure 400 {object} model.Response
+// @Failure 404 {object} model.Response
+// @Router /products/{id}/reviews [get]
+func (h *ReviewHandler) GetProductReviews(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+
+	var req model.ReviewListRequest
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &req); err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid input"})
+	}
+	req.ProductID = id
+
+	ctx := c.Request().Context()
+	reviews, err := h.service.GetByProductID(ctx, req)
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: reviews})
+}
+
+// GetProductRating handles fetching the rating of a product
+// @Summary Get the rating of a product
+// @Description Average rating and number of reviews per rating of a product across every shop
+// @Tags reviews
+// @Produce json
+// @Param id path int true "Product ID"
+// @Success 200 {object} model.Response
+// @Failure 400 {object} model.Response
+// @Failure 404 {object} model.Response
+// @Router /products/{id}/rating [get]
+func (h *ReviewHandler) GetProductRating(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+
+	ctx := c.Request().Context()
+	rating, err := h.service.GetProductRating(ctx, id)
+	if err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: rating})
+}
+
+// GetShopRating handles fetching the rating of a shop
+// @Summary Get the rating of a shop
+// @Description Average rating and number of reviews per rating of every product sold by a shop
+// @Tags reviews
+// @Produce json
+// @Param id path int true "Shop ID"
+// @Success 200 {object} model.Response
+// @Failure 400 {object} model.Response
+// @Failure 404 {object} model.Response
+// @Router /shops/{id}/rating [get]
+func (h *ReviewHandler) GetShopRating(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+
+	ctx := c.Request().Context()
+	rating, err := h.service.GetShopRating(ctx, id)
+	if err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: rating})
+}
+
+// ReplyReview handles the reply of a shop to a review
+// @Summary Reply to a review
+// @Description Set the reply of the shop the review is written for, a new reply replaces the previous one
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param id path int true "Review ID"
+// @Param reply body model.ReviewReplyRequest true "Reply"
+// @Success 200 {object} model.Response
+// @Failure 400 {object} model.Response
+// @Failure 403 {object} model.Response
+// @Failure 404 {object} model.Response
+// @Router /reviews/{id}/reply [put]
+func (h *ReviewHandler) ReplyReview(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+
+	var req model.ReviewReplyRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid input"})
+	}
+
+	ctx := c.Request().Context()
+	review, err := h.service.Reply(ctx, id, req)
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: review})
+}
+
+// FlagReview handles flagging a review for moderation
+// @Summary Flag a review
+// @Description Report a review for moderation, it is hidden once it reaches the configured number of flags
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param id path int true "Review ID"
+// @Param flag body model.ReviewFlagRequest true "Reason"
+// @Success 201 {object} model.Response
+// @Failure 400 {object} model.Response
+// @Failure 404 {object} model.Response
+// @Failure 409 {object} model.Response
+// @Router /reviews/{id}/flags [post]
+func (h *ReviewHandler) FlagReview(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+
+	var req model.ReviewFlagRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid input"})
+	}
+
+	ctx := c.Request().Context()
+	review, err := h.service.Flag(ctx, id, req)
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, model.Response{Message: "success", Data: review})
+}
+
+// GetFlaggedReviews handles listing the reviews waiting for moderation
+// @Summary Get flagged reviews
+// @Description List the flagged and hidden reviews that have not been moderated, the most flagged first
+// @Tags reviews
+// @Produce json
+// @Param page query int false "Page, starts at 1"
+// @Param limit query int false "Reviews per page, at most 100"
+// @Success 200 {object} model.Response
+// @Failure 400 {object} model.Response
+// @Router /reviews/flagged [get]
+func (h *ReviewHandler) GetFlaggedReviews(c echo.Context) error {
+	var req model.ReviewListRequest
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &req); err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid input"})
+	}
+
+	ctx := c.Request().Context()
+	reviews, err := h.service.GetFlagged(ctx, req)
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: reviews})
+}
+
+// ModerateReview handles publishing or hiding a review
+// @Summary Moderate a review
+// @Description Publish a flagged review again or hide it, its flags are cleared
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param id path int true "Review ID"
+// @Param moderation body model.ReviewModerationRequest true "published or hidden"
+// @Success 200 {object} model.Response
+// @Failure 400 {object} model.Response
+// @Failure 404 {object} model.Response
+// @Router /reviews/{id}/moderation [put]
+func (h *ReviewHandler) ModerateReview(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+
+	var req model.ReviewModerationRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid input"})
+	}
+
+	ctx := c.Request().Context()
+	review, err := h.service.Moderate(ctx, id, req)
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: review})
+}
@@ -23,12 +23,28 @@ func RegisterShopHandler(e *echo.Echo, svc service.ShopService) {
 	e.GET("shops/:id", handler.GetShop)
 	e.PUT("shops/:id", handler.UpdateShop)
 	e.DELETE("shops/:id", handler.DeleteShop)
-
+	e.POST("shops/:id/image", handler.UploadShopImage)
+	e.GET("shops/:id/status-history", handler.GetShopStatusHistory)
+	e.GET("storefront/:shopID", handler.GetStorefront)
+
+	e.GET("shops/:id/members", handler.GetShopMembers)
+	e.PUT("shops/:id/members/:userID", handler.UpdateShopMemberRole)
+	e.DELETE("shops/:id/members/:userID", handler.DeleteShopMember)
+	e.GET("shops/:id/invitations", handler.GetShopInvitations)
+	e.POST("shops/:id/invitations", handler.InviteShopMember)
+	e.DELETE("shops/:id/invitations/:invitationID", handler.RevokeShopInvitation)
+	e.POST("shops/invitations/accept", handler.AcceptShopInvitation)
+
+	e.GET("shops/:id/products", handler.GetShopProductsByShop)
 	e.GET("shop-products", handler.GetAllShopProducts)
 	e.POST("shop-products", handler.CreateShopProduct)
 	e.GET("shop-products/:id", handler.GetShopProduct)
 	e.PUT("shop-products/:id", handler.UpdateShopProduct)
 	e.DELETE("shop-products/:id", handler.DeleteShopProduct)
+	e.GET("shop-products/:id/status-history", handler.GetShopProductStatusHistory)
+	e.GET("shop-products/:id/prices", handler.GetShopProductPrices)
+	e.POST("shop-products/:id/prices", handler.SetShopProductPrice)
+	e.DELETE("shop-products/:id/prices/:priceID", handler.CancelShopProductPrice)
 
 	e.POST("shops/transfer", handler.CreateTransferProduct)
 }
@@ -37,6 +53,77 @@ func NewShopHandler(service service.ShopService) *ShopHandler {
 	return &ShopHandler{service: service}
 }
 
+// GetShopProductsByShop handles listing the shop products of a shop
+// @Summary Get the shop products of a shop
+// @Description List the shop products of a shop, users who are not members of the shop only see the active ones
+// @Tags shop-products
+// @Produce json
+// @Param id path int true "Shop ID"
+// @Param status query string false "draft, active, suspended or archived"
+// @Param product_id query int false "Product ID"
+// @Param variant_id query int false "Variant ID"
+// @Param in_stock query bool false "Only shop products in stock, or out of stock when false"
+// @Param page query int false "Page, starts at 1"
+// @Param limit query int false "Shop products per page, at most 100"
+// @Success 200 {object} model.Response
+// @Failure 400 {object} model.Response
+// @Failure 403 {object} model.Response
+// @Failure 404 {object} model.Response
+// @Router /shops/{id}/products [get]
+func (h *ShopHandler) GetShopProductsByShop(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+
+	var req model.ShopProductListRequest
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &req); err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid input"})
+	}
+	req.ShopID = id
+
+	ctx := c.Request().Context()
+	shopProducts, err := h.service.ShopProductServiceGetByShopID(ctx, req)
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: shopProducts})
+}
+
+// GetStorefront handles the public view of a shop
+// @Summary Get the storefront of a shop
+// @Description Public view of an active shop with its active listings, their products and sellable stock, newest first
+// @Tags shops
+// @Produce json
+// @Param shopID path int true "Shop ID"
+// @Param page query int false "Page, starts at 1"
+// @Param limit query int false "Listings per page, at most 100"
+// @Success 200 {object} model.Response
+// @Failure 400 {object} model.Response
+// @Failure 404 {object} model.Response
+// @Router /storefront/{shopID} [get]
+func (h *ShopHandler) GetStorefront(c echo.Context) error {
+	shopID, err := strconv.Atoi(c.Param("shopID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+
+	var req model.StorefrontRequest
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &req); err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid input"})
+	}
+	req.ShopID = shopID
+
+	ctx := c.Request().Context()
+	storefront, err := h.service.GetStorefront(ctx, req)
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: storefront})
+}
+
 // CreateShop Create shop
 // @Summary      Create Shop
 // @Description  Create Shop
@@ -56,7 +143,7 @@ func (h *ShopHandler) CreateShop(c echo.Context) error {
 
 	ctx := c.Request().Context()
 	if err := h.service.Create(ctx, &shop); err != nil {
-		return c.JSON(http.StatusInternalServerError, model.Response{Message: err.Error()})
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
 	}
 
 	return c.JSON(http.StatusCreated, model.Response{Message: "success", Data: shop})
@@ -99,7 +186,7 @@ func (h *ShopHandler) GetAllShops(c echo.Context) error {
 	ctx := c.Request().Context()
 	shops, err := h.service.GetAll(ctx)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, model.Response{Message: err.Error()})
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
 	}
 
 	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: shops})
@@ -131,7 +218,7 @@ func (h *ShopHandler) UpdateShop(c echo.Context) error {
 
 	ctx := c.Request().Context()
 	if err := h.service.Update(ctx, &shop); err != nil {
-		return c.JSON(http.StatusInternalServerError, model.Response{Message: err.Error()})
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
 	}
 
 	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: shop})
@@ -154,7 +241,7 @@ func (h *ShopHandler) DeleteShop(c echo.Context) error {
 
 	ctx := c.Request().Context()
 	if err := h.service.Delete(ctx, id); err != nil {
-		return c.JSON(http.StatusInternalServerError, model.Response{Message: err.Error()})
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
 	}
 
 	return c.JSON(http.StatusNoContent, model.Response{Message: "success"})
@@ -179,7 +266,7 @@ func (h *ShopHandler) CreateShopProduct(c echo.Context) error {
 
 	ctx := c.Request().Context()
 	if err := h.service.ShopProductServiceCreate(ctx, &shopproduct); err != nil {
-		return c.JSON(http.StatusInternalServerError, model.Response{Message: err.Error()})
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
 	}
 
 	return c.JSON(http.StatusCreated, model.Response{Message: "success", Data: shopproduct})
@@ -204,7 +291,7 @@ func (h *ShopHandler) GetShopProduct(c echo.Context) error {
 	ctx := c.Request().Context()
 	shopproduct, err := h.service.ShopProductServiceGet(ctx, id)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, model.Response{Message: "ShopProduct not found"})
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
 	}
 
 	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: shopproduct})
@@ -212,17 +299,19 @@ func (h *ShopHandler) GetShopProduct(c echo.Context) error {
 
 // GetAllShopProducts handles fetching all shopproduct
 // @Summary Get all shopproduct
-// @Description Retrieve all shopproduct in the system
+// @Description Retrieve all shopproduct in the system, admin only. Use GET /shops/{id}/products for the listings of a shop
 // @Tags shopproducts
 // @Produce json
 // @Success 200 {object}  model.Response
+// @Failure 403 {object}  model.Response
 // @Failure 500 {object}  model.Response
+// @Deprecated
 // @Router /shop-products [get]
 func (h *ShopHandler) GetAllShopProducts(c echo.Context) error {
 	ctx := c.Request().Context()
 	shopproducts, err := h.service.ShopProductServiceGetAll(ctx)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, model.Response{Message: err.Error()})
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
 	}
 
 	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: shopproducts})
@@ -254,7 +343,7 @@ func (h *ShopHandler) UpdateShopProduct(c echo.Context) error {
 
 	ctx := c.Request().Context()
 	if err := h.service.ShopProductServiceUpdate(ctx, &shopproduct); err != nil {
-		return c.JSON(http.StatusInternalServerError, model.Response{Message: err.Error()})
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
 	}
 
 	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: shopproduct})
@@ -277,7 +366,7 @@ func (h *ShopHandler) DeleteShopProduct(c echo.Context) error {
 
 	ctx := c.Request().Context()
 	if err := h.service.ShopProductServiceDelete(ctx, id); err != nil {
-		return c.JSON(http.StatusInternalServerError, model.Response{Message: err.Error()})
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
 	}
 
 	return c.JSON(http.StatusNoContent, model.Response{Message: "success"})
@@ -301,8 +390,396 @@ func (h *ShopHandler) CreateTransferProduct(c echo.Context) error {
 	}
 
 	if err := h.service.CreateTransferProduct(ctx, &transferProduct); err != nil {
-		return c.JSON(http.StatusInternalServerError, model.Response{Message: err.Error()})
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
 	}
 
 	return c.JSON(http.StatusOK, model.Response{Message: "success"})
 }
+
+// GetShopProductPrices handles fetching the prices of a shopproduct
+// @Summary Get the prices of a shopproduct
+// @Description Retrieve the current price, scheduled price changes and price history of a shopproduct, in minor units
+// @Tags shopproducts
+// @Produce json
+// @Param id path int true "ShopProduct ID"
+// @Success 200 {object}  model.Response
+// @Failure 400 {object}  model.Response
+// @Failure 404 {object}  model.Response
+// @Router /shop-products/{id}/prices [get]
+func (h *ShopHandler) GetShopProductPrices(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+
+	ctx := c.Request().Context()
+	prices, err := h.service.ShopProductServiceGetPrices(ctx, id)
+	if err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: prices})
+}
+
+// SetShopProductPrice handles changing the price of a shopproduct
+// @Summary Change the price of a shopproduct
+// @Description Change the price now, or schedule the change when effective_at is in the future
+// @Tags shopproducts
+// @Accept json
+// @Produce json
+// @Param id path int true "ShopProduct ID"
+// @Param price body model.ShopProductPriceRequest true "Price in minor units"
+// @Success 201 {object} model.Response
+// @Failure 400 {object} model.Response
+// @Failure 404 {object} model.Response
+// @Failure 500 {object} model.Response
+// @Router /shop-products/{id}/prices [post]
+func (h *ShopHandler) SetShopProductPrice(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+
+	var req model.ShopProductPriceRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid input"})
+	}
+	if err := req.Validate(); err != nil {
+		return validationErrorResponse(c, err)
+	}
+
+	ctx := c.Request().Context()
+	price, err := h.service.ShopProductServiceSetPrice(ctx, id, req)
+	if err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, model.Response{Message: "success", Data: price})
+}
+
+// CancelShopProductPrice handles cancelling a scheduled price change
+// @Summary Cancel a scheduled price change
+// @Description Remove a price change that is not effective yet
+// @Tags shopproducts
+// @Param id path int true "ShopProduct ID"
+// @Param priceID path int true "Price ID"
+// @Success 204
+// @Failure 400 {object} model.Response
+// @Failure 404 {object} model.Response
+// @Failure 500 {object} model.Response
+// @Router /shop-products/{id}/prices/{priceID} [delete]
+func (h *ShopHandler) CancelShopProductPrice(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+	priceID, err := strconv.Atoi(c.Param("priceID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+
+	ctx := c.Request().Context()
+	if err := h.service.ShopProductServiceCancelPrice(ctx, id, priceID); err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusNoContent, model.Response{Message: "success"})
+}
+
+// UploadShopImage handles uploading the shop image
+// @Summary Upload a shop image
+// @Description Upload a JPEG, PNG or GIF image as multipart form file "image", a thumbnail is generated and the previous image is replaced
+// @Tags shops
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Shop ID"
+// @Param image formData file true "Image file"
+// @Success 200 {object} model.Response
+// @Failure 400 {object} model.Response
+// @Failure 404 {object} model.Response
+// @Failure 413 {object} model.Response
+// @Failure 415 {object} model.Response
+// @Failure 500 {object} model.Response
+// @Router /shops/{id}/image [post]
+func (h *ShopHandler) UploadShopImage(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+
+	file, err := openImageUpload(c)
+	if err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+	defer file.Close()
+
+	ctx := c.Request().Context()
+	image, err := h.service.UploadImage(ctx, id, file)
+	if err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: image})
+}
+
+// GetShopStatusHistory handles fetching the status changes of a shop
+// @Summary Get the status history of a shop
+// @Description Retrieve who changed the status of a shop and when, oldest first
+// @Tags shops
+// @Produce json
+// @Param id path int true "Shop ID"
+// @Success 200 {object} model.Response
+// @Failure 400 {object} model.Response
+// @Failure 403 {object} model.Response
+// @Failure 404 {object} model.Response
+// @Router /shops/{id}/status-history [get]
+func (h *ShopHandler) GetShopStatusHistory(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+
+	ctx := c.Request().Context()
+	changes, err := h.service.GetStatusHistory(ctx, id)
+	if err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: changes})
+}
+
+// GetShopProductStatusHistory handles fetching the status changes of a shop product
+// @Summary Get the status history of a shop product
+// @Description Retrieve who changed the status of a shop product and when, oldest first
+// @Tags shops
+// @Produce json
+// @Param id path int true "ShopProduct ID"
+// @Success 200 {object} model.Response
+// @Failure 400 {object} model.Response
+// @Failure 403 {object} model.Response
+// @Failure 404 {object} model.Response
+// @Router /shop-products/{id}/status-history [get]
+func (h *ShopHandler) GetShopProductStatusHistory(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+
+	ctx := c.Request().Context()
+	changes, err := h.service.ShopProductServiceGetStatusHistory(ctx, id)
+	if err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: changes})
+}
+
+// GetShopMembers handles listing the members of a shop
+// @Summary Get the members of a shop
+// @Description List the users with access to a shop and their role, any member can list them
+// @Tags shops
+// @Produce json
+// @Param id path int true "Shop ID"
+// @Success 200 {object} model.Response
+// @Failure 400 {object} model.Response
+// @Failure 403 {object} model.Response
+// @Failure 404 {object} model.Response
+// @Router /shops/{id}/members [get]
+func (h *ShopHandler) GetShopMembers(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+
+	ctx := c.Request().Context()
+	members, err := h.service.ShopMemberServiceGetAll(ctx, id)
+	if err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: members})
+}
+
+// UpdateShopMemberRole handles changing the role of a shop member
+// @Summary Change the role of a shop member
+// @Description Make a member manager or staff, the owner manages managers and staff, a manager manages staff
+// @Tags shops
+// @Accept json
+// @Produce json
+// @Param id path int true "Shop ID"
+// @Param userID path int true "User ID"
+// @Param role body model.ShopMemberRoleRequest true "manager or staff"
+// @Success 200 {object} model.Response
+// @Failure 400 {object} model.Response
+// @Failure 403 {object} model.Response
+// @Failure 404 {object} model.Response
+// @Failure 409 {object} model.Response
+// @Router /shops/{id}/members/{userID} [put]
+func (h *ShopHandler) UpdateShopMemberRole(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+	userID, err := strconv.Atoi(c.Param("userID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+
+	var req model.ShopMemberRoleRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid input"})
+	}
+
+	ctx := c.Request().Context()
+	member, err := h.service.ShopMemberServiceUpdateRole(ctx, id, userID, req)
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: member})
+}
+
+// DeleteShopMember handles removing a member from a shop
+// @Summary Remove a shop member
+// @Description Remove a member ranked below the authenticated member, members other than the owner can also leave
+// @Tags shops
+// @Param id path int true "Shop ID"
+// @Param userID path int true "User ID"
+// @Success 204 {object} model.Response
+// @Failure 400 {object} model.Response
+// @Failure 403 {object} model.Response
+// @Failure 404 {object} model.Response
+// @Failure 409 {object} model.Response
+// @Router /shops/{id}/members/{userID} [delete]
+func (h *ShopHandler) DeleteShopMember(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+	userID, err := strconv.Atoi(c.Param("userID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+
+	ctx := c.Request().Context()
+	if err := h.service.ShopMemberServiceDelete(ctx, id, userID); err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusNoContent, model.Response{Message: "success"})
+}
+
+// GetShopInvitations handles listing the pending invitations of a shop
+// @Summary Get the invitations of a shop
+// @Description List the invitations that are not accepted or revoked yet, expired ones included
+// @Tags shops
+// @Produce json
+// @Param id path int true "Shop ID"
+// @Success 200 {object} model.Response
+// @Failure 400 {object} model.Response
+// @Failure 403 {object} model.Response
+// @Failure 404 {object} model.Response
+// @Router /shops/{id}/invitations [get]
+func (h *ShopHandler) GetShopInvitations(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+
+	ctx := c.Request().Context()
+	invitations, err := h.service.ShopMemberServiceGetInvitations(ctx, id)
+	if err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: invitations})
+}
+
+// InviteShopMember handles inviting an user to a shop
+// @Summary Invite a shop member
+// @Description Send an invitation token to an email or phone, the owner invites managers and staff, a manager invites staff
+// @Tags shops
+// @Accept json
+// @Produce json
+// @Param id path int true "Shop ID"
+// @Param invitation body model.ShopInvitationRequest true "Email or phone and role"
+// @Success 201 {object} model.Response
+// @Failure 400 {object} model.Response
+// @Failure 403 {object} model.Response
+// @Failure 404 {object} model.Response
+// @Router /shops/{id}/invitations [post]
+func (h *ShopHandler) InviteShopMember(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+
+	var req model.ShopInvitationRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid input"})
+	}
+
+	ctx := c.Request().Context()
+	invitation, err := h.service.ShopMemberServiceInvite(ctx, id, req)
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, model.Response{Message: "success", Data: invitation})
+}
+
+// RevokeShopInvitation handles revoking a pending invitation
+// @Summary Revoke a shop invitation
+// @Description Revoke an invitation that is not accepted yet
+// @Tags shops
+// @Param id path int true "Shop ID"
+// @Param invitationID path int true "Invitation ID"
+// @Success 204 {object} model.Response
+// @Failure 400 {object} model.Response
+// @Failure 403 {object} model.Response
+// @Failure 404 {object} model.Response
+// @Router /shops/{id}/invitations/{invitationID} [delete]
+func (h *ShopHandler) RevokeShopInvitation(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+	invitationID, err := strconv.Atoi(c.Param("invitationID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+
+	ctx := c.Request().Context()
+	if err := h.service.ShopMemberServiceRevokeInvitation(ctx, id, invitationID); err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusNoContent, model.Response{Message: "success"})
+}
+
+// AcceptShopInvitation handles joining a shop with an invitation token
+// @Summary Accept a shop invitation
+// @Description Join the shop with the token sent to a verified email or phone of the authenticated user. The membership gives access to the shop only, the roles of the user stay the same
+// @Tags shops
+// @Accept json
+// @Produce json
+// @Param invitation body model.AcceptShopInvitationRequest true "Invitation token"
+// @Success 201 {object} model.Response
+// @Failure 400 {object} model.Response
+// @Failure 403 {object} model.Response
+// @Failure 409 {object} model.Response
+// @Router /shops/invitations/accept [post]
+func (h *ShopHandler) AcceptShopInvitation(c echo.Context) error {
+	var req model.AcceptShopInvitationRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid input"})
+	}
+
+	ctx := c.Request().Context()
+	member, err := h.service.ShopMemberServiceAcceptInvitation(ctx, req)
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, model.Response{Message: "success", Data: member})
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"simcomm-monolith/config"
+	"simcomm-monolith/util"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// multipartOverhead is the room left for the multipart framing and the other
+// form fields around the uploaded file
+const multipartOverhead = 64 * 1024
+
+// UploadBodyLimitMiddleware caps the body of the upload routes before the
+// multipart form is parsed, so an oversized upload is never buffered to disk
+func UploadBodyLimitMiddleware(cfg *config.Config) echo.MiddlewareFunc {
+	imageLimit := middleware.BodyLimit(fmt.Sprint(cfg.StorageConfig.MaxUploadSize + multipartOverhead))
+	bodyLimits := map[string]echo.MiddlewareFunc{
+		"/products/:id/image":   imageLimit,
+		"/shops/:id/image":      imageLimit,
+		"/warehouses/:id/image": imageLimit,
+		"/products/import":      middleware.BodyLimit(fmt.Sprint(cfg.ProductImportConfig.MaxFileSize + multipartOverhead)),
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			bodyLimit, ok := bodyLimits[c.Path()]
+			if !ok || c.Request().Method != http.MethodPost {
+				return next(c)
+			}
+			return bodyLimit(next)(c)
+		}
+	}
+}
+
+// openImageUpload opens the "image" file of a multipart form, the service
+// checks its size and content
+func openImageUpload(c echo.Context) (multipart.File, error) {
+	fileHeader, err := c.FormFile("image")
+	if err != nil {
+		if errors.Is(err, echo.ErrStatusRequestEntityTooLarge) {
+			return nil, errors.New(util.ErrImageTooLarge)
+		}
+		if errors.Is(err, http.ErrMissingFile) || errors.Is(err, http.ErrNotMultipart) {
+			return nil, errors.New(util.ErrImageRequired)
+		}
+		return nil, errors.New(util.ErrInvalidImage)
+	}
+	return fileHeader.Open()
+}
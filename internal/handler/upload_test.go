@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"simcomm-monolith/config"
+	"simcomm-monolith/internal/model"
+	"simcomm-monolith/util"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// multipartBody returns a form with a file of the given size under the field name
+func multipartBody(t *testing.T, field string, size int) (*bytes.Buffer, string) {
+	t.Helper()
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile(field, "upload.bin")
+	require.NoError(t, err)
+	_, err = part.Write(bytes.Repeat([]byte("x"), size))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	return body, writer.FormDataContentType()
+}
+
+func TestUploadBodyLimitMiddleware(t *testing.T) {
+	cfg := &config.Config{
+		StorageConfig:       config.StorageConfig{MaxUploadSize: 1024},
+		ProductImportConfig: config.ProductImportConfig{MaxFileSize: 4 * 1024},
+	}
+	oversized := 2*multipartOverhead + 1024
+
+	tests := []struct {
+		name        string
+		path        string
+		field       string
+		size        int
+		chunked     bool
+		wantStatus  int
+		wantMessage string
+	}{
+		{"image within the limit", "/products/:id/image", "image", 1024, false, http.StatusOK, ""},
+		{"oversized image", "/shops/:id/image", "image", oversized, false, http.StatusRequestEntityTooLarge, ""},
+		{"oversized chunked image", "/warehouses/:id/image", "image", oversized, true, http.StatusRequestEntityTooLarge, util.ErrImageTooLarge},
+		{"import within the limit", "/products/import", "file", 4 * 1024, false, http.StatusOK, ""},
+		{"oversized import", "/products/import", "file", oversized + 4*1024, false, http.StatusRequestEntityTooLarge, ""},
+		{"other routes are not limited", "/products", "file", oversized, false, http.StatusOK, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, contentType := multipartBody(t, tt.field, tt.size)
+			var reader io.Reader = body
+			if tt.chunked {
+				reader = io.MultiReader(body)
+			}
+			req := httptest.NewRequest(http.MethodPost, "/upload", reader)
+			req.Header.Set(echo.HeaderContentType, contentType)
+			if tt.chunked {
+				req.ContentLength = -1
+			}
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+			c.SetPath(tt.path)
+
+			handler := UploadBodyLimitMiddleware(cfg)(func(c echo.Context) error {
+				file, err := openImageUpload(c)
+				if err != nil {
+					if err.Error() == util.ErrImageRequired {
+						return c.NoContent(http.StatusOK)
+					}
+					return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+				}
+				file.Close()
+				return c.NoContent(http.StatusOK)
+			})
+			err := handler(c)
+			if err != nil {
+				e.HTTPErrorHandler(err, c)
+			}
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantMessage != "" {
+				assert.Contains(t, rec.Body.String(), tt.wantMessage)
+			}
+		})
+	}
+}
@@ -1,11 +1,13 @@
 package handler
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
 
 	"simcomm-monolith/internal/model"
 	"simcomm-monolith/internal/service"
+	"simcomm-monolith/util"
 
 	"github.com/labstack/echo/v4"
 )
@@ -18,14 +20,42 @@ func RegisterUserHandler(e *echo.Echo, svc service.UserService) {
 	handler := &UserHandler{
 		service: svc,
 	}
+	e.GET("users/me", handler.GetProfile)
+	e.PATCH("users/me", handler.UpdateProfile)
+	e.DELETE("users/me", handler.EraseProfile)
+	e.POST("users/me/deactivate", handler.DeactivateProfile)
+	e.GET("users/me/export", handler.ExportProfile)
 	e.GET("users", handler.GetAllUsers)
 	e.POST("users", handler.CreateUser)
 	e.GET("users/:id", handler.GetUser)
 	e.PUT("users/:id", handler.UpdateUser)
 	e.DELETE("users/:id", handler.DeleteUser)
+	e.POST("users/:id/roles", handler.GrantRole)
+	e.DELETE("users/:id/roles/:role", handler.RevokeRole)
+	e.POST("users/:id/unlock", handler.UnlockUser)
+	e.POST("users/:id/deactivate", handler.DeactivateUser)
+	e.POST("users/:id/reactivate", handler.ReactivateUser)
 
 	e.POST("ecommerce/login", handler.Login)
+	e.POST("ecommerce/login/2fa", handler.LoginTwoFactor)
 	e.POST("ecommerce/signup", handler.SignUp)
+	e.POST("ecommerce/logout", handler.Logout)
+	e.POST("ecommerce/token/refresh", handler.RefreshToken)
+	e.POST("ecommerce/verification/send", handler.SendVerification)
+	e.POST("ecommerce/verification/verify", handler.VerifyContact)
+	e.POST("ecommerce/password/forgot", handler.ForgotPassword)
+	e.POST("ecommerce/password/reset", handler.ResetPassword)
+	e.GET("ecommerce/oidc/:provider/login", handler.OIDCLogin)
+	e.GET("ecommerce/oidc/:provider/callback", handler.OIDCCallback)
+	e.PUT("users/me/password", handler.ChangePassword)
+	e.POST("users/me/2fa/totp", handler.EnrollTOTP)
+	e.POST("users/me/2fa/totp/confirm", handler.ConfirmTOTP)
+	e.DELETE("users/me/2fa/totp", handler.DisableTOTP)
+	e.POST("users/me/2fa/recovery-codes", handler.RegenerateRecoveryCodes)
+	e.GET("users/me/addresses", handler.GetAddresses)
+	e.POST("users/me/addresses", handler.AddAddress)
+	e.PUT("users/me/addresses/:addressID", handler.UpdateAddress)
+	e.DELETE("users/me/addresses/:addressID", handler.DeleteAddress)
 }
 
 func NewUserHandler(service service.UserService) *UserHandler {
@@ -38,23 +68,28 @@ func NewUserHandler(service service.UserService) *UserHandler {
 // @Tags         users
 // @Accept       json
 // @Produce      json
-// @Param user body model.User true "User details"
+// @Param user body model.UserRequest true "User details"
 // @Success      201  {object}  model.Response
 // @Failure      400  {object}  model.Response
 // @Failure      500  {object}  model.Response
 // @Router       /users [post]
 func (h *UserHandler) CreateUser(c echo.Context) error {
-	var user model.User
-	if err := c.Bind(&user); err != nil {
+	var req model.UserRequest
+	if err := c.Bind(&req); err != nil {
 		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid input"})
 	}
 
+	if err := req.Validate(); err != nil {
+		return validationErrorResponse(c, err)
+	}
+	user := req.ToUser()
+
 	ctx := c.Request().Context()
 	if err := h.service.Create(ctx, &user); err != nil {
-		return c.JSON(http.StatusInternalServerError, model.Response{Message: err.Error()})
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
 	}
 
-	return c.JSON(http.StatusCreated, model.Response{Message: "success", Data: user})
+	return c.JSON(http.StatusCreated, model.Response{Message: "success", Data: user.ToResponse()})
 }
 
 // GetUser handles fetching an user by ID
@@ -79,7 +114,7 @@ func (h *UserHandler) GetUser(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, model.Response{Message: "User not found"})
 	}
 
-	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: user})
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: user.ToResponse()})
 }
 
 // GetAllUsers handles fetching all user
@@ -97,7 +132,12 @@ func (h *UserHandler) GetAllUsers(c echo.Context) error {
 		return c.JSON(http.StatusInternalServerError, model.Response{Message: err.Error()})
 	}
 
-	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: users})
+	data := make([]model.UserResponse, 0, len(users))
+	for _, user := range users {
+		data = append(data, user.ToResponse())
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: data})
 }
 
 // UpdateUser handles updating an existing user
@@ -107,34 +147,39 @@ func (h *UserHandler) GetAllUsers(c echo.Context) error {
 // @Accept json
 // @Produce json
 // @Param id path int true "User ID"
-// @Param user body model.User true "User details"
-// @Success 200 {object} model.User
+// @Param user body model.UserRequest true "User details"
+// @Success 200 {object} model.Response
 // @Failure 400 {object} model.Response
 // @Failure 500 {object} model.Response
 // @Router /users/{id} [put]
 func (h *UserHandler) UpdateUser(c echo.Context) error {
-	var user model.User
-	if err := c.Bind(&user); err != nil {
+	var req model.UserRequest
+	if err := c.Bind(&req); err != nil {
 		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid input"})
 	}
 
+	if err := req.Validate(); err != nil {
+		return validationErrorResponse(c, err)
+	}
+
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
 	}
+	user := req.ToUser()
 	user.ID = id
 
 	ctx := c.Request().Context()
 	if err := h.service.Update(ctx, &user); err != nil {
-		return c.JSON(http.StatusInternalServerError, model.Response{Message: err.Error()})
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
 	}
 
-	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: user})
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: user.ToResponse()})
 }
 
 // DeleteUser handles deleting an user by ID
 // @Summary Delete an user by ID
-// @Description Remove an user from the system by its ID
+// @Description Soft delete an user by its ID and revoke its sessions, orders keep their history
 // @Tags users
 // @Param id path int true "User ID"
 // @Success 204
@@ -175,7 +220,7 @@ func (h *UserHandler) SignUp(c echo.Context) (err error) {
 
 	err = req.Validate()
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, model.Response{Message: err.Error()})
+		return validationErrorResponse(c, err)
 	}
 
 	err = h.service.SignUp(c.Request().Context(), req)
@@ -188,13 +233,16 @@ func (h *UserHandler) SignUp(c echo.Context) (err error) {
 
 // Login         Customer Login
 // @Summary      Login
-// @Description  Login
+// @Description  Login, users with two-factor authentication get a challenge token to complete at /ecommerce/login/2fa
 // @Tags         users
 // @Accept       json
 // @Produce      json
 // @Param loginRequest body model.LoginRequest true "Login request"
 // @Success      201  {object}  model.Response
 // @Failure      400  {object}  model.Response
+// @Failure      403  {object}  model.Response
+// @Failure      423  {object}  model.Response
+// @Failure      429  {object}  model.Response
 // @Failure      500  {object}  model.Response
 // @Router       /ecommerce/login [post]
 func (h *UserHandler) Login(c echo.Context) (err error) {
@@ -206,13 +254,845 @@ func (h *UserHandler) Login(c echo.Context) (err error) {
 
 	err = req.Validate()
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, err.Error())
+		return validationErrorResponse(c, err)
 	}
+	req.ClientIP = c.RealIP()
 
 	data, err := h.service.Login(c.Request().Context(), req)
 	if err != nil {
+		switch err.Error() {
+		case util.ErrTooManyLoginAttempts, util.ErrAccountLocked, util.ErrContactNotVerified, util.ErrAccountDeactivated:
+			return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+		}
 		return c.JSON(http.StatusBadRequest, err.Error())
 	}
 
 	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: data})
 }
+
+// Logout        Customer Logout
+// @Summary      Logout
+// @Description  Revoke the session of the given bearer token
+// @Tags         users
+// @Produce      json
+// @Param Authorization header string true "Bearer token"
+// @Success      200  {object}  model.Response
+// @Failure      401  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /ecommerce/logout [post]
+func (h *UserHandler) Logout(c echo.Context) (err error) {
+	token, err := util.ExtractBearerToken(c.Request().Header.Get(echo.HeaderAuthorization))
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, model.Response{Message: err.Error()})
+	}
+
+	err = h.service.Logout(c.Request().Context(), token)
+	if err != nil {
+		switch err.Error() {
+		case util.ErrInvalidToken:
+			return c.JSON(http.StatusUnauthorized, model.Response{Message: err.Error()})
+		case util.ErrAccountLocked, util.ErrAccountDeactivated:
+			return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success"})
+}
+
+// GrantRole     Grant role to user
+// @Summary      Grant Role
+// @Description  Grant a role to an user, admin only
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param id path int true "User ID"
+// @Param roleRequest body model.RoleRequest true "Role to grant"
+// @Success      200  {object}  model.Response
+// @Failure      400  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /users/{id}/roles [post]
+func (h *UserHandler) GrantRole(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+
+	var req model.RoleRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid input"})
+	}
+
+	ctx := c.Request().Context()
+	if err := h.service.GrantRole(ctx, id, req.Role); err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success"})
+}
+
+// RevokeRole    Revoke role from user
+// @Summary      Revoke Role
+// @Description  Revoke a role from an user, admin only
+// @Tags         users
+// @Produce      json
+// @Security     BearerAuth
+// @Param id path int true "User ID"
+// @Param role path string true "Role to revoke"
+// @Success      200  {object}  model.Response
+// @Failure      400  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /users/{id}/roles/{role} [delete]
+func (h *UserHandler) RevokeRole(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+
+	ctx := c.Request().Context()
+	if err := h.service.RevokeRole(ctx, id, c.Param("role")); err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success"})
+}
+
+// RefreshToken  Rotate refresh token
+// @Summary      Refresh Token
+// @Description  Exchange a refresh token for a new access token and refresh token, a locked or deactivated account cannot refresh
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param refreshTokenRequest body model.RefreshTokenRequest true "Refresh token request"
+// @Success      200  {object}  model.Response
+// @Failure      400  {object}  model.Response
+// @Failure      401  {object}  model.Response
+// @Failure      403  {object}  model.Response
+// @Failure      423  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /ecommerce/token/refresh [post]
+func (h *UserHandler) RefreshToken(c echo.Context) (err error) {
+	var req model.RefreshTokenRequest
+	err = c.Bind(&req)
+	if err != nil || req.RefreshToken == "" {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid input"})
+	}
+
+	data, err := h.service.RefreshToken(c.Request().Context(), req)
+	if err != nil {
+		switch err.Error() {
+		case util.ErrInvalidToken:
+			return c.JSON(http.StatusUnauthorized, model.Response{Message: err.Error()})
+		case util.ErrAccountLocked, util.ErrAccountDeactivated:
+			return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: data})
+}
+
+// UnlockUser    Unlock user
+// @Summary      Unlock User
+// @Description  Unlock an user locked by failed login attempts, admin only
+// @Tags         users
+// @Produce      json
+// @Security     BearerAuth
+// @Param id path int true "User ID"
+// @Success      200  {object}  model.Response
+// @Failure      400  {object}  model.Response
+// @Failure      404  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /users/{id}/unlock [post]
+func (h *UserHandler) UnlockUser(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+
+	ctx := c.Request().Context()
+	if err := h.service.Unlock(ctx, id); err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success"})
+}
+
+// SendVerification Send verification code
+// @Summary      Send Verification Code
+// @Description  Send a one-time verification code to the user email or phone
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param sendVerificationRequest body model.SendVerificationRequest true "Send verification request"
+// @Success      200  {object}  model.Response
+// @Failure      400  {object}  model.Response
+// @Failure      429  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /ecommerce/verification/send [post]
+func (h *UserHandler) SendVerification(c echo.Context) (err error) {
+	var req model.SendVerificationRequest
+	err = c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid input"})
+	}
+
+	err = req.Validate()
+	if err != nil {
+		return validationErrorResponse(c, err)
+	}
+
+	err = h.service.SendVerification(c.Request().Context(), req)
+	if err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success"})
+}
+
+// VerifyContact Verify email or phone
+// @Summary      Verify Contact
+// @Description  Verify the user email or phone with the one-time verification code
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param verifyContactRequest body model.VerifyContactRequest true "Verify contact request"
+// @Success      200  {object}  model.Response
+// @Failure      400  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /ecommerce/verification/verify [post]
+func (h *UserHandler) VerifyContact(c echo.Context) (err error) {
+	var req model.VerifyContactRequest
+	err = c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid input"})
+	}
+
+	err = req.Validate()
+	if err != nil {
+		return validationErrorResponse(c, err)
+	}
+
+	err = h.service.VerifyContact(c.Request().Context(), req)
+	if err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success"})
+}
+
+// ForgotPassword Forgot password
+// @Summary      Forgot Password
+// @Description  Send a single-use password reset token to the user
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param forgotPasswordRequest body model.ForgotPasswordRequest true "Forgot password request"
+// @Success      200  {object}  model.Response
+// @Failure      400  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /ecommerce/password/forgot [post]
+func (h *UserHandler) ForgotPassword(c echo.Context) (err error) {
+	var req model.ForgotPasswordRequest
+	err = c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid input"})
+	}
+
+	err = req.Validate()
+	if err != nil {
+		return validationErrorResponse(c, err)
+	}
+
+	err = h.service.ForgotPassword(c.Request().Context(), req)
+	if err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success"})
+}
+
+// ResetPassword Reset password
+// @Summary      Reset Password
+// @Description  Set a new password using a password reset token
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param resetPasswordRequest body model.ResetPasswordRequest true "Reset password request"
+// @Success      200  {object}  model.Response
+// @Failure      400  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /ecommerce/password/reset [post]
+func (h *UserHandler) ResetPassword(c echo.Context) (err error) {
+	var req model.ResetPasswordRequest
+	err = c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid input"})
+	}
+
+	err = req.Validate()
+	if err != nil {
+		return validationErrorResponse(c, err)
+	}
+
+	err = h.service.ResetPassword(c.Request().Context(), req)
+	if err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success"})
+}
+
+// ChangePassword Change password
+// @Summary      Change Password
+// @Description  Change the password of the authenticated user and revoke all of its sessions
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param changePasswordRequest body model.ChangePasswordRequest true "Change password request"
+// @Success      200  {object}  model.Response
+// @Failure      400  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /users/me/password [put]
+func (h *UserHandler) ChangePassword(c echo.Context) (err error) {
+	var req model.ChangePasswordRequest
+	err = c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid input"})
+	}
+
+	err = req.Validate()
+	if err != nil {
+		return validationErrorResponse(c, err)
+	}
+
+	ctx := c.Request().Context()
+	authUser, _ := util.GetAuthUser(ctx)
+	err = h.service.ChangePassword(ctx, authUser.ID, req)
+	if err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success"})
+}
+
+// GetProfile    Get own profile
+// @Summary      Get Profile
+// @Description  Retrieve the profile of the authenticated user
+// @Tags         users
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  model.Response
+// @Failure      404  {object}  model.Response
+// @Router       /users/me [get]
+func (h *UserHandler) GetProfile(c echo.Context) error {
+	ctx := c.Request().Context()
+	authUser, _ := util.GetAuthUser(ctx)
+	user, err := h.service.Get(ctx, authUser.ID)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, model.Response{Message: "User not found"})
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: user.ToResponse()})
+}
+
+// UpdateProfile Update own profile
+// @Summary      Update Profile
+// @Description  Update the profile of the authenticated user, a changed email or phone has to be verified again
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param updateProfileRequest body model.UpdateProfileRequest true "Profile details"
+// @Success      200  {object}  model.Response
+// @Failure      400  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /users/me [patch]
+func (h *UserHandler) UpdateProfile(c echo.Context) error {
+	var req model.UpdateProfileRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid input"})
+	}
+
+	if err := req.Validate(); err != nil {
+		return validationErrorResponse(c, err)
+	}
+
+	ctx := c.Request().Context()
+	authUser, _ := util.GetAuthUser(ctx)
+	user, err := h.service.UpdateProfile(ctx, authUser.ID, req)
+	if err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: user.ToResponse()})
+}
+
+// GetAddresses  Get own addresses
+// @Summary      Get Addresses
+// @Description  Retrieve the address book of the authenticated user
+// @Tags         users
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  model.Response
+// @Failure      404  {object}  model.Response
+// @Router       /users/me/addresses [get]
+func (h *UserHandler) GetAddresses(c echo.Context) error {
+	ctx := c.Request().Context()
+	authUser, _ := util.GetAuthUser(ctx)
+	addresses, err := h.service.GetAddresses(ctx, authUser.ID)
+	if err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: addresses})
+}
+
+// AddAddress    Add address
+// @Summary      Add Address
+// @Description  Add an address to the address book of the authenticated user
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param address body model.AddressRequest true "Address details"
+// @Success      201  {object}  model.Response
+// @Failure      400  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /users/me/addresses [post]
+func (h *UserHandler) AddAddress(c echo.Context) error {
+	var req model.AddressRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid input"})
+	}
+
+	if err := req.Validate(); err != nil {
+		return validationErrorResponse(c, err)
+	}
+
+	ctx := c.Request().Context()
+	authUser, _ := util.GetAuthUser(ctx)
+	address, err := h.service.AddAddress(ctx, authUser.ID, req)
+	if err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, model.Response{Message: "success", Data: address})
+}
+
+// UpdateAddress Update address
+// @Summary      Update Address
+// @Description  Update an address in the address book of the authenticated user
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param addressID path int true "Address ID"
+// @Param address body model.AddressRequest true "Address details"
+// @Success      200  {object}  model.Response
+// @Failure      400  {object}  model.Response
+// @Failure      404  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /users/me/addresses/{addressID} [put]
+func (h *UserHandler) UpdateAddress(c echo.Context) error {
+	addressID, err := strconv.Atoi(c.Param("addressID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+
+	var req model.AddressRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid input"})
+	}
+
+	if err := req.Validate(); err != nil {
+		return validationErrorResponse(c, err)
+	}
+
+	ctx := c.Request().Context()
+	authUser, _ := util.GetAuthUser(ctx)
+	address, err := h.service.UpdateAddress(ctx, authUser.ID, addressID, req)
+	if err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: address})
+}
+
+// DeleteAddress Delete address
+// @Summary      Delete Address
+// @Description  Remove an address from the address book of the authenticated user
+// @Tags         users
+// @Security     BearerAuth
+// @Param addressID path int true "Address ID"
+// @Success      204
+// @Failure      400  {object}  model.Response
+// @Failure      404  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /users/me/addresses/{addressID} [delete]
+func (h *UserHandler) DeleteAddress(c echo.Context) error {
+	addressID, err := strconv.Atoi(c.Param("addressID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+
+	ctx := c.Request().Context()
+	authUser, _ := util.GetAuthUser(ctx)
+	if err := h.service.DeleteAddress(ctx, authUser.ID, addressID); err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusNoContent, model.Response{Message: "success"})
+}
+
+// OIDCLogin     Start identity provider login
+// @Summary      OIDC Login
+// @Description  Start an OpenID Connect login, the client is redirected to the returned authorization URL
+// @Tags         users
+// @Produce      json
+// @Param provider path string true "Identity provider name"
+// @Success      200  {object}  model.Response
+// @Failure      404  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /ecommerce/oidc/{provider}/login [get]
+func (h *UserHandler) OIDCLogin(c echo.Context) error {
+	data, err := h.service.OIDCLogin(c.Request().Context(), c.Param("provider"))
+	if err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: data})
+}
+
+// OIDCCallback  Complete identity provider login
+// @Summary      OIDC Callback
+// @Description  Complete an OpenID Connect login and issue access and refresh tokens
+// @Tags         users
+// @Produce      json
+// @Param provider path string true "Identity provider name"
+// @Param code query string true "Authorization code"
+// @Param state query string true "Login state"
+// @Success      200  {object}  model.Response
+// @Failure      400  {object}  model.Response
+// @Failure      404  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /ecommerce/oidc/{provider}/callback [get]
+func (h *UserHandler) OIDCCallback(c echo.Context) error {
+	if errParam := c.QueryParam("error"); errParam != "" {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: errParam})
+	}
+
+	code, state := c.QueryParam("code"), c.QueryParam("state")
+	if code == "" || state == "" {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid input"})
+	}
+
+	data, err := h.service.OIDCCallback(c.Request().Context(), c.Param("provider"), code, state)
+	if err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: data})
+}
+
+// LoginTwoFactor Complete two-factor login
+// @Summary      Two-Factor Login
+// @Description  Complete a login challenge with a TOTP code or a recovery code
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param twoFactorLoginRequest body model.TwoFactorLoginRequest true "Two-factor login request"
+// @Success      200  {object}  model.Response
+// @Failure      400  {object}  model.Response
+// @Failure      423  {object}  model.Response
+// @Failure      429  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /ecommerce/login/2fa [post]
+func (h *UserHandler) LoginTwoFactor(c echo.Context) (err error) {
+	var req model.TwoFactorLoginRequest
+	err = c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid input"})
+	}
+
+	err = req.Validate()
+	if err != nil {
+		return validationErrorResponse(c, err)
+	}
+	req.ClientIP = c.RealIP()
+
+	data, err := h.service.LoginTwoFactor(c.Request().Context(), req)
+	if err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: data})
+}
+
+// EnrollTOTP    Start TOTP enrolment
+// @Summary      Enroll TOTP
+// @Description  Generate a TOTP secret and otpauth URI, two-factor authentication is enabled once confirmed
+// @Tags         users
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  model.Response
+// @Failure      400  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /users/me/2fa/totp [post]
+func (h *UserHandler) EnrollTOTP(c echo.Context) error {
+	ctx := c.Request().Context()
+	authUser, _ := util.GetAuthUser(ctx)
+	data, err := h.service.EnrollTOTP(ctx, authUser.ID)
+	if err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: data})
+}
+
+// ConfirmTOTP   Confirm TOTP enrolment
+// @Summary      Confirm TOTP
+// @Description  Enable two-factor authentication with a code from the authenticator app and return the recovery codes
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param totpCodeRequest body model.TOTPCodeRequest true "TOTP code"
+// @Success      200  {object}  model.Response
+// @Failure      400  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /users/me/2fa/totp/confirm [post]
+func (h *UserHandler) ConfirmTOTP(c echo.Context) (err error) {
+	var req model.TOTPCodeRequest
+	err = c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid input"})
+	}
+
+	err = req.Validate()
+	if err != nil {
+		return validationErrorResponse(c, err)
+	}
+
+	ctx := c.Request().Context()
+	authUser, _ := util.GetAuthUser(ctx)
+	data, err := h.service.ConfirmTOTP(ctx, authUser.ID, req)
+	if err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: data})
+}
+
+// DisableTOTP   Disable TOTP
+// @Summary      Disable TOTP
+// @Description  Disable two-factor authentication with the password and a TOTP or recovery code
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param disableTOTPRequest body model.DisableTOTPRequest true "Disable TOTP request"
+// @Success      200  {object}  model.Response
+// @Failure      400  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /users/me/2fa/totp [delete]
+func (h *UserHandler) DisableTOTP(c echo.Context) (err error) {
+	var req model.DisableTOTPRequest
+	err = c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid input"})
+	}
+
+	err = req.Validate()
+	if err != nil {
+		return validationErrorResponse(c, err)
+	}
+
+	ctx := c.Request().Context()
+	authUser, _ := util.GetAuthUser(ctx)
+	err = h.service.DisableTOTP(ctx, authUser.ID, req)
+	if err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success"})
+}
+
+// RegenerateRecoveryCodes Regenerate recovery codes
+// @Summary      Regenerate Recovery Codes
+// @Description  Replace every two-factor recovery code, requires a TOTP code
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param totpCodeRequest body model.TOTPCodeRequest true "TOTP code"
+// @Success      200  {object}  model.Response
+// @Failure      400  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /users/me/2fa/recovery-codes [post]
+func (h *UserHandler) RegenerateRecoveryCodes(c echo.Context) (err error) {
+	var req model.TOTPCodeRequest
+	err = c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid input"})
+	}
+
+	err = req.Validate()
+	if err != nil {
+		return validationErrorResponse(c, err)
+	}
+
+	ctx := c.Request().Context()
+	authUser, _ := util.GetAuthUser(ctx)
+	data, err := h.service.RegenerateRecoveryCodes(ctx, authUser.ID, req)
+	if err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: data})
+}
+
+// DeactivateUser Deactivate user
+// @Summary      Deactivate User
+// @Description  Block an user from logging in and revoke its sessions, admin only
+// @Tags         users
+// @Produce      json
+// @Security     BearerAuth
+// @Param id path int true "User ID"
+// @Success      200  {object}  model.Response
+// @Failure      400  {object}  model.Response
+// @Failure      404  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /users/{id}/deactivate [post]
+func (h *UserHandler) DeactivateUser(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+
+	ctx := c.Request().Context()
+	if err := h.service.Deactivate(ctx, id); err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success"})
+}
+
+// ReactivateUser Reactivate user
+// @Summary      Reactivate User
+// @Description  Allow a deactivated user to log in again, admin only
+// @Tags         users
+// @Produce      json
+// @Security     BearerAuth
+// @Param id path int true "User ID"
+// @Success      200  {object}  model.Response
+// @Failure      400  {object}  model.Response
+// @Failure      404  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /users/{id}/reactivate [post]
+func (h *UserHandler) ReactivateUser(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+
+	ctx := c.Request().Context()
+	if err := h.service.Reactivate(ctx, id); err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success"})
+}
+
+// DeactivateProfile Deactivate own account
+// @Summary      Deactivate Account
+// @Description  Deactivate the account of the authenticated user, only an admin can reactivate it
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param passwordConfirmationRequest body model.PasswordConfirmationRequest true "Password confirmation"
+// @Success      200  {object}  model.Response
+// @Failure      400  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /users/me/deactivate [post]
+func (h *UserHandler) DeactivateProfile(c echo.Context) (err error) {
+	var req model.PasswordConfirmationRequest
+	err = c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid input"})
+	}
+
+	err = req.Validate()
+	if err != nil {
+		return validationErrorResponse(c, err)
+	}
+
+	ctx := c.Request().Context()
+	authUser, _ := util.GetAuthUser(ctx)
+	err = h.service.DeactivateSelf(ctx, authUser.ID, req)
+	if err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success"})
+}
+
+// EraseProfile  Erase own account
+// @Summary      Erase Account
+// @Description  Anonymize the personal data of the authenticated user and its orders, then delete the account
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param passwordConfirmationRequest body model.PasswordConfirmationRequest true "Password confirmation"
+// @Success      200  {object}  model.Response
+// @Failure      400  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /users/me [delete]
+func (h *UserHandler) EraseProfile(c echo.Context) (err error) {
+	var req model.PasswordConfirmationRequest
+	err = c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid input"})
+	}
+
+	err = req.Validate()
+	if err != nil {
+		return validationErrorResponse(c, err)
+	}
+
+	ctx := c.Request().Context()
+	authUser, _ := util.GetAuthUser(ctx)
+	err = h.service.Erase(ctx, authUser.ID, req)
+	if err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success"})
+}
+
+// ExportProfile Export own data
+// @Summary      Export Data
+// @Description  Download the profile, addresses, shops and orders of the authenticated user as a JSON archive
+// @Tags         users
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  model.UserExport
+// @Failure      404  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /users/me/export [get]
+func (h *UserHandler) ExportProfile(c echo.Context) error {
+	ctx := c.Request().Context()
+	authUser, _ := util.GetAuthUser(ctx)
+	data, err := h.service.Export(ctx, authUser.ID)
+	if err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	filename := fmt.Sprintf("user-%d-export-%s.json", authUser.ID, data.ExportedAt.Format(util.DateFormatYYYYMMDD))
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+	return c.JSONPretty(http.StatusOK, data, "  ")
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"simcomm-monolith/internal/model"
+	"simcomm-monolith/internal/service/mocks"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUserHandlerDoesNotLeakSecrets(t *testing.T) {
+	user := &model.User{
+		ID:        1,
+		Name:      "Jane Doe",
+		Email:     "jane@example.com",
+		Passsword: "$2a$10$password-hash",
+		UserDetail: model.UserDetail{
+			TwoFactor: model.TwoFactor{
+				Enabled:            true,
+				Secret:             "TOTP-SECRET",
+				RecoveryCodeHashes: []string{"recovery-code-hash"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		path  string
+		setup func(svc *mocks.UserService)
+	}{
+		{
+			name:  "get user",
+			path:  "/users/1",
+			setup: func(svc *mocks.UserService) { svc.On("Get", mock.Anything, 1).Return(user, nil) },
+		},
+		{
+			name:  "get all users",
+			path:  "/users",
+			setup: func(svc *mocks.UserService) { svc.On("GetAll", mock.Anything).Return([]model.User{*user}, nil) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &mocks.UserService{}
+			tt.setup(svc)
+			e := echo.New()
+			RegisterUserHandler(e, svc)
+
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			body := rec.Body.String()
+			assert.Contains(t, body, "jane@example.com")
+			for _, secret := range []string{"password", "$2a$10$", "TOTP-SECRET", "recovery-code-hash"} {
+				assert.NotContains(t, body, secret)
+			}
+			svc.AssertExpectations(t)
+		})
+	}
+}
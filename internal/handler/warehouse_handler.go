@@ -23,6 +23,10 @@ func RegisterWarehouseHandler(e *echo.Echo, svc service.WarehouseService) {
 	e.GET("warehouses/:id", handler.GetWarehouse)
 	e.PUT("warehouses/:id", handler.UpdateWarehouse)
 	e.DELETE("warehouses/:id", handler.DeleteWarehouse)
+	e.POST("warehouses/:id/image", handler.UploadWarehouseImage)
+	e.GET("warehouses/:id/status-history", handler.GetWarehouseStatusHistory)
+	e.GET("shops/:id/warehouses", handler.GetWarehousesByShop)
+	e.GET("warehouses/:id/stored-products", handler.GetWarehouseStoredProductsByWarehouse)
 
 	e.GET("warehouse-stored-products", handler.GetAllWarehouseStoredProducts)
 	e.POST("warehouse-stored-products", handler.CreateWarehouseStoredProduct)
@@ -35,6 +39,79 @@ func NewWarehouseHandler(service service.WarehouseService) *WarehouseHandler {
 	return &WarehouseHandler{service: service}
 }
 
+// GetWarehousesByShop handles listing the warehouses of a shop
+// @Summary Get the warehouses of a shop
+// @Description List the warehouses of a shop to any of its members
+// @Tags warehouses
+// @Produce json
+// @Param id path int true "Shop ID"
+// @Param status query string false "draft, active, suspended or archived"
+// @Param q query string false "Part of the name or location"
+// @Param page query int false "Page, starts at 1"
+// @Param limit query int false "Warehouses per page, at most 100"
+// @Success 200 {object} model.Response
+// @Failure 400 {object} model.Response
+// @Failure 403 {object} model.Response
+// @Failure 404 {object} model.Response
+// @Router /shops/{id}/warehouses [get]
+func (h *WarehouseHandler) GetWarehousesByShop(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+
+	var req model.WarehouseListRequest
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &req); err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid input"})
+	}
+	req.ShopID = id
+
+	ctx := c.Request().Context()
+	warehouses, err := h.service.GetByShopID(ctx, req)
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: warehouses})
+}
+
+// GetWarehouseStoredProductsByWarehouse handles listing the products stored in a warehouse
+// @Summary Get the stored products of a warehouse
+// @Description List the products stored in a warehouse to any member of its shop
+// @Tags warehouse-stored-products
+// @Produce json
+// @Param id path int true "Warehouse ID"
+// @Param shop_product_id query int false "Shop product ID"
+// @Param variant_id query int false "Variant ID"
+// @Param in_stock query bool false "Only stored products in stock, or out of stock when false"
+// @Param page query int false "Page, starts at 1"
+// @Param limit query int false "Stored products per page, at most 100"
+// @Success 200 {object} model.Response
+// @Failure 400 {object} model.Response
+// @Failure 403 {object} model.Response
+// @Failure 404 {object} model.Response
+// @Router /warehouses/{id}/stored-products [get]
+func (h *WarehouseHandler) GetWarehouseStoredProductsByWarehouse(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+
+	var req model.StoredProductListRequest
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &req); err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid input"})
+	}
+	req.WarehouseID = id
+
+	ctx := c.Request().Context()
+	storedProducts, err := h.service.WSPGetByWarehouseID(ctx, req)
+	if err != nil {
+		return serviceErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: storedProducts})
+}
+
 // CreateWarehouse Create warehouse
 // @Summary      Create Warehouse
 // @Description  Create Warehouse
@@ -68,6 +145,7 @@ func (h *WarehouseHandler) CreateWarehouse(c echo.Context) error {
 // @Param id path int true "Warehouse ID"
 // @Success 200 {object}  model.Response
 // @Failure 400 {object}  model.Response
+// @Failure 403 {object}  model.Response
 // @Failure 404 {object}  model.Response
 // @Router /warehouses/{id} [get]
 func (h *WarehouseHandler) GetWarehouse(c echo.Context) error {
@@ -79,7 +157,7 @@ func (h *WarehouseHandler) GetWarehouse(c echo.Context) error {
 	ctx := c.Request().Context()
 	warehouse, err := h.service.Get(ctx, id)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, model.Response{Message: "Warehouse not found"})
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
 	}
 
 	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: warehouse})
@@ -87,11 +165,13 @@ func (h *WarehouseHandler) GetWarehouse(c echo.Context) error {
 
 // GetAllWarehouses handles fetching all warehouse
 // @Summary Get all warehouse
-// @Description Retrieve all warehouse in the system
+// @Description Retrieve all warehouse in the system, admin only. Use GET /shops/{id}/warehouses for the warehouses of a shop
 // @Tags warehouses
 // @Produce json
 // @Success 200 {object}  model.Response
+// @Failure 403 {object}  model.Response
 // @Failure 500 {object}  model.Response
+// @Deprecated
 // @Router /warehouses [get]
 func (h *WarehouseHandler) GetAllWarehouses(c echo.Context) error {
 	ctx := c.Request().Context()
@@ -191,6 +271,7 @@ func (h *WarehouseHandler) CreateWarehouseStoredProduct(c echo.Context) error {
 // @Param id path int true "WarehouseStoredProduct ID"
 // @Success 200 {object}  model.Response
 // @Failure 400 {object}  model.Response
+// @Failure 403 {object}  model.Response
 // @Failure 404 {object}  model.Response
 // @Router /warehouse-stored-products/{id} [get]
 func (h *WarehouseHandler) GetWarehouseStoredProduct(c echo.Context) error {
@@ -202,7 +283,7 @@ func (h *WarehouseHandler) GetWarehouseStoredProduct(c echo.Context) error {
 	ctx := c.Request().Context()
 	warehousestoredproduct, err := h.service.WSPGet(ctx, id)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, model.Response{Message: "WarehouseStoredProduct not found"})
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
 	}
 
 	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: warehousestoredproduct})
@@ -210,11 +291,13 @@ func (h *WarehouseHandler) GetWarehouseStoredProduct(c echo.Context) error {
 
 // GetAllWarehouseStoredProducts handles fetching all warehousestoredproduct
 // @Summary Get all warehousestoredproduct
-// @Description Retrieve all warehousestoredproduct in the system
+// @Description Retrieve all warehousestoredproduct in the system, admin only. Use GET /warehouses/{id}/stored-products for the stock of a warehouse
 // @Tags warehousestoredproducts
 // @Produce json
 // @Success 200 {object}  model.Response
+// @Failure 403 {object}  model.Response
 // @Failure 500 {object}  model.Response
+// @Deprecated
 // @Router /warehouse-stored-products [get]
 func (h *WarehouseHandler) GetAllWarehouseStoredProducts(c echo.Context) error {
 	ctx := c.Request().Context()
@@ -236,6 +319,8 @@ func (h *WarehouseHandler) GetAllWarehouseStoredProducts(c echo.Context) error {
 // @Param warehousestoredproduct body model.WarehouseStoredProduct true "WarehouseStoredProduct details"
 // @Success 200 {object} model.WarehouseStoredProduct
 // @Failure 400 {object} model.Response
+// @Failure 403 {object} model.Response
+// @Failure 404 {object} model.Response
 // @Failure 500 {object} model.Response
 // @Router /warehouse-stored-products/{id} [put]
 func (h *WarehouseHandler) UpdateWarehouseStoredProduct(c echo.Context) error {
@@ -252,7 +337,7 @@ func (h *WarehouseHandler) UpdateWarehouseStoredProduct(c echo.Context) error {
 
 	ctx := c.Request().Context()
 	if err := h.service.WSPUpdate(ctx, &warehousestoredproduct); err != nil {
-		return c.JSON(http.StatusInternalServerError, model.Response{Message: err.Error()})
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
 	}
 
 	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: warehousestoredproduct})
@@ -265,6 +350,8 @@ func (h *WarehouseHandler) UpdateWarehouseStoredProduct(c echo.Context) error {
 // @Param id path int true "WarehouseStoredProduct ID"
 // @Success 204
 // @Failure 400 {object} model.Response
+// @Failure 403 {object} model.Response
+// @Failure 404 {object} model.Response
 // @Failure 500 {object} model.Response
 // @Router /warehouse-stored-products/{id} [delete]
 func (h *WarehouseHandler) DeleteWarehouseStoredProduct(c echo.Context) error {
@@ -275,8 +362,70 @@ func (h *WarehouseHandler) DeleteWarehouseStoredProduct(c echo.Context) error {
 
 	ctx := c.Request().Context()
 	if err := h.service.WSPDelete(ctx, id); err != nil {
-		return c.JSON(http.StatusInternalServerError, model.Response{Message: err.Error()})
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
 	}
 
 	return c.JSON(http.StatusNoContent, model.Response{Message: "success"})
 }
+
+// UploadWarehouseImage handles uploading the warehouse image
+// @Summary Upload a warehouse image
+// @Description Upload a JPEG, PNG or GIF image as multipart form file "image", a thumbnail is generated and the previous image is replaced
+// @Tags warehouses
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Warehouse ID"
+// @Param image formData file true "Image file"
+// @Success 200 {object} model.Response
+// @Failure 400 {object} model.Response
+// @Failure 404 {object} model.Response
+// @Failure 413 {object} model.Response
+// @Failure 415 {object} model.Response
+// @Failure 500 {object} model.Response
+// @Router /warehouses/{id}/image [post]
+func (h *WarehouseHandler) UploadWarehouseImage(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+
+	file, err := openImageUpload(c)
+	if err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+	defer file.Close()
+
+	ctx := c.Request().Context()
+	image, err := h.service.UploadImage(ctx, id, file)
+	if err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: image})
+}
+
+// GetWarehouseStatusHistory handles fetching the status changes of a warehouse
+// @Summary Get the status history of a warehouse
+// @Description Retrieve who changed the status of a warehouse and when, oldest first
+// @Tags warehouses
+// @Produce json
+// @Param id path int true "Warehouse ID"
+// @Success 200 {object} model.Response
+// @Failure 400 {object} model.Response
+// @Failure 403 {object} model.Response
+// @Failure 404 {object} model.Response
+// @Router /warehouses/{id}/status-history [get]
+func (h *WarehouseHandler) GetWarehouseStatusHistory(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Message: "Invalid ID"})
+	}
+
+	ctx := c.Request().Context()
+	changes, err := h.service.GetStatusHistory(ctx, id)
+	if err != nil {
+		return c.JSON(errorStatus(err), model.Response{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, model.Response{Message: "success", Data: changes})
+}
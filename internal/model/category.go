@@ -0,0 +1,176 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+const AttributeTypeString = "string"
+const AttributeTypeNumber = "number"
+const AttributeTypeBoolean = "boolean"
+const AttributeTypeEnum = "enum"
+
+// Category is a node of the category tree, a root category has no ParentID
+type Category struct {
+	ID        int            `json:"id" gorm:"column:id"`
+	ParentID  *int           `json:"parent_id" gorm:"column:parent_id"`
+	Name      string         `json:"name" gorm:"column:name"`
+	Slug      string         `json:"slug" gorm:"column:slug"`
+	Detail    CategoryDetail `json:"detail" gorm:"type:jsonb;column:detail"`
+	CreatedAt time.Time      `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (Category) TableName() string {
+	return "categories"
+}
+
+// CategoryDetail Attributes only holds the attributes defined by this category,
+// a category also inherits the attributes of its ancestors
+type CategoryDetail struct {
+	Attributes []AttributeDefinition `json:"attributes"`
+}
+
+// AttributeDefinition describes a typed product attribute, e.g. size, colour or material
+type AttributeDefinition struct {
+	Code     string   `json:"code"`
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	Required bool     `json:"required"`
+	Options  []string `json:"options,omitempty"`
+	Unit     string   `json:"unit,omitempty"`
+}
+
+// Implement the Valuer interface for Detail
+func (d CategoryDetail) Value() (driver.Value, error) {
+	return json.Marshal(d)
+}
+
+// Implement the Scanner interface for Detail
+func (d *CategoryDetail) Scan(value interface{}) error {
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("failed to scan Detail")
+	}
+	return json.Unmarshal(bytes, d)
+}
+
+// Validate checks the attribute definitions of the category itself
+func (c *Category) Validate() error {
+	var errs ValidationErrors
+	if c.Name == "" {
+		errs.Add("name", "is required")
+	}
+	if c.Slug == "" {
+		errs.Add("slug", "is required")
+	}
+
+	codes := make(map[string]bool)
+	for _, attribute := range c.Detail.Attributes {
+		field := "detail.attributes." + attribute.Code
+		if attribute.Code == "" {
+			errs.Add("detail.attributes", "code is required")
+			continue
+		}
+		if codes[attribute.Code] {
+			errs.Add(field, "is defined more than once")
+		}
+		codes[attribute.Code] = true
+
+		switch attribute.Type {
+		case AttributeTypeString, AttributeTypeNumber, AttributeTypeBoolean:
+		case AttributeTypeEnum:
+			if len(attribute.Options) == 0 {
+				errs.Add(field, "enum must have options")
+			}
+		default:
+			errs.Add(field, "type must be string, number, boolean or enum")
+		}
+	}
+	return errs.Err()
+}
+
+// CategoryNode is a category with its sub categories, used to return the tree
+type CategoryNode struct {
+	Category
+	Children []*CategoryNode `json:"children"`
+}
+
+// CategorySchema is a category with every attribute it defines or inherits
+type CategorySchema struct {
+	Category
+	Path       []Category            `json:"path"`
+	Attributes []AttributeDefinition `json:"attributes"`
+}
+
+// ValidateAttributes checks product attributes against the schema, unknown
+// attributes are rejected so typos do not silently create new attributes
+func (cs CategorySchema) ValidateAttributes(attributes map[string]interface{}) error {
+	return cs.validateValues("detail.attributes.", attributes, true)
+}
+
+// ValidateVariantOptions checks variant options against the schema, options
+// only hold the attributes that differ between variants so none is required
+func (cs CategorySchema) ValidateVariantOptions(options map[string]interface{}) error {
+	return cs.validateValues("detail.options.", options, false)
+}
+
+func (cs CategorySchema) validateValues(fieldPrefix string, attributes map[string]interface{}, checkRequired bool) error {
+	var errs ValidationErrors
+	definitions := make(map[string]AttributeDefinition)
+	for _, definition := range cs.Attributes {
+		definitions[definition.Code] = definition
+	}
+
+	for code := range attributes {
+		if _, ok := definitions[code]; !ok {
+			errs.Add(fieldPrefix+code, "is not defined for the category")
+		}
+	}
+
+	for _, definition := range cs.Attributes {
+		field := fieldPrefix + definition.Code
+		value, ok := attributes[definition.Code]
+		if !ok || value == nil {
+			if checkRequired && definition.Required {
+				errs.Add(field, "is required")
+			}
+			continue
+		}
+
+		switch definition.Type {
+		case AttributeTypeString:
+			if _, ok := value.(string); !ok {
+				errs.Add(field, "must be a string")
+			}
+		case AttributeTypeNumber:
+			if _, ok := value.(float64); !ok {
+				errs.Add(field, "must be a number")
+			}
+		case AttributeTypeBoolean:
+			if _, ok := value.(bool); !ok {
+				errs.Add(field, "must be a boolean")
+			}
+		case AttributeTypeEnum:
+			if !containsString(definition.Options, value) {
+				errs.Add(field, "must be one of the category options")
+			}
+		}
+	}
+	return errs.Err()
+}
+
+func containsString(options []string, value interface{}) bool {
+	s, ok := value.(string)
+	if !ok {
+		return false
+	}
+	for _, option := range options {
+		if option == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func validationFields(t *testing.T, err error) []string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	var errs ValidationErrors
+	assert.ErrorAs(t, err, &errs)
+	var fields []string
+	for _, fe := range errs {
+		fields = append(fields, fe.Field)
+	}
+	return fields
+}
+
+func TestCategoryValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		category   Category
+		wantFields []string
+	}{
+		{"valid category", Category{Name: "Shoes", Slug: "shoes", Detail: CategoryDetail{Attributes: []AttributeDefinition{
+			{Code: "size", Type: AttributeTypeNumber, Required: true},
+			{Code: "colour", Type: AttributeTypeEnum, Options: []string{"black", "white"}},
+		}}}, nil},
+		{"missing name and slug", Category{}, []string{"name", "slug"}},
+		{"attribute without code", Category{Name: "Shoes", Slug: "shoes", Detail: CategoryDetail{Attributes: []AttributeDefinition{
+			{Type: AttributeTypeString},
+		}}}, []string{"detail.attributes"}},
+		{"attribute defined twice", Category{Name: "Shoes", Slug: "shoes", Detail: CategoryDetail{Attributes: []AttributeDefinition{
+			{Code: "size", Type: AttributeTypeNumber},
+			{Code: "size", Type: AttributeTypeString},
+		}}}, []string{"detail.attributes.size"}},
+		{"enum without options", Category{Name: "Shoes", Slug: "shoes", Detail: CategoryDetail{Attributes: []AttributeDefinition{
+			{Code: "colour", Type: AttributeTypeEnum},
+		}}}, []string{"detail.attributes.colour"}},
+		{"unknown type", Category{Name: "Shoes", Slug: "shoes", Detail: CategoryDetail{Attributes: []AttributeDefinition{
+			{Code: "size", Type: "integer"},
+		}}}, []string{"detail.attributes.size"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantFields, validationFields(t, tt.category.Validate()))
+		})
+	}
+}
+
+func TestCategorySchemaValidateAttributes(t *testing.T) {
+	schema := CategorySchema{Attributes: []AttributeDefinition{
+		{Code: "size", Type: AttributeTypeNumber, Required: true},
+		{Code: "colour", Type: AttributeTypeEnum, Options: []string{"black", "white"}},
+		{Code: "waterproof", Type: AttributeTypeBoolean},
+		{Code: "material", Type: AttributeTypeString},
+	}}
+
+	tests := []struct {
+		name       string
+		attributes map[string]interface{}
+		wantFields []string
+	}{
+		{"valid attributes", map[string]interface{}{"size": 42.0, "colour": "black", "waterproof": true, "material": "leather"}, nil},
+		{"missing required attribute", map[string]interface{}{"colour": "black"}, []string{"detail.attributes.size"}},
+		{"unknown attribute", map[string]interface{}{"size": 42.0, "weight": 1.0}, []string{"detail.attributes.weight"}},
+		{"wrong types", map[string]interface{}{"size": "42", "waterproof": "yes", "material": 1.0}, []string{
+			"detail.attributes.size", "detail.attributes.waterproof", "detail.attributes.material",
+		}},
+		{"option outside the enum", map[string]interface{}{"size": 42.0, "colour": "red"}, []string{"detail.attributes.colour"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantFields, validationFields(t, schema.ValidateAttributes(tt.attributes)))
+		})
+	}
+
+	// variant options never require an attribute
+	assert.NoError(t, schema.ValidateVariantOptions(map[string]interface{}{"colour": "white"}))
+}
@@ -7,6 +7,39 @@ import (
 	"time"
 )
 
+// OrderStatusPending is the status every order is placed with
+const OrderStatusPending = "pending"
+const OrderStatusShipped = "shipped"
+
+// OrderStatusCompleted is the status of an order delivered to the buyer, its
+// lines can be reviewed
+const OrderStatusCompleted = "completed"
+const OrderStatusCancelled = "cancelled"
+
+// orderStatusTransitions lists the statuses each order status can move to during
+// fulfilment, completed and cancelled are final
+var orderStatusTransitions = map[string][]string{
+	OrderStatusPending:   {OrderStatusShipped, OrderStatusCancelled},
+	OrderStatusShipped:   {OrderStatusCompleted},
+	OrderStatusCompleted: {},
+	OrderStatusCancelled: {},
+}
+
+func IsValidOrderStatus(status string) bool {
+	_, ok := orderStatusTransitions[status]
+	return ok
+}
+
+// CanTransitionTo reports whether the order can move to the status
+func (o Order) CanTransitionTo(status string) bool {
+	for _, next := range orderStatusTransitions[o.Status] {
+		if next == status {
+			return true
+		}
+	}
+	return false
+}
+
 type Order struct {
 	ID        int         `json:"id" gorm:"column:id"`
 	UserID    int         `json:"user_id" gorm:"column:user_id"`
@@ -24,6 +57,20 @@ func (Order) TableName() string {
 type OrderDetail struct {
 	UserDetail      UserDetail      `json:"user_detail"`
 	WarehouseDetail WarehouseDetail `json:"warehouse_detail"`
+	ShippingAddress *UserAddress    `json:"shipping_address"`
+	Items           []OrderItem     `json:"items"`
+	Currency        string          `json:"currency"`
+	Total           int64           `json:"total"`
+}
+
+// OrderItem prices are in minor units of the order currency, captured when the order is placed
+type OrderItem struct {
+	ShopProductID int   `json:"shop_product_id"`
+	ProductID     int   `json:"product_id"`
+	VariantID     *int  `json:"variant_id,omitempty"`
+	Quantity      int   `json:"quantity"`
+	UnitPrice     int64 `json:"unit_price"`
+	Subtotal      int64 `json:"subtotal"`
 }
 
 // Implement the Valuer interface for Detail
@@ -5,25 +5,54 @@ import (
 	"encoding/json"
 	"errors"
 	"time"
+
+	"gorm.io/gorm"
 )
 
+// Product is owned by the user who created it and optionally by a shop, the managers
+// of the shop can change it as well. A product without an owner, e.g. created before
+// owners were recorded, can only be changed by admins.
 type Product struct {
-	ID        int           `json:"id" gorm:"column:id"`
-	Code      string        `json:"code" gorm:"column:code"`
-	Name      string        `json:"name" gorm:"column:name"`
-	Status    string        `json:"status" gorm:"column:status"`
-	Detail    ProductDetail `json:"detail" gorm:"type:jsonb;column:detail"`
-	CreatedAt time.Time     `json:"created_at" gorm:"column:created_at"`
-	UpdatedAt time.Time     `json:"updated_at" gorm:"column:updated_at"`
+	ID         int           `json:"id" gorm:"column:id"`
+	Code       string        `json:"code" gorm:"column:code"`
+	Name       string        `json:"name" gorm:"column:name"`
+	CategoryID *int          `json:"category_id" gorm:"column:category_id"`
+	UserID     *int          `json:"user_id" gorm:"column:user_id"`
+	ShopID     *int          `json:"shop_id" gorm:"column:shop_id"`
+	Status     Status        `json:"status" gorm:"column:status"`
+	Detail     ProductDetail `json:"detail" gorm:"type:jsonb;column:detail"`
+	CreatedAt  time.Time     `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt  time.Time     `json:"updated_at" gorm:"column:updated_at"`
+
+	statusChange *StatusChange
 }
 
 func (Product) TableName() string {
 	return "products"
 }
 
+// RecordStatusChange moves the product from its stored status, the change is recorded
+// when the product is saved
+func (p *Product) RecordStatusChange(from Status, to Status, by int, at time.Time) {
+	p.statusChange = newStatusChange(StatusEntityProduct, from, to, by, at)
+	p.Status = to
+}
+
+// KeepStatus restores the stored status and drops a pending status change
+func (p *Product) KeepStatus(status Status) {
+	p.Status = status
+	p.statusChange = nil
+}
+
+func (p *Product) AfterSave(tx *gorm.DB) error {
+	return saveStatusChange(tx, &p.statusChange, p.ID)
+}
+
 type ProductDetail struct {
-	Weight   int    `json:"weight"`
-	ImageURL string `json:"image_url"`
+	Weight       int                    `json:"weight"`
+	ImageURL     string                 `json:"image_url"`
+	ThumbnailURL string                 `json:"thumbnail_url,omitempty"`
+	Attributes   map[string]interface{} `json:"attributes,omitempty"`
 }
 
 // Implement the Valuer interface for Detail
@@ -39,3 +68,115 @@ func (d *ProductDetail) Scan(value interface{}) error {
 	}
 	return json.Unmarshal(bytes, d)
 }
+
+// ProductVariant is a sellable SKU of a product, e.g. one size and colour,
+// an empty weight or image falls back to the parent product. A variant has no
+// price of its own, each shop prices it through the ShopProduct listing it.
+type ProductVariant struct {
+	ID        int           `json:"id" gorm:"column:id"`
+	ProductID int           `json:"product_id" gorm:"column:product_id"`
+	SKU       string        `json:"sku" gorm:"column:sku"`
+	Name      string        `json:"name" gorm:"column:name"`
+	Status    string        `json:"status" gorm:"column:status"`
+	Detail    VariantDetail `json:"detail" gorm:"type:jsonb;column:detail"`
+	Stock     int           `json:"stock" gorm:"-"`
+	CreatedAt time.Time     `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt time.Time     `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (ProductVariant) TableName() string {
+	return "product_variants"
+}
+
+// VariantDetail Options are the attributes that tell the variants apart, validated
+// against the category schema of the parent product
+type VariantDetail struct {
+	Weight   int                    `json:"weight"`
+	ImageURL string                 `json:"image_url"`
+	Options  map[string]interface{} `json:"options"`
+}
+
+// Implement the Valuer interface for Detail
+func (d VariantDetail) Value() (driver.Value, error) {
+	return json.Marshal(d)
+}
+
+// Implement the Scanner interface for Detail
+func (d *VariantDetail) Scan(value interface{}) error {
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("failed to scan Detail")
+	}
+	return json.Unmarshal(bytes, d)
+}
+
+const ImportFormatCSV = "csv"
+const ImportFormatJSONL = "jsonl"
+
+const ImportStatusPending = "pending"
+const ImportStatusProcessing = "processing"
+const ImportStatusCompleted = "completed"
+const ImportStatusFailed = "failed"
+
+// ProductImportJob tracks a bulk import of products upserted by their code,
+// small files are imported right away and large files by the import worker
+type ProductImportJob struct {
+	ID         int                    `json:"id" gorm:"column:id"`
+	UserID     int                    `json:"user_id" gorm:"column:user_id"`
+	Format     string                 `json:"format" gorm:"column:format"`
+	Status     string                 `json:"status" gorm:"column:status"`
+	Detail     ProductImportJobDetail `json:"detail" gorm:"type:jsonb;column:detail"`
+	CreatedAt  time.Time              `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt  time.Time              `json:"updated_at" gorm:"column:updated_at"`
+	FinishedAt *time.Time             `json:"finished_at" gorm:"column:finished_at"`
+}
+
+func (ProductImportJob) TableName() string {
+	return "product_import_jobs"
+}
+
+// ProductImportJobDetail Errors lists every row that was not imported, rows are
+// numbered from 1 after the CSV header
+type ProductImportJobDetail struct {
+	Total   int              `json:"total"`
+	Created int              `json:"created"`
+	Updated int              `json:"updated"`
+	Failed  int              `json:"failed"`
+	Errors  []ImportRowError `json:"errors"`
+	Message string           `json:"message,omitempty"`
+}
+
+type ImportRowError struct {
+	Row    int              `json:"row"`
+	Code   string           `json:"code"`
+	Errors ValidationErrors `json:"errors"`
+}
+
+// Implement the Valuer interface for Detail
+func (d ProductImportJobDetail) Value() (driver.Value, error) {
+	return json.Marshal(d)
+}
+
+// Implement the Scanner interface for Detail
+func (d *ProductImportJobDetail) Scan(value interface{}) error {
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("failed to scan Detail")
+	}
+	return json.Unmarshal(bytes, d)
+}
+
+// ProductImportRow is a parsed row of an import file, Errors holds the values that could not be parsed
+type ProductImportRow struct {
+	Row     int              `json:"row"`
+	Product Product          `json:"product"`
+	Errors  ValidationErrors `json:"errors,omitempty"`
+}
+
+// ProductImportMessage is published to the import worker for large files, Roles are
+// the roles of the user who started the import
+type ProductImportMessage struct {
+	JobID int                `json:"job_id"`
+	Roles []string           `json:"roles"`
+	Rows  []ProductImportRow `json:"rows"`
+}
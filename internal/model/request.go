@@ -1,32 +1,30 @@
 package model
 
 import (
-	"errors"
-	"fmt"
+	"simcomm-monolith/util"
 	"strings"
+	"time"
+	"unicode/utf8"
 )
 
 type LoginRequest struct {
 	Identifier string `json:"identifier"`
 	Password   string `json:"password"`
+	ClientIP   string `json:"-"`
 }
 
+// Validate also normalizes the identifier so it matches the stored email or phone
 func (lr *LoginRequest) Validate() error {
-	var errMessage string
-	errTemplate := "%s is not valid;"
+	var errs ValidationErrors
 	if lr.Identifier == "" {
-		errMessage += fmt.Sprintf(errTemplate, "email or phone")
+		errs.Add("identifier", "is required")
 	}
-
-	//TODO: validate with more proper validataion
+	lr.Identifier = util.NormalizeIdentifier(lr.Identifier)
 
 	if lr.Password == "" {
-		errMessage += fmt.Sprintf(errTemplate, "password")
-	}
-	if errMessage != "" {
-		return errors.New(errMessage)
+		errs.Add("password", "is required")
 	}
-	return nil
+	return errs.Err()
 }
 
 type SignUpRequest struct {
@@ -37,29 +35,767 @@ type SignUpRequest struct {
 	Role     string `json:"role"`
 }
 
+// Validate also normalizes the email and phone before they are stored
 func (sur *SignUpRequest) Validate() error {
-	var errMessage string
-	errTemplate := "%s is not valid;"
-	if sur.Name == "" {
-		errMessage += fmt.Sprintf(errTemplate, "name")
+	var errs ValidationErrors
+	var err error
+	if err = util.CheckName(sur.Name); err != nil {
+		errs.Add("name", err.Error())
+	}
+	sur.Name = strings.TrimSpace(sur.Name)
+
+	if sur.Email, err = util.NormalizeEmail(sur.Email); err != nil {
+		errs.Add("email", err.Error())
+	}
+	if sur.Phone, err = util.NormalizePhone(sur.Phone); err != nil {
+		errs.Add("phone", err.Error())
+	}
+	if err = util.CheckPasswordStrength(sur.Password); err != nil {
+		errs.Add("password", err.Error())
+	}
+
+	// every other role, seller included, can only be granted by an admin
+	role := strings.ToLower(sur.Role)
+	if role != "" && role != util.RoleCustomer {
+		errs.Add("role", "must be customer, other roles are granted by an admin")
+	}
+	return errs.Err()
+}
+
+type RoleRequest struct {
+	Role string `json:"role"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type SendVerificationRequest struct {
+	Identifier string `json:"identifier"`
+	Channel    string `json:"channel"`
+}
+
+func (svr *SendVerificationRequest) Validate() error {
+	var errs ValidationErrors
+	if svr.Identifier == "" {
+		errs.Add("identifier", "is required")
+	}
+	svr.Identifier = util.NormalizeIdentifier(svr.Identifier)
+
+	if svr.Channel != util.ChannelEmail && svr.Channel != util.ChannelPhone {
+		errs.Add("channel", "must be email or phone")
+	}
+	return errs.Err()
+}
+
+type VerifyContactRequest struct {
+	Identifier string `json:"identifier"`
+	Channel    string `json:"channel"`
+	Code       string `json:"code"`
+}
+
+func (vcr *VerifyContactRequest) Validate() error {
+	var errs ValidationErrors
+	if vcr.Identifier == "" {
+		errs.Add("identifier", "is required")
+	}
+	vcr.Identifier = util.NormalizeIdentifier(vcr.Identifier)
+
+	if vcr.Channel != util.ChannelEmail && vcr.Channel != util.ChannelPhone {
+		errs.Add("channel", "must be email or phone")
+	}
+	if vcr.Code == "" {
+		errs.Add("code", "is required")
+	}
+	return errs.Err()
+}
+
+type ForgotPasswordRequest struct {
+	Identifier string `json:"identifier"`
+}
+
+func (fpr *ForgotPasswordRequest) Validate() error {
+	var errs ValidationErrors
+	if fpr.Identifier == "" {
+		errs.Add("identifier", "is required")
+	}
+	fpr.Identifier = util.NormalizeIdentifier(fpr.Identifier)
+	return errs.Err()
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"new_password"`
+}
+
+func (rpr *ResetPasswordRequest) Validate() error {
+	var errs ValidationErrors
+	if rpr.Token == "" {
+		errs.Add("token", "is required")
+	}
+	if err := util.CheckPasswordStrength(rpr.NewPassword); err != nil {
+		errs.Add("new_password", err.Error())
+	}
+	return errs.Err()
+}
+
+type ChangePasswordRequest struct {
+	OldPassword string `json:"old_password"`
+	NewPassword string `json:"new_password"`
+}
+
+func (cpr *ChangePasswordRequest) Validate() error {
+	var errs ValidationErrors
+	if cpr.OldPassword == "" {
+		errs.Add("old_password", "is required")
+	}
+	if err := util.CheckPasswordStrength(cpr.NewPassword); err != nil {
+		errs.Add("new_password", err.Error())
+	}
+	return errs.Err()
+}
+
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token"`
+	Code           string `json:"code"`
+	ClientIP       string `json:"-"`
+}
+
+// Validate accepts either a TOTP code or a recovery code
+func (tflr *TwoFactorLoginRequest) Validate() error {
+	var errs ValidationErrors
+	if tflr.ChallengeToken == "" {
+		errs.Add("challenge_token", "is required")
+	}
+	tflr.Code = strings.ReplaceAll(strings.TrimSpace(tflr.Code), " ", "")
+	if tflr.Code == "" {
+		errs.Add("code", "is required")
+	}
+	return errs.Err()
+}
+
+type TOTPCodeRequest struct {
+	Code string `json:"code"`
+}
+
+func (tcr *TOTPCodeRequest) Validate() error {
+	var errs ValidationErrors
+	tcr.Code = strings.ReplaceAll(strings.TrimSpace(tcr.Code), " ", "")
+	if tcr.Code == "" {
+		errs.Add("code", "is required")
+	}
+	return errs.Err()
+}
+
+type DisableTOTPRequest struct {
+	Password string `json:"password"`
+	Code     string `json:"code"`
+}
+
+func (dtr *DisableTOTPRequest) Validate() error {
+	var errs ValidationErrors
+	if dtr.Password == "" {
+		errs.Add("password", "is required")
+	}
+	dtr.Code = strings.ReplaceAll(strings.TrimSpace(dtr.Code), " ", "")
+	if dtr.Code == "" {
+		errs.Add("code", "is required")
+	}
+	return errs.Err()
+}
+
+// ShopProductPriceRequest changes the price immediately, or at EffectiveAt when it is in the future
+type ShopProductPriceRequest struct {
+	Price       int64      `json:"price"`
+	Currency    string     `json:"currency"`
+	EffectiveAt *time.Time `json:"effective_at"`
+}
+
+func (sppr *ShopProductPriceRequest) Validate() error {
+	var errs ValidationErrors
+	if sppr.Price < 0 {
+		errs.Add("price", "must not be negative")
+	}
+
+	var ok bool
+	if sppr.Currency, ok = util.NormalizeCurrency(sppr.Currency); !ok {
+		errs.Add("currency", "is not supported")
+	}
+	return errs.Err()
+}
+
+// PasswordConfirmationRequest confirms a sensitive action on the own account
+type PasswordConfirmationRequest struct {
+	Password string `json:"password"`
+}
+
+func (pcr *PasswordConfirmationRequest) Validate() error {
+	var errs ValidationErrors
+	if pcr.Password == "" {
+		errs.Add("password", "is required")
 	}
-	if sur.Email == "" {
-		errMessage += fmt.Sprintf(errTemplate, "email")
+	return errs.Err()
+}
+
+// UserRequest is used by admin to create or update an user, an empty password
+// on update keeps the current password
+type UserRequest struct {
+	Name       string     `json:"name"`
+	Email      string     `json:"email"`
+	Phone      string     `json:"phone"`
+	Password   string     `json:"password"`
+	UserDetail UserDetail `json:"detail"`
+}
+
+func (ur *UserRequest) Validate() error {
+	var errs ValidationErrors
+	var err error
+	if err = util.CheckName(ur.Name); err != nil {
+		errs.Add("name", err.Error())
+	}
+	ur.Name = strings.TrimSpace(ur.Name)
+
+	if ur.Email, err = util.NormalizeEmail(ur.Email); err != nil {
+		errs.Add("email", err.Error())
+	}
+	if ur.Phone, err = util.NormalizePhone(ur.Phone); err != nil {
+		errs.Add("phone", err.Error())
+	}
+	if ur.Password != "" {
+		if err = util.CheckPasswordStrength(ur.Password); err != nil {
+			errs.Add("password", err.Error())
+		}
+	}
+	for _, role := range ur.UserDetail.Roles {
+		if !util.IsValidRole(role) {
+			errs.Add("detail.roles", "contains an invalid role")
+			break
+		}
+	}
+	return errs.Err()
+}
+
+func (ur *UserRequest) ToUser() User {
+	return User{
+		Name:       ur.Name,
+		Email:      ur.Email,
+		Phone:      ur.Phone,
+		Passsword:  ur.Password,
+		UserDetail: ur.UserDetail,
 	}
-	if sur.Phone == "" {
-		errMessage += fmt.Sprintf(errTemplate, "phone")
+}
+
+// UpdateProfileRequest is used by an user to update its own profile, omitted fields are left unchanged
+type UpdateProfileRequest struct {
+	Name  *string `json:"name"`
+	Email *string `json:"email"`
+	Phone *string `json:"phone"`
+}
+
+func (upr *UpdateProfileRequest) Validate() error {
+	var errs ValidationErrors
+	if upr.Name != nil {
+		if err := util.CheckName(*upr.Name); err != nil {
+			errs.Add("name", err.Error())
+		}
+		name := strings.TrimSpace(*upr.Name)
+		upr.Name = &name
 	}
-	if sur.Password == "" {
-		errMessage += fmt.Sprintf(errTemplate, "password")
+	if upr.Email != nil {
+		email, err := util.NormalizeEmail(*upr.Email)
+		if err != nil {
+			errs.Add("email", err.Error())
+		}
+		upr.Email = &email
 	}
-	if strings.ToLower(sur.Role) != "customer" {
-		errMessage += fmt.Sprintf(errTemplate, "role")
+	if upr.Phone != nil {
+		phone, err := util.NormalizePhone(*upr.Phone)
+		if err != nil {
+			errs.Add("phone", err.Error())
+		}
+		upr.Phone = &phone
 	}
+	return errs.Err()
+}
+
+type AddressRequest struct {
+	Label      string   `json:"label"`
+	Recipient  string   `json:"recipient"`
+	Phone      string   `json:"phone"`
+	Street     string   `json:"street"`
+	City       string   `json:"city"`
+	Province   string   `json:"province"`
+	Country    string   `json:"country"`
+	PostalCode string   `json:"postal_code"`
+	Latitude   *float64 `json:"latitude"`
+	Longitude  *float64 `json:"longitude"`
+	IsDefault  bool     `json:"is_default"`
+}
 
-	//TODO: validate with more proper validataion
+func (ar *AddressRequest) Validate() error {
+	var errs ValidationErrors
+	if strings.TrimSpace(ar.Recipient) == "" {
+		errs.Add("recipient", "is required")
+	}
+	if ar.Phone != "" {
+		phone, err := util.NormalizePhone(ar.Phone)
+		if err != nil {
+			errs.Add("phone", err.Error())
+		}
+		ar.Phone = phone
+	}
+	if strings.TrimSpace(ar.Street) == "" {
+		errs.Add("street", "is required")
+	}
+	if strings.TrimSpace(ar.City) == "" {
+		errs.Add("city", "is required")
+	}
+	if strings.TrimSpace(ar.Country) == "" {
+		errs.Add("country", "is required")
+	}
+	if strings.TrimSpace(ar.PostalCode) == "" {
+		errs.Add("postal_code", "is required")
+	}
+	if (ar.Latitude == nil) != (ar.Longitude == nil) {
+		errs.Add("coordinates", "latitude and longitude must be set together")
+	}
+	if ar.Latitude != nil && (*ar.Latitude < -90 || *ar.Latitude > 90) {
+		errs.Add("latitude", "must be between -90 and 90")
+	}
+	if ar.Longitude != nil && (*ar.Longitude < -180 || *ar.Longitude > 180) {
+		errs.Add("longitude", "must be between -180 and 180")
+	}
+	return errs.Err()
+}
 
-	if errMessage != "" {
-		return errors.New(errMessage)
+// ValidateAddress validates an address that does not come from the address book,
+// e.g. the shipping address of an order, the way AddressRequest is validated
+func ValidateAddress(address *UserAddress) error {
+	req := AddressRequest{
+		Recipient:  address.Recipient,
+		Phone:      address.Phone,
+		Street:     address.Street,
+		City:       address.City,
+		Country:    address.Country,
+		PostalCode: address.PostalCode,
+		Latitude:   address.Latitude,
+		Longitude:  address.Longitude,
+	}
+	if err := req.Validate(); err != nil {
+		return err
 	}
+	address.Phone = req.Phone
 	return nil
 }
+
+func (ar *AddressRequest) ToUserAddress() UserAddress {
+	return UserAddress{
+		Label:      ar.Label,
+		Recipient:  ar.Recipient,
+		Phone:      ar.Phone,
+		Street:     ar.Street,
+		City:       ar.City,
+		Province:   ar.Province,
+		Country:    ar.Country,
+		PostalCode: ar.PostalCode,
+		Latitude:   ar.Latitude,
+		Longitude:  ar.Longitude,
+		IsDefault:  ar.IsDefault,
+	}
+}
+
+const SearchSortRelevance = "relevance"
+const SearchSortPriceAsc = "price_asc"
+const SearchSortPriceDesc = "price_desc"
+const SearchSortNewest = "newest"
+
+const searchDefaultLimit = 20
+const searchMaxLimit = 100
+
+// ProductSearchRequest filters the shop listings matched by Query, a price range is
+// compared in minor units of Currency. CategoryIDs is filled by the service with
+// CategoryID and its sub categories
+type ProductSearchRequest struct {
+	Query       string `query:"q"`
+	CategoryID  *int   `query:"category_id"`
+	CategoryIDs []int
+	ShopID      *int   `query:"shop_id"`
+	MinPrice    *int64 `query:"min_price"`
+	MaxPrice    *int64 `query:"max_price"`
+	Currency    string `query:"currency"`
+	InStock     *bool  `query:"in_stock"`
+	Sort        string `query:"sort"`
+	Page        int    `query:"page"`
+	Limit       int    `query:"limit"`
+}
+
+func (psr *ProductSearchRequest) Validate() error {
+	var errs ValidationErrors
+	psr.Query = strings.TrimSpace(psr.Query)
+
+	if psr.MinPrice != nil && *psr.MinPrice < 0 {
+		errs.Add("min_price", "must not be negative")
+	}
+	if psr.MaxPrice != nil && *psr.MaxPrice < 0 {
+		errs.Add("max_price", "must not be negative")
+	}
+	if psr.MinPrice != nil && psr.MaxPrice != nil && *psr.MinPrice > *psr.MaxPrice {
+		errs.Add("max_price", "must not be lower than min_price")
+	}
+	if psr.Currency != "" {
+		var ok bool
+		if psr.Currency, ok = util.NormalizeCurrency(psr.Currency); !ok {
+			errs.Add("currency", "is not supported")
+		}
+	}
+
+	switch psr.Sort {
+	case "":
+		psr.Sort = SearchSortRelevance
+	case SearchSortRelevance, SearchSortPriceAsc, SearchSortPriceDesc, SearchSortNewest:
+	default:
+		errs.Add("sort", "must be relevance, price_asc, price_desc or newest")
+	}
+
+	if psr.Page < 1 {
+		psr.Page = 1
+	}
+	if psr.Limit < 1 {
+		psr.Limit = searchDefaultLimit
+	}
+	if psr.Limit > searchMaxLimit {
+		errs.Add("limit", "must not be greater than 100")
+	}
+	return errs.Err()
+}
+
+func (psr *ProductSearchRequest) Offset() int {
+	return (psr.Page - 1) * psr.Limit
+}
+
+const MinReviewRating = 1
+const MaxReviewRating = 5
+const maxReviewCommentLength = 2000
+
+// ReviewRequest reviews a line of a completed order of the authenticated user, the
+// line is the one with the shop product and variant, VariantID is left out for a
+// line without a variant
+type ReviewRequest struct {
+	OrderID       int    `json:"order_id"`
+	ShopProductID int    `json:"shop_product_id"`
+	VariantID     *int   `json:"variant_id,omitempty"`
+	Rating        int    `json:"rating"`
+	Comment       string `json:"comment"`
+}
+
+func (rr *ReviewRequest) Validate() error {
+	var errs ValidationErrors
+	rr.Comment = strings.TrimSpace(rr.Comment)
+
+	if rr.OrderID < 1 {
+		errs.Add("order_id", "is required")
+	}
+	if rr.ShopProductID < 1 {
+		errs.Add("shop_product_id", "is required")
+	}
+	if rr.Rating < MinReviewRating || rr.Rating > MaxReviewRating {
+		errs.Add("rating", "must be between 1 and 5")
+	}
+	if utf8.RuneCountInString(rr.Comment) > maxReviewCommentLength {
+		errs.Add("comment", "must not be longer than 2000 characters")
+	}
+	return errs.Err()
+}
+
+type ReviewReplyRequest struct {
+	Reply string `json:"reply"`
+}
+
+func (rrr *ReviewReplyRequest) Validate() error {
+	var errs ValidationErrors
+	rrr.Reply = strings.TrimSpace(rrr.Reply)
+
+	if rrr.Reply == "" {
+		errs.Add("reply", "is required")
+	}
+	if utf8.RuneCountInString(rrr.Reply) > maxReviewCommentLength {
+		errs.Add("reply", "must not be longer than 2000 characters")
+	}
+	return errs.Err()
+}
+
+type ReviewFlagRequest struct {
+	Reason string `json:"reason"`
+}
+
+func (rfr *ReviewFlagRequest) Validate() error {
+	var errs ValidationErrors
+	rfr.Reason = strings.TrimSpace(rfr.Reason)
+
+	if rfr.Reason == "" {
+		errs.Add("reason", "is required")
+	}
+	if utf8.RuneCountInString(rfr.Reason) > maxReviewCommentLength {
+		errs.Add("reason", "must not be longer than 2000 characters")
+	}
+	return errs.Err()
+}
+
+// ReviewModerationRequest publishes a flagged review again or hides it
+type ReviewModerationRequest struct {
+	Status string `json:"status"`
+}
+
+func (rmr *ReviewModerationRequest) Validate() error {
+	var errs ValidationErrors
+	if rmr.Status != ReviewStatusPublished && rmr.Status != ReviewStatusHidden {
+		errs.Add("status", "must be published or hidden")
+	}
+	return errs.Err()
+}
+
+// OrderStatusRequest moves an order to the next status of its fulfilment
+type OrderStatusRequest struct {
+	Status string `json:"status"`
+}
+
+func (osr *OrderStatusRequest) Validate() error {
+	var errs ValidationErrors
+	if !IsValidOrderStatus(osr.Status) {
+		errs.Add("status", "must be pending, shipped, completed or cancelled")
+	}
+	return errs.Err()
+}
+
+const ReviewSortNewest = "newest"
+const ReviewSortOldest = "oldest"
+const ReviewSortRatingDesc = "rating_desc"
+const ReviewSortRatingAsc = "rating_asc"
+
+// ReviewListRequest filters the reviews of ProductID, which is taken from the path,
+// hidden reviews are never listed
+type ReviewListRequest struct {
+	ProductID   int
+	ShopID      *int  `query:"shop_id"`
+	VariantID   *int  `query:"variant_id"`
+	Rating      *int  `query:"rating"`
+	MinRating   *int  `query:"min_rating"`
+	WithComment *bool `query:"with_comment"`
+	WithReply   *bool `query:"with_reply"`
+
+	Sort  string `query:"sort"`
+	Page  int    `query:"page"`
+	Limit int    `query:"limit"`
+}
+
+func (rlr *ReviewListRequest) Validate() error {
+	var errs ValidationErrors
+	if rlr.Rating != nil && (*rlr.Rating < MinReviewRating || *rlr.Rating > MaxReviewRating) {
+		errs.Add("rating", "must be between 1 and 5")
+	}
+	if rlr.MinRating != nil && (*rlr.MinRating < MinReviewRating || *rlr.MinRating > MaxReviewRating) {
+		errs.Add("min_rating", "must be between 1 and 5")
+	}
+
+	switch rlr.Sort {
+	case "":
+		rlr.Sort = ReviewSortNewest
+	case ReviewSortNewest, ReviewSortOldest, ReviewSortRatingDesc, ReviewSortRatingAsc:
+	default:
+		errs.Add("sort", "must be newest, oldest, rating_desc or rating_asc")
+	}
+
+	if rlr.Page < 1 {
+		rlr.Page = 1
+	}
+	if rlr.Limit < 1 {
+		rlr.Limit = searchDefaultLimit
+	}
+	if rlr.Limit > searchMaxLimit {
+		errs.Add("limit", "must not be greater than 100")
+	}
+	return errs.Err()
+}
+
+func (rlr *ReviewListRequest) Offset() int {
+	return (rlr.Page - 1) * rlr.Limit
+}
+
+// ShopInvitationRequest invites the user with the email or phone to the shop,
+// the owner role cannot be given
+type ShopInvitationRequest struct {
+	Email string `json:"email"`
+	Phone string `json:"phone"`
+	Role  string `json:"role"`
+}
+
+func (sir *ShopInvitationRequest) Validate() error {
+	var errs ValidationErrors
+	switch {
+	case sir.Email != "" && sir.Phone != "":
+		errs.Add("email", "must not be set together with phone")
+	case sir.Email != "":
+		email, err := util.NormalizeEmail(sir.Email)
+		if err != nil {
+			errs.Add("email", err.Error())
+		}
+		sir.Email = email
+	case sir.Phone != "":
+		phone, err := util.NormalizePhone(sir.Phone)
+		if err != nil {
+			errs.Add("phone", err.Error())
+		}
+		sir.Phone = phone
+	default:
+		errs.Add("email", "email or phone is required")
+	}
+
+	if sir.Role != ShopRoleManager && sir.Role != ShopRoleStaff {
+		errs.Add("role", "must be manager or staff")
+	}
+	return errs.Err()
+}
+
+type AcceptShopInvitationRequest struct {
+	Token string `json:"token"`
+}
+
+func (asir *AcceptShopInvitationRequest) Validate() error {
+	var errs ValidationErrors
+	asir.Token = strings.TrimSpace(asir.Token)
+	if asir.Token == "" {
+		errs.Add("token", "is required")
+	}
+	return errs.Err()
+}
+
+type ShopMemberRoleRequest struct {
+	Role string `json:"role"`
+}
+
+func (smrr *ShopMemberRoleRequest) Validate() error {
+	var errs ValidationErrors
+	if smrr.Role != ShopRoleManager && smrr.Role != ShopRoleStaff {
+		errs.Add("role", "must be manager or staff")
+	}
+	return errs.Err()
+}
+
+type StorefrontRequest struct {
+	ShopID int
+	Page   int `query:"page"`
+	Limit  int `query:"limit"`
+}
+
+func (sr *StorefrontRequest) Validate() error {
+	var errs ValidationErrors
+	if sr.Page < 1 {
+		sr.Page = 1
+	}
+	if sr.Limit < 1 {
+		sr.Limit = searchDefaultLimit
+	}
+	if sr.Limit > searchMaxLimit {
+		errs.Add("limit", "must not be greater than 100")
+	}
+	return errs.Err()
+}
+
+func (sr *StorefrontRequest) Offset() int {
+	return (sr.Page - 1) * sr.Limit
+}
+
+// ShopProductListRequest filters the shop products of a shop, InStock compares the
+// stock of the shop product
+type ShopProductListRequest struct {
+	ShopID    int
+	Status    Status `query:"status"`
+	ProductID *int   `query:"product_id"`
+	VariantID *int   `query:"variant_id"`
+	InStock   *bool  `query:"in_stock"`
+
+	Page  int `query:"page"`
+	Limit int `query:"limit"`
+}
+
+func (splr *ShopProductListRequest) Validate() error {
+	var errs ValidationErrors
+	if splr.Status != "" && !splr.Status.IsValid() {
+		errs.Add("status", "must be draft, active, suspended or archived")
+	}
+	if splr.Page < 1 {
+		splr.Page = 1
+	}
+	if splr.Limit < 1 {
+		splr.Limit = searchDefaultLimit
+	}
+	if splr.Limit > searchMaxLimit {
+		errs.Add("limit", "must not be greater than 100")
+	}
+	return errs.Err()
+}
+
+func (splr *ShopProductListRequest) Offset() int {
+	return (splr.Page - 1) * splr.Limit
+}
+
+// WarehouseListRequest filters the warehouses of a shop, Query matches part of the
+// name or location
+type WarehouseListRequest struct {
+	ShopID int
+	Status Status `query:"status"`
+	Query  string `query:"q"`
+
+	Page  int `query:"page"`
+	Limit int `query:"limit"`
+}
+
+func (wlr *WarehouseListRequest) Validate() error {
+	var errs ValidationErrors
+	if wlr.Status != "" && !wlr.Status.IsValid() {
+		errs.Add("status", "must be draft, active, suspended or archived")
+	}
+	wlr.Query = strings.TrimSpace(wlr.Query)
+	if wlr.Page < 1 {
+		wlr.Page = 1
+	}
+	if wlr.Limit < 1 {
+		wlr.Limit = searchDefaultLimit
+	}
+	if wlr.Limit > searchMaxLimit {
+		errs.Add("limit", "must not be greater than 100")
+	}
+	return errs.Err()
+}
+
+func (wlr *WarehouseListRequest) Offset() int {
+	return (wlr.Page - 1) * wlr.Limit
+}
+
+type StoredProductListRequest struct {
+	WarehouseID   int
+	ShopProductID *int  `query:"shop_product_id"`
+	VariantID     *int  `query:"variant_id"`
+	InStock       *bool `query:"in_stock"`
+
+	Page  int `query:"page"`
+	Limit int `query:"limit"`
+}
+
+func (splr *StoredProductListRequest) Validate() error {
+	var errs ValidationErrors
+	if splr.Page < 1 {
+		splr.Page = 1
+	}
+	if splr.Limit < 1 {
+		splr.Limit = searchDefaultLimit
+	}
+	if splr.Limit > searchMaxLimit {
+		errs.Add("limit", "must not be greater than 100")
+	}
+	return errs.Err()
+}
+
+func (splr *StoredProductListRequest) Offset() int {
+	return (splr.Page - 1) * splr.Limit
+}
@@ -0,0 +1,130 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignUpRequestValidate(t *testing.T) {
+	valid := func() SignUpRequest {
+		return SignUpRequest{
+			Name:     "Jane Doe",
+			Email:    "jane@example.com",
+			Phone:    "+6281234567890",
+			Password: "Secr3t-Passw0rd",
+		}
+	}
+
+	tests := []struct {
+		name       string
+		modify     func(req *SignUpRequest)
+		wantFields []string
+	}{
+		{"valid without role", func(req *SignUpRequest) {}, nil},
+		{"customer role", func(req *SignUpRequest) { req.Role = "Customer" }, nil},
+		{"seller role is not self-assignable", func(req *SignUpRequest) { req.Role = "seller" }, []string{"role"}},
+		{"admin role is not self-assignable", func(req *SignUpRequest) { req.Role = "admin" }, []string{"role"}},
+		{"local phone is normalized", func(req *SignUpRequest) { req.Phone = "0812-3456-7890" }, nil},
+		{"bare phone is ambiguous", func(req *SignUpRequest) { req.Phone = "81234567890" }, []string{"phone"}},
+		{"invalid email", func(req *SignUpRequest) { req.Email = "jane@localhost" }, []string{"email"}},
+		{"weak password", func(req *SignUpRequest) { req.Password = "password" }, []string{"password"}},
+		{"every invalid field is reported", func(req *SignUpRequest) {
+			req.Name = "J"
+			req.Email = ""
+			req.Phone = ""
+		}, []string{"name", "email", "phone"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid()
+			tt.modify(&req)
+			err := req.Validate()
+			if tt.wantFields == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var errs ValidationErrors
+			assert.ErrorAs(t, err, &errs)
+			var fields []string
+			for _, fe := range errs {
+				fields = append(fields, fe.Field)
+			}
+			assert.Equal(t, tt.wantFields, fields)
+		})
+	}
+}
+
+func TestLoginRequestValidate(t *testing.T) {
+	tests := []struct {
+		name           string
+		req            LoginRequest
+		wantIdentifier string
+		wantErr        bool
+	}{
+		{"email is lowercased", LoginRequest{Identifier: "Jane@Example.com", Password: "x"}, "jane@example.com", false},
+		{"local phone is normalized", LoginRequest{Identifier: "0812 3456 7890", Password: "x"}, "+6281234567890", false},
+		{"missing identifier", LoginRequest{Password: "x"}, "", true},
+		{"missing password", LoginRequest{Identifier: "jane@example.com"}, "jane@example.com", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantIdentifier, tt.req.Identifier)
+		})
+	}
+}
+
+func TestValidateAddress(t *testing.T) {
+	valid := func() UserAddress {
+		return UserAddress{
+			Recipient:  "Jane Doe",
+			Phone:      "0812-3456-7890",
+			Street:     "Jl. Sudirman 1",
+			City:       "Jakarta",
+			Country:    "ID",
+			PostalCode: "10220",
+		}
+	}
+	latitude := 91.0
+
+	tests := []struct {
+		name       string
+		modify     func(address *UserAddress)
+		wantFields []string
+	}{
+		{"valid address", func(address *UserAddress) {}, nil},
+		{"missing street and city", func(address *UserAddress) {
+			address.Street = " "
+			address.City = ""
+		}, []string{"street", "city"}},
+		{"invalid phone", func(address *UserAddress) { address.Phone = "12" }, []string{"phone"}},
+		{"coordinates out of range", func(address *UserAddress) {
+			address.Latitude = &latitude
+			address.Longitude = &latitude
+		}, []string{"latitude"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			address := valid()
+			tt.modify(&address)
+			err := ValidateAddress(&address)
+			if tt.wantFields == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, "+6281234567890", address.Phone)
+				return
+			}
+			var errs ValidationErrors
+			assert.ErrorAs(t, err, &errs)
+			var fields []string
+			for _, fe := range errs {
+				fields = append(fields, fe.Field)
+			}
+			assert.Equal(t, tt.wantFields, fields)
+		})
+	}
+}
@@ -1,10 +1,156 @@
 package model
 
+import "time"
+
 type Response struct {
 	Message string      `json:"message"`
 	Data    interface{} `json:"data"`
 }
 
+// LoginData carries only a ChallengeToken when the user still has to pass two-factor authentication
 type LoginData struct {
-	Token string `json:"token"`
+	Token             string `json:"token,omitempty"`
+	RefreshToken      string `json:"refresh_token,omitempty"`
+	TwoFactorRequired bool   `json:"two_factor_required,omitempty"`
+	ChallengeToken    string `json:"challenge_token,omitempty"`
+}
+
+type UserResponse struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	Email      string     `json:"email"`
+	Phone      string     `json:"phone"`
+	UserDetail UserDetail `json:"detail"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+type OIDCLoginData struct {
+	AuthorizationURL string `json:"authorization_url"`
+}
+
+// UserExport bundles every personal data of an user, returned as a downloadable archive
+type UserExport struct {
+	ExportedAt time.Time     `json:"exported_at"`
+	Profile    UserResponse  `json:"profile"`
+	Addresses  []UserAddress `json:"addresses"`
+	Shops      []Shop        `json:"shops"`
+	Orders     []Order       `json:"orders"`
+}
+
+// ShopProductPrices splits the price changes of a shop product, History is newest first
+type ShopProductPrices struct {
+	Current   *ShopProductPrice  `json:"current"`
+	Scheduled []ShopProductPrice `json:"scheduled"`
+	History   []ShopProductPrice `json:"history"`
+}
+
+type ImageData struct {
+	ImageURL     string `json:"image_url"`
+	ThumbnailURL string `json:"thumbnail_url"`
+}
+
+type TOTPEnrolmentData struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+type RecoveryCodesData struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// ProductSearchHit is a shop listing matching a search with the product it sells,
+// Rank is zero when the search has no query
+type ProductSearchHit struct {
+	ShopProduct ShopProduct `json:"shop_product"`
+	Product     Product     `json:"product"`
+	Rank        float64     `json:"rank"`
+}
+
+// FacetCount is the number of hits sharing a facet value
+type FacetCount struct {
+	Value interface{} `json:"value"`
+	Count int64       `json:"count"`
+}
+
+// SearchFacets are counted over every hit of the search, each facet ignores its
+// own filter so the other values stay selectable
+type SearchFacets struct {
+	Categories []FacetCount `json:"categories"`
+	Shops      []FacetCount `json:"shops"`
+	InStock    []FacetCount `json:"in_stock"`
+}
+
+type ProductSearchResult struct {
+	Hits   []ProductSearchHit `json:"hits"`
+	Facets SearchFacets       `json:"facets"`
+	Total  int64              `json:"total"`
+	Page   int                `json:"page"`
+	Limit  int                `json:"limit"`
+}
+
+// RatingSummary aggregates the ratings of the reviews that are not hidden, Stars
+// counts the reviews per rating from 1 to 5
+type RatingSummary struct {
+	Average float64       `json:"average"`
+	Count   int64         `json:"count"`
+	Stars   map[int]int64 `json:"stars"`
+}
+
+type ReviewList struct {
+	Reviews []Review      `json:"reviews"`
+	Rating  RatingSummary `json:"rating"`
+	Total   int64         `json:"total"`
+	Page    int           `json:"page"`
+	Limit   int           `json:"limit"`
+}
+
+// StorefrontListing is an active shop listing with the product and variant it sells,
+// SellableStock is the stock stored in the active warehouses of the shop
+type StorefrontListing struct {
+	ShopProduct   ShopProduct     `json:"shop_product"`
+	Product       Product         `json:"product"`
+	Variant       *ProductVariant `json:"variant,omitempty"`
+	SellableStock int             `json:"sellable_stock"`
+}
+
+// StorefrontShop is the public part of a shop, the owner and the contact details
+// are left out
+type StorefrontShop struct {
+	ID           int       `json:"id"`
+	Name         string    `json:"name"`
+	Location     string    `json:"location"`
+	ImageURL     string    `json:"image_url"`
+	ThumbnailURL string    `json:"thumbnail_url,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// Storefront is the public view of a shop
+type Storefront struct {
+	Shop     StorefrontShop      `json:"shop"`
+	Listings []StorefrontListing `json:"listings"`
+	Total    int64               `json:"total"`
+	Page     int                 `json:"page"`
+	Limit    int                 `json:"limit"`
+}
+
+type ShopProductList struct {
+	ShopProducts []ShopProduct `json:"shop_products"`
+	Total        int64         `json:"total"`
+	Page         int           `json:"page"`
+	Limit        int           `json:"limit"`
+}
+
+type WarehouseList struct {
+	Warehouses []Warehouse `json:"warehouses"`
+	Total      int64       `json:"total"`
+	Page       int         `json:"page"`
+	Limit      int         `json:"limit"`
+}
+
+type StoredProductList struct {
+	StoredProducts []WarehouseStoredProduct `json:"stored_products"`
+	Total          int64                    `json:"total"`
+	Page           int                      `json:"page"`
+	Limit          int                      `json:"limit"`
 }
@@ -0,0 +1,66 @@
+package model
+
+import "time"
+
+const ReviewStatusPublished = "published"
+const ReviewStatusFlagged = "flagged"
+const ReviewStatusHidden = "hidden"
+
+// Review is written by the buyer of a completed order line, ProductID, VariantID
+// and ShopID are copied from the order line. FlagCount counts the flags since
+// the review was last moderated
+type Review struct {
+	ID               int        `json:"id" gorm:"column:id"`
+	OrderID          int        `json:"order_id" gorm:"column:order_id"`
+	ShopProductID    int        `json:"shop_product_id" gorm:"column:shop_product_id"`
+	ProductID        int        `json:"product_id" gorm:"column:product_id"`
+	VariantID        *int       `json:"variant_id,omitempty" gorm:"column:variant_id"`
+	ShopID           int        `json:"shop_id" gorm:"column:shop_id"`
+	UserID           int        `json:"user_id" gorm:"column:user_id"`
+	Rating           int        `json:"rating" gorm:"column:rating"`
+	Comment          string     `json:"comment" gorm:"column:comment"`
+	Reply            string     `json:"reply,omitempty" gorm:"column:reply"`
+	RepliedBy        *int       `json:"replied_by,omitempty" gorm:"column:replied_by"`
+	RepliedAt        *time.Time `json:"replied_at,omitempty" gorm:"column:replied_at"`
+	ModerationStatus string     `json:"moderation_status" gorm:"column:moderation_status"`
+	FlagCount        int        `json:"flag_count" gorm:"column:flag_count"`
+	CreatedAt        time.Time  `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt        time.Time  `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (Review) TableName() string {
+	return "reviews"
+}
+
+// Flag counts a new flag, a published review waits for moderation once flagged
+// and is hidden when hideThreshold flags are reached, zero never hides it
+func (r *Review) Flag(hideThreshold int, at time.Time) {
+	r.FlagCount++
+	if r.ModerationStatus == ReviewStatusPublished {
+		r.ModerationStatus = ReviewStatusFlagged
+	}
+	if hideThreshold > 0 && r.FlagCount >= hideThreshold && r.ModerationStatus == ReviewStatusFlagged {
+		r.ModerationStatus = ReviewStatusHidden
+	}
+	r.UpdatedAt = at
+}
+
+// Moderate publishes or hides the review and clears its flags
+func (r *Review) Moderate(status string, at time.Time) {
+	r.ModerationStatus = status
+	r.FlagCount = 0
+	r.UpdatedAt = at
+}
+
+// ReviewFlag reports a review for moderation, an user flags a review only once
+type ReviewFlag struct {
+	ID        int       `json:"id" gorm:"column:id"`
+	ReviewID  int       `json:"review_id" gorm:"column:review_id"`
+	UserID    int       `json:"user_id" gorm:"column:user_id"`
+	Reason    string    `json:"reason" gorm:"column:reason"`
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+}
+
+func (ReviewFlag) TableName() string {
+	return "review_flags"
+}
@@ -0,0 +1,54 @@
+package model
+
+import "time"
+
+type Session struct {
+	TokenID   string    `json:"token_id"`
+	UserID    int       `json:"user_id"`
+	Roles     []string  `json:"roles"`
+	FamilyID  string    `json:"family_id"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiredAt time.Time `json:"expired_at"`
+}
+
+// RefreshToken is stored by the hash of the opaque token handed to the client
+type RefreshToken struct {
+	TokenHash string    `json:"token_hash"`
+	FamilyID  string    `json:"family_id"`
+	UserID    int       `json:"user_id"`
+	ExpiredAt time.Time `json:"expired_at"`
+}
+
+// TokenFamily groups every refresh token rotated from the same login
+type TokenFamily struct {
+	ID        string    `json:"id"`
+	UserID    int       `json:"user_id"`
+	TokenID   string    `json:"token_id"`
+	ExpiredAt time.Time `json:"expired_at"`
+}
+
+// OIDCState keeps the PKCE verifier and nonce of a pending OpenID Connect login
+type OIDCState struct {
+	Provider     string `json:"provider"`
+	CodeVerifier string `json:"code_verifier"`
+	Nonce        string `json:"nonce"`
+}
+
+// OIDCIdentity holds the verified ID token claims of an OpenID Connect login
+type OIDCIdentity struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// LoginChallenge is the pending second login step of an user with two-factor
+// authentication, stored by the hash of the challenge token. IdentifierKey is the
+// login attempt key of the first step, wrong codes count toward the same lock.
+type LoginChallenge struct {
+	TokenHash     string    `json:"token_hash"`
+	UserID        int       `json:"user_id"`
+	IdentifierKey string    `json:"identifier_key"`
+	ExpiredAt     time.Time `json:"expired_at"`
+}
@@ -5,27 +5,60 @@ import (
 	"encoding/json"
 	"errors"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type Shop struct {
 	ID        int        `json:"id" gorm:"column:id"`
 	UserID    int        `json:"user_id" gorm:"column:user_id"`
 	Name      string     `json:"name" gorm:"column:name"`
-	Status    string     `json:"status" gorm:"column:status"`
+	Status    Status     `json:"status" gorm:"column:status"`
 	Location  string     `json:"location" gorm:"column:location"`
 	Detail    ShopDetail `json:"detail" gorm:"type:jsonb;column:detail"`
 	CreatedAt time.Time  `json:"created_at" gorm:"column:created_at"`
 	UpdatedAt time.Time  `json:"updated_at" gorm:"column:updated_at"`
+
+	statusChange *StatusChange
 }
 
 func (Shop) TableName() string {
 	return "shops"
 }
 
+func (s Shop) ToStorefront() StorefrontShop {
+	return StorefrontShop{
+		ID:           s.ID,
+		Name:         s.Name,
+		Location:     s.Location,
+		ImageURL:     s.Detail.ImageURL,
+		ThumbnailURL: s.Detail.ThumbnailURL,
+		CreatedAt:    s.CreatedAt,
+	}
+}
+
+// RecordStatusChange moves the shop from its stored status, the change is recorded
+// when the shop is saved
+func (s *Shop) RecordStatusChange(from Status, to Status, by int, at time.Time) {
+	s.statusChange = newStatusChange(StatusEntityShop, from, to, by, at)
+	s.Status = to
+}
+
+// KeepStatus restores the stored status and drops a pending status change
+func (s *Shop) KeepStatus(status Status) {
+	s.Status = status
+	s.statusChange = nil
+}
+
+func (s *Shop) AfterSave(tx *gorm.DB) error {
+	return saveStatusChange(tx, &s.statusChange, s.ID)
+}
+
 type ShopDetail struct {
-	Contact   Contact   `json:"contact"`
-	Addresses []Address `json:"addresses"`
-	ImageURL  string    `json:"image_url"`
+	Contact      Contact   `json:"contact"`
+	Addresses    []Address `json:"addresses"`
+	ImageURL     string    `json:"image_url"`
+	ThumbnailURL string    `json:"thumbnail_url,omitempty"`
 }
 
 // Implement the Valuer interface for Detail
@@ -45,18 +78,57 @@ func (d *ShopDetail) Scan(value interface{}) error {
 type ShopProduct struct {
 	ID        int                `json:"id" gorm:"column:id"`
 	ProductID int                `json:"product_id" gorm:"column:product_id"`
+	VariantID *int               `json:"variant_id" gorm:"column:variant_id"`
 	ShopID    int                `json:"shop_id" gorm:"column:shop_id"`
-	Status    string             `json:"status" gorm:"column:status"`
+	Status    Status             `json:"status" gorm:"column:status"`
 	Stock     int                `json:"stock" gorm:"column:stock"`
+	Price     int64              `json:"price" gorm:"column:price"`
+	Currency  string             `json:"currency" gorm:"column:currency"`
 	Detail    ShopProductDetails `json:"detail" gorm:"type:jsonb;column:detail"`
 	CreatedAt time.Time          `json:"created_at" gorm:"column:created_at"`
 	UpdatedAt time.Time          `json:"updated_at" gorm:"column:updated_at"`
+
+	statusChange *StatusChange
 }
 
 func (ShopProduct) TableName() string {
 	return "shop_products"
 }
 
+// RecordStatusChange moves the shop product from its stored status, the change is recorded
+// when the shop product is saved
+func (sp *ShopProduct) RecordStatusChange(from Status, to Status, by int, at time.Time) {
+	sp.statusChange = newStatusChange(StatusEntityShopProduct, from, to, by, at)
+	sp.Status = to
+}
+
+// KeepStatus restores the stored status and drops a pending status change
+func (sp *ShopProduct) KeepStatus(status Status) {
+	sp.Status = status
+	sp.statusChange = nil
+}
+
+func (sp *ShopProduct) AfterSave(tx *gorm.DB) error {
+	return saveStatusChange(tx, &sp.statusChange, sp.ID)
+}
+
+// ShopProductPrice is a price change of a shop product in minor units of its
+// currency, a change is scheduled until AppliedAt is set at its EffectiveAt
+type ShopProductPrice struct {
+	ID            int        `json:"id" gorm:"column:id"`
+	ShopProductID int        `json:"shop_product_id" gorm:"column:shop_product_id"`
+	Price         int64      `json:"price" gorm:"column:price"`
+	Currency      string     `json:"currency" gorm:"column:currency"`
+	EffectiveAt   time.Time  `json:"effective_at" gorm:"column:effective_at"`
+	AppliedAt     *time.Time `json:"applied_at" gorm:"column:applied_at"`
+	CreatedBy     int        `json:"created_by" gorm:"column:created_by"`
+	CreatedAt     time.Time  `json:"created_at" gorm:"column:created_at"`
+}
+
+func (ShopProductPrice) TableName() string {
+	return "shop_product_prices"
+}
+
 type ShopProductDetails struct {
 	ShopProductDetails []ShopProductDetail `json:"shop_product_details"`
 }
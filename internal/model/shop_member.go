@@ -0,0 +1,82 @@
+package model
+
+import "time"
+
+const ShopRoleOwner = "owner"
+const ShopRoleManager = "manager"
+const ShopRoleStaff = "staff"
+
+// shopRoleRanks orders the shop roles, a member manages the members ranked below
+var shopRoleRanks = map[string]int{
+	ShopRoleOwner:   3,
+	ShopRoleManager: 2,
+	ShopRoleStaff:   1,
+}
+
+func IsValidShopRole(role string) bool {
+	_, ok := shopRoleRanks[role]
+	return ok
+}
+
+// ShopMember gives an user access to a shop, the owner of a shop is the member
+// with the owner role and matches Shop.UserID
+type ShopMember struct {
+	ID        int       `json:"id" gorm:"column:id"`
+	ShopID    int       `json:"shop_id" gorm:"column:shop_id"`
+	UserID    int       `json:"user_id" gorm:"column:user_id"`
+	Role      string    `json:"role" gorm:"column:role"`
+	InvitedBy *int      `json:"invited_by,omitempty" gorm:"column:invited_by"`
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (ShopMember) TableName() string {
+	return "shop_members"
+}
+
+// HasRole reports whether the member holds at least one of the given roles
+func (sm ShopMember) HasRole(roles ...string) bool {
+	for _, role := range roles {
+		if sm.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+// CanManage reports whether the member can invite, remove or give a role, only
+// roles ranked below the own role can be managed
+func (sm ShopMember) CanManage(role string) bool {
+	return shopRoleRanks[role] < shopRoleRanks[sm.Role]
+}
+
+const ShopInvitationPending = "pending"
+const ShopInvitationAccepted = "accepted"
+const ShopInvitationRevoked = "revoked"
+
+// ShopInvitation is sent to an email or phone, it is accepted by the user who
+// verified that contact with the token sent to it
+type ShopInvitation struct {
+	ID         int        `json:"id" gorm:"column:id"`
+	ShopID     int        `json:"shop_id" gorm:"column:shop_id"`
+	Email      string     `json:"email,omitempty" gorm:"column:email"`
+	Phone      string     `json:"phone,omitempty" gorm:"column:phone"`
+	Role       string     `json:"role" gorm:"column:role"`
+	TokenHash  string     `json:"-" gorm:"column:token_hash"`
+	Status     string     `json:"status" gorm:"column:status"`
+	InvitedBy  int        `json:"invited_by" gorm:"column:invited_by"`
+	AcceptedBy *int       `json:"accepted_by,omitempty" gorm:"column:accepted_by"`
+	ExpiresAt  time.Time  `json:"expires_at" gorm:"column:expires_at"`
+	AcceptedAt *time.Time `json:"accepted_at,omitempty" gorm:"column:accepted_at"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt  time.Time  `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (ShopInvitation) TableName() string {
+	return "shop_invitations"
+}
+
+// IsOpen reports whether the invitation can still be accepted
+func (si ShopInvitation) IsOpen(at time.Time) bool {
+	return si.Status == ShopInvitationPending && at.Before(si.ExpiresAt)
+}
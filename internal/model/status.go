@@ -0,0 +1,93 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Status is the lifecycle state of a product, shop, shop product or warehouse
+type Status string
+
+const StatusDraft Status = "draft"
+const StatusActive Status = "active"
+const StatusSuspended Status = "suspended"
+const StatusArchived Status = "archived"
+
+// statusTransitions lists the statuses each status can move to, archived is final
+var statusTransitions = map[Status][]Status{
+	StatusDraft:     {StatusActive, StatusArchived},
+	StatusActive:    {StatusSuspended, StatusArchived},
+	StatusSuspended: {StatusActive, StatusArchived},
+	StatusArchived:  {},
+}
+
+func (s Status) IsValid() bool {
+	_, ok := statusTransitions[s]
+	return ok
+}
+
+// IsInitial reports whether an entity can be created with the status
+func (s Status) IsInitial() bool {
+	return s == StatusDraft || s == StatusActive
+}
+
+// CanTransitionTo reports whether the status can move to another status, a status
+// stored before statuses were enforced can move to any valid status
+func (s Status) CanTransitionTo(to Status) bool {
+	if !s.IsValid() {
+		return to.IsValid()
+	}
+	for _, next := range statusTransitions[s] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+const StatusEntityProduct = "product"
+const StatusEntityShop = "shop"
+const StatusEntityShopProduct = "shop_product"
+const StatusEntityWarehouse = "warehouse"
+
+// StatusChange records who moved an entity to a status and when, FromStatus is
+// empty for the status an entity was created with
+type StatusChange struct {
+	ID         int       `json:"id" gorm:"column:id"`
+	EntityType string    `json:"entity_type" gorm:"column:entity_type"`
+	EntityID   int       `json:"entity_id" gorm:"column:entity_id"`
+	FromStatus Status    `json:"from_status" gorm:"column:from_status"`
+	ToStatus   Status    `json:"to_status" gorm:"column:to_status"`
+	ChangedBy  int       `json:"changed_by" gorm:"column:changed_by"`
+	ChangedAt  time.Time `json:"changed_at" gorm:"column:changed_at"`
+}
+
+func (StatusChange) TableName() string {
+	return "status_changes"
+}
+
+func newStatusChange(entityType string, from Status, to Status, by int, at time.Time) *StatusChange {
+	return &StatusChange{
+		EntityType: entityType,
+		FromStatus: from,
+		ToStatus:   to,
+		ChangedBy:  by,
+		ChangedAt:  at,
+	}
+}
+
+// saveStatusChange stores the pending status change of a saved entity, it runs in
+// the AfterSave hook so it shares the transaction of the entity
+func saveStatusChange(tx *gorm.DB, pending **StatusChange, entityID int) error {
+	if *pending == nil {
+		return nil
+	}
+	change := *pending
+	change.EntityID = entityID
+	if err := tx.Session(&gorm.Session{NewDB: true}).Create(change).Error; err != nil {
+		return err
+	}
+	*pending = nil
+	return nil
+}
@@ -4,7 +4,10 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type User struct {
@@ -12,19 +15,115 @@ type User struct {
 	Name       string     `json:"name" gorm:"column:name"`
 	Email      string     `json:"email" gorm:"column:email"`
 	Phone      string     `json:"phone" gorm:"column:phone"`
-	Passsword  string     `json:"password" gorm:"column:password"`
+	Passsword  string     `json:"-" gorm:"column:password"`
 	UserDetail UserDetail `json:"detail" gorm:"type:jsonb;column:detail"`
 	CreatedAt  time.Time  `json:"created_at" gorm:"column:created_at"`
 	UpdatedAt  time.Time  `json:"updated_at" gorm:"column:updated_at"`
+
+	// DeletedAt makes Delete a soft delete, deleted users are excluded from every query
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"column:deleted_at"`
 }
 
 func (User) TableName() string {
 	return "users"
 }
 
+func (u User) ToResponse() UserResponse {
+	detail := u.UserDetail
+	// the TOTP secret and recovery code hashes never leave the server
+	detail.TwoFactor = TwoFactor{Enabled: u.UserDetail.TwoFactor.Enabled}
+
+	return UserResponse{
+		ID:         u.ID,
+		Name:       u.Name,
+		Email:      u.Email,
+		Phone:      u.Phone,
+		UserDetail: detail,
+		CreatedAt:  u.CreatedAt,
+		UpdatedAt:  u.UpdatedAt,
+	}
+}
+
 type UserDetail struct {
-	Roles     []string  `json:"roles"`
-	Addresses []Address `json:"addresses"`
+	Roles         []string      `json:"roles"`
+	Addresses     []UserAddress `json:"addresses"`
+	EmailVerified bool          `json:"email_verified"`
+	PhoneVerified bool          `json:"phone_verified"`
+	Identities    []Identity    `json:"identities,omitempty"`
+	TwoFactor     TwoFactor     `json:"two_factor"`
+	DeactivatedAt *time.Time    `json:"deactivated_at,omitempty"`
+}
+
+// TwoFactor holds the TOTP enrolment, PendingSecret is set until the user
+// confirms the enrolment with a code from the authenticator app
+type TwoFactor struct {
+	Enabled            bool     `json:"enabled"`
+	Secret             string   `json:"secret,omitempty"`
+	PendingSecret      string   `json:"pending_secret,omitempty"`
+	LastUsedStep       int64    `json:"last_used_step,omitempty"`
+	RecoveryCodeHashes []string `json:"recovery_code_hashes,omitempty"`
+}
+
+// Identity links the user to a subject of an external OpenID Connect provider
+type Identity struct {
+	Provider string `json:"provider"`
+	Subject  string `json:"subject"`
+}
+
+// DefaultAddress returns the default address, nil when the user has no address
+func (d UserDetail) DefaultAddress() *UserAddress {
+	for i := range d.Addresses {
+		if d.Addresses[i].IsDefault {
+			return &d.Addresses[i]
+		}
+	}
+	return nil
+}
+
+type UserAddress struct {
+	ID         int      `json:"id"`
+	Label      string   `json:"label"`
+	Recipient  string   `json:"recipient"`
+	Phone      string   `json:"phone"`
+	Street     string   `json:"street"`
+	City       string   `json:"city"`
+	Province   string   `json:"province"`
+	Country    string   `json:"country"`
+	PostalCode string   `json:"postal_code"`
+	Latitude   *float64 `json:"latitude,omitempty"`
+	Longitude  *float64 `json:"longitude,omitempty"`
+	IsDefault  bool     `json:"is_default"`
+}
+
+// Anonymize removes the personal data of an erased user, the row is kept so
+// orders still reference it
+func (u *User) Anonymize(anonymizedPassword string) {
+	u.Name = "Deleted User"
+	u.Email = fmt.Sprintf("deleted-%d@anonymized.invalid", u.ID)
+	u.Phone = ""
+	u.Passsword = anonymizedPassword
+	u.UserDetail = UserDetail{}
+}
+
+// Anonymize removes the contact and street of the address, the region is kept for order history
+func (a *UserAddress) Anonymize() {
+	a.Label = ""
+	a.Recipient = ""
+	a.Phone = ""
+	a.Street = ""
+	a.PostalCode = ""
+	a.Latitude = nil
+	a.Longitude = nil
+}
+
+// HasRole reports whether the user holds the role
+func (d UserDetail) HasRole(role string) bool {
+	for _, userRole := range d.Roles {
+		if userRole == role {
+			return true
+		}
+	}
+	return false
 }
 
 // Implement the Valuer interface for Detail
@@ -38,5 +137,33 @@ func (d *UserDetail) Scan(value interface{}) error {
 	if !ok {
 		return errors.New("failed to scan Detail")
 	}
-	return json.Unmarshal(bytes, d)
+	if err := json.Unmarshal(bytes, d); err != nil {
+		return err
+	}
+	d.upgradeAddresses()
+	return nil
+}
+
+// upgradeAddresses gives an ID to the addresses stored before the address book
+// and makes the first one the default when none is, the IDs follow the address
+// order so they are the same on every read until the address book is saved
+func (d *UserDetail) upgradeAddresses() {
+	maxID := 0
+	hasDefault := false
+	for _, address := range d.Addresses {
+		if address.ID > maxID {
+			maxID = address.ID
+		}
+		hasDefault = hasDefault || address.IsDefault
+	}
+
+	for i := range d.Addresses {
+		if d.Addresses[i].ID == 0 {
+			maxID++
+			d.Addresses[i].ID = maxID
+		}
+	}
+	if !hasDefault && len(d.Addresses) > 0 {
+		d.Addresses[0].IsDefault = true
+	}
 }
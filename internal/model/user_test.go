@@ -0,0 +1,63 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserDetailScanUpgradesAddresses(t *testing.T) {
+	tests := []struct {
+		name        string
+		addresses   []UserAddress
+		wantIDs     []int
+		wantDefault int
+	}{
+		{
+			name:        "legacy addresses get IDs and a default",
+			addresses:   []UserAddress{{Street: "a"}, {Street: "b"}},
+			wantIDs:     []int{1, 2},
+			wantDefault: 1,
+		},
+		{
+			name:        "legacy addresses are numbered after the address book",
+			addresses:   []UserAddress{{Street: "a"}, {ID: 4, Street: "b", IsDefault: true}},
+			wantIDs:     []int{5, 4},
+			wantDefault: 4,
+		},
+		{
+			name:        "address book is kept as is",
+			addresses:   []UserAddress{{ID: 1, Street: "a"}, {ID: 2, Street: "b", IsDefault: true}},
+			wantIDs:     []int{1, 2},
+			wantDefault: 2,
+		},
+		{
+			name:      "no address",
+			addresses: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := json.Marshal(UserDetail{Addresses: tt.addresses})
+			require.NoError(t, err)
+
+			var detail UserDetail
+			require.NoError(t, detail.Scan(value))
+
+			var ids []int
+			for _, address := range detail.Addresses {
+				ids = append(ids, address.ID)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+			if tt.wantDefault == 0 {
+				assert.Nil(t, detail.DefaultAddress())
+				return
+			}
+			require.NotNil(t, detail.DefaultAddress())
+			assert.Equal(t, tt.wantDefault, detail.DefaultAddress().ID)
+		})
+	}
+}
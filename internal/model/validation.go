@@ -0,0 +1,31 @@
+package model
+
+import "strings"
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationErrors collects every invalid field of a request
+type ValidationErrors []FieldError
+
+func (ve ValidationErrors) Error() string {
+	messages := make([]string, 0, len(ve))
+	for _, fe := range ve {
+		messages = append(messages, fe.Field+" "+fe.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (ve *ValidationErrors) Add(field string, message string) {
+	*ve = append(*ve, FieldError{Field: field, Message: message})
+}
+
+// Err returns nil when there is no invalid field
+func (ve ValidationErrors) Err() error {
+	if len(ve) == 0 {
+		return nil
+	}
+	return ve
+}
@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+// VerificationCode is a one-time code sent to an user contact, stored by its hash
+type VerificationCode struct {
+	UserID    int       `json:"user_id"`
+	Channel   string    `json:"channel"`
+	CodeHash  string    `json:"code_hash"`
+	ExpiredAt time.Time `json:"expired_at"`
+}
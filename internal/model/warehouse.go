@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"errors"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type Warehouse struct {
@@ -12,26 +14,47 @@ type Warehouse struct {
 	ShopID    int             `json:"shop_id" gorm:"column:shop_id"`
 	Name      string          `json:"name" gorm:"column:name"`
 	Location  string          `json:"location" gorm:"column:location"`
-	Status    string          `json:"status" gorm:"column:status"`
+	Status    Status          `json:"status" gorm:"column:status"`
 	Detail    WarehouseDetail `json:"detail" gorm:"type:jsonb"`
 	CreatedAt time.Time       `json:"created_at" gorm:"column:created_at"`
 	UpdatedAt time.Time       `json:"updated_at" gorm:"column:updated_at"`
+
+	statusChange *StatusChange
 }
 
 func (Warehouse) TableName() string {
 	return "warehouses"
 }
 
+// RecordStatusChange moves the warehouse from its stored status, the change is recorded
+// when the warehouse is saved
+func (w *Warehouse) RecordStatusChange(from Status, to Status, by int, at time.Time) {
+	w.statusChange = newStatusChange(StatusEntityWarehouse, from, to, by, at)
+	w.Status = to
+}
+
+// KeepStatus restores the stored status and drops a pending status change
+func (w *Warehouse) KeepStatus(status Status) {
+	w.Status = status
+	w.statusChange = nil
+}
+
+func (w *Warehouse) AfterSave(tx *gorm.DB) error {
+	return saveStatusChange(tx, &w.statusChange, w.ID)
+}
+
 type WarehouseDetail struct {
-	Contact   Contact   `json:"contact"`
-	Addresses []Address `json:"addresses"`
-	ImageURL  string    `json:"image_url"`
+	Contact      Contact   `json:"contact"`
+	Addresses    []Address `json:"addresses"`
+	ImageURL     string    `json:"image_url"`
+	ThumbnailURL string    `json:"thumbnail_url,omitempty"`
 }
 
 type WarehouseStoredProduct struct {
 	ID              int       `json:"id" gorm:"column:id"`
 	WarehouseID     int       `json:"warehouse_id" gorm:"column:warehouse_id"`
 	ShopProductID   int       `json:"shop_product_id" gorm:"column:shop_product_id"`
+	VariantID       *int      `json:"variant_id" gorm:"column:variant_id"`
 	ShopProductName string    `json:"shop_product_name" gorm:"column:shop_product_name"`
 	Stock           int       `json:"stock" gorm:"column:stock"`
 	CreatedAt       time.Time `json:"created_at" gorm:"column:created_at"`
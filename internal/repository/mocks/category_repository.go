@@ -0,0 +1,103 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "simcomm-monolith/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CategoryRepository is an autogenerated mock type for the CategoryRepository type
+type CategoryRepository struct {
+	mock.Mock
+}
+
+// CategoryRepositoryCreate provides a mock function with given fields: ctx, category
+func (_m *CategoryRepository) CategoryRepositoryCreate(ctx context.Context, category *model.Category) error {
+	ret := _m.Called(ctx, category)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Category) error); ok {
+		r0 = rf(ctx, category)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CategoryRepositoryDelete provides a mock function with given fields: ctx, id
+func (_m *CategoryRepository) CategoryRepositoryDelete(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CategoryRepositoryGet provides a mock function with given fields: ctx, id
+func (_m *CategoryRepository) CategoryRepositoryGet(ctx context.Context, id int) (*model.Category, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.Category
+	if rf, ok := ret.Get(0).(func(context.Context, int) *model.Category); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Category)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CategoryRepositoryGetAll provides a mock function with given fields: ctx
+func (_m *CategoryRepository) CategoryRepositoryGetAll(ctx context.Context) ([]model.Category, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []model.Category
+	if rf, ok := ret.Get(0).(func(context.Context) []model.Category); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Category)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CategoryRepositoryUpdate provides a mock function with given fields: ctx, category
+func (_m *CategoryRepository) CategoryRepositoryUpdate(ctx context.Context, category *model.Category) error {
+	ret := _m.Called(ctx, category)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Category) error); ok {
+		r0 = rf(ctx, category)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,66 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "simcomm-monolith/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ImportJobRepository is an autogenerated mock type for the ImportJobRepository type
+type ImportJobRepository struct {
+	mock.Mock
+}
+
+// ImportJobRepositoryCreate provides a mock function with given fields: ctx, job
+func (_m *ImportJobRepository) ImportJobRepositoryCreate(ctx context.Context, job *model.ProductImportJob) error {
+	ret := _m.Called(ctx, job)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ProductImportJob) error); ok {
+		r0 = rf(ctx, job)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ImportJobRepositoryGet provides a mock function with given fields: ctx, id
+func (_m *ImportJobRepository) ImportJobRepositoryGet(ctx context.Context, id int) (*model.ProductImportJob, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.ProductImportJob
+	if rf, ok := ret.Get(0).(func(context.Context, int) *model.ProductImportJob); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ProductImportJob)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportJobRepositoryUpdate provides a mock function with given fields: ctx, job
+func (_m *ImportJobRepository) ImportJobRepositoryUpdate(ctx context.Context, job *model.ProductImportJob) error {
+	ret := _m.Called(ctx, job)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ProductImportJob) error); ok {
+		r0 = rf(ctx, job)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,28 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Notifier is an autogenerated mock type for the Notifier type
+type Notifier struct {
+	mock.Mock
+}
+
+// Send provides a mock function with given fields: ctx, channel, destination, message
+func (_m *Notifier) Send(ctx context.Context, channel string, destination string, message string) error {
+	ret := _m.Called(ctx, channel, destination, message)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, channel, destination, message)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,59 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "simcomm-monolith/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OIDCProvider is an autogenerated mock type for the OIDCProvider type
+type OIDCProvider struct {
+	mock.Mock
+}
+
+// AuthorizationURL provides a mock function with given fields: ctx, state, nonce, codeChallenge
+func (_m *OIDCProvider) AuthorizationURL(ctx context.Context, state string, nonce string, codeChallenge string) (string, error) {
+	ret := _m.Called(ctx, state, nonce, codeChallenge)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) string); ok {
+		r0 = rf(ctx, state, nonce, codeChallenge)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, state, nonce, codeChallenge)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Exchange provides a mock function with given fields: ctx, code, codeVerifier, nonce
+func (_m *OIDCProvider) Exchange(ctx context.Context, code string, codeVerifier string, nonce string) (*model.OIDCIdentity, error) {
+	ret := _m.Called(ctx, code, codeVerifier, nonce)
+
+	var r0 *model.OIDCIdentity
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *model.OIDCIdentity); ok {
+		r0 = rf(ctx, code, codeVerifier, nonce)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.OIDCIdentity)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, code, codeVerifier, nonce)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
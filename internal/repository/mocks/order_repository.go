@@ -0,0 +1,149 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "simcomm-monolith/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OrderRepository is an autogenerated mock type for the OrderRepository type
+type OrderRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, order
+func (_m *OrderRepository) Create(ctx context.Context, order *model.Order) error {
+	ret := _m.Called(ctx, order)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Order) error); ok {
+		r0 = rf(ctx, order)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *OrderRepository) Delete(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, id
+func (_m *OrderRepository) Get(ctx context.Context, id int) (*model.Order, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.Order
+	if rf, ok := ret.Get(0).(func(context.Context, int) *model.Order); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Order)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: ctx
+func (_m *OrderRepository) GetAll(ctx context.Context) ([]model.Order, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []model.Order
+	if rf, ok := ret.Get(0).(func(context.Context) []model.Order); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Order)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByUserID provides a mock function with given fields: ctx, userID
+func (_m *OrderRepository) GetByUserID(ctx context.Context, userID int) ([]model.Order, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []model.Order
+	if rf, ok := ret.Get(0).(func(context.Context, int) []model.Order); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Order)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetVisibleToUser provides a mock function with given fields: ctx, userID
+func (_m *OrderRepository) GetVisibleToUser(ctx context.Context, userID int) ([]model.Order, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []model.Order
+	if rf, ok := ret.Get(0).(func(context.Context, int) []model.Order); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Order)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, order
+func (_m *OrderRepository) Update(ctx context.Context, order *model.Order) error {
+	ret := _m.Called(ctx, order)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Order) error); ok {
+		r0 = rf(ctx, order)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,441 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "simcomm-monolith/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ProductRepository is an autogenerated mock type for the ProductRepository type
+type ProductRepository struct {
+	mock.Mock
+}
+
+// CategoryRepositoryCreate provides a mock function with given fields: ctx, category
+func (_m *ProductRepository) CategoryRepositoryCreate(ctx context.Context, category *model.Category) error {
+	ret := _m.Called(ctx, category)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Category) error); ok {
+		r0 = rf(ctx, category)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CategoryRepositoryDelete provides a mock function with given fields: ctx, id
+func (_m *ProductRepository) CategoryRepositoryDelete(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CategoryRepositoryGet provides a mock function with given fields: ctx, id
+func (_m *ProductRepository) CategoryRepositoryGet(ctx context.Context, id int) (*model.Category, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.Category
+	if rf, ok := ret.Get(0).(func(context.Context, int) *model.Category); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Category)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CategoryRepositoryGetAll provides a mock function with given fields: ctx
+func (_m *ProductRepository) CategoryRepositoryGetAll(ctx context.Context) ([]model.Category, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []model.Category
+	if rf, ok := ret.Get(0).(func(context.Context) []model.Category); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Category)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CategoryRepositoryUpdate provides a mock function with given fields: ctx, category
+func (_m *ProductRepository) CategoryRepositoryUpdate(ctx context.Context, category *model.Category) error {
+	ret := _m.Called(ctx, category)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Category) error); ok {
+		r0 = rf(ctx, category)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Create provides a mock function with given fields: ctx, product
+func (_m *ProductRepository) Create(ctx context.Context, product *model.Product) error {
+	ret := _m.Called(ctx, product)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Product) error); ok {
+		r0 = rf(ctx, product)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *ProductRepository) Delete(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindInBatches provides a mock function with given fields: ctx, batchSize, fn
+func (_m *ProductRepository) FindInBatches(ctx context.Context, batchSize int, fn func([]model.Product) error) error {
+	ret := _m.Called(ctx, batchSize, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, func([]model.Product) error) error); ok {
+		r0 = rf(ctx, batchSize, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, id
+func (_m *ProductRepository) Get(ctx context.Context, id int) (*model.Product, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.Product
+	if rf, ok := ret.Get(0).(func(context.Context, int) *model.Product); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Product)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: ctx
+func (_m *ProductRepository) GetAll(ctx context.Context) ([]model.Product, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []model.Product
+	if rf, ok := ret.Get(0).(func(context.Context) []model.Product); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Product)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetShopIDs provides a mock function with given fields: ctx, ids
+func (_m *ProductRepository) GetShopIDs(ctx context.Context, ids ...int) ([]int, error) {
+	_va := make([]interface{}, len(ids))
+	for _i := range ids {
+		_va[_i] = ids[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 []int
+	if rf, ok := ret.Get(0).(func(context.Context, ...int) []int); ok {
+		r0 = rf(ctx, ids...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, ...int) error); ok {
+		r1 = rf(ctx, ids...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStatusHistory provides a mock function with given fields: ctx, id
+func (_m *ProductRepository) GetStatusHistory(ctx context.Context, id int) ([]model.StatusChange, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 []model.StatusChange
+	if rf, ok := ret.Get(0).(func(context.Context, int) []model.StatusChange); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.StatusChange)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportJobRepositoryCreate provides a mock function with given fields: ctx, job
+func (_m *ProductRepository) ImportJobRepositoryCreate(ctx context.Context, job *model.ProductImportJob) error {
+	ret := _m.Called(ctx, job)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ProductImportJob) error); ok {
+		r0 = rf(ctx, job)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ImportJobRepositoryGet provides a mock function with given fields: ctx, id
+func (_m *ProductRepository) ImportJobRepositoryGet(ctx context.Context, id int) (*model.ProductImportJob, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.ProductImportJob
+	if rf, ok := ret.Get(0).(func(context.Context, int) *model.ProductImportJob); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ProductImportJob)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportJobRepositoryUpdate provides a mock function with given fields: ctx, job
+func (_m *ProductRepository) ImportJobRepositoryUpdate(ctx context.Context, job *model.ProductImportJob) error {
+	ret := _m.Called(ctx, job)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ProductImportJob) error); ok {
+		r0 = rf(ctx, job)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Search provides a mock function with given fields: ctx, req
+func (_m *ProductRepository) Search(ctx context.Context, req model.ProductSearchRequest) (*model.ProductSearchResult, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *model.ProductSearchResult
+	if rf, ok := ret.Get(0).(func(context.Context, model.ProductSearchRequest) *model.ProductSearchResult); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ProductSearchResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.ProductSearchRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, product
+func (_m *ProductRepository) Update(ctx context.Context, product *model.Product) error {
+	ret := _m.Called(ctx, product)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Product) error); ok {
+		r0 = rf(ctx, product)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpsertByCode provides a mock function with given fields: ctx, product, authorize
+func (_m *ProductRepository) UpsertByCode(ctx context.Context, product *model.Product, authorize func(*model.Product) error) (bool, error) {
+	ret := _m.Called(ctx, product, authorize)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Product, func(*model.Product) error) bool); ok {
+		r0 = rf(ctx, product, authorize)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *model.Product, func(*model.Product) error) error); ok {
+		r1 = rf(ctx, product, authorize)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VariantRepositoryCreate provides a mock function with given fields: ctx, variant
+func (_m *ProductRepository) VariantRepositoryCreate(ctx context.Context, variant *model.ProductVariant) error {
+	ret := _m.Called(ctx, variant)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ProductVariant) error); ok {
+		r0 = rf(ctx, variant)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VariantRepositoryDelete provides a mock function with given fields: ctx, id
+func (_m *ProductRepository) VariantRepositoryDelete(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VariantRepositoryGet provides a mock function with given fields: ctx, id
+func (_m *ProductRepository) VariantRepositoryGet(ctx context.Context, id int) (*model.ProductVariant, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.ProductVariant
+	if rf, ok := ret.Get(0).(func(context.Context, int) *model.ProductVariant); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ProductVariant)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VariantRepositoryGetByProductID provides a mock function with given fields: ctx, productID
+func (_m *ProductRepository) VariantRepositoryGetByProductID(ctx context.Context, productID int) ([]model.ProductVariant, error) {
+	ret := _m.Called(ctx, productID)
+
+	var r0 []model.ProductVariant
+	if rf, ok := ret.Get(0).(func(context.Context, int) []model.ProductVariant); ok {
+		r0 = rf(ctx, productID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ProductVariant)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VariantRepositoryUpdate provides a mock function with given fields: ctx, variant
+func (_m *ProductRepository) VariantRepositoryUpdate(ctx context.Context, variant *model.ProductVariant) error {
+	ret := _m.Called(ctx, variant)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ProductVariant) error); ok {
+		r0 = rf(ctx, variant)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
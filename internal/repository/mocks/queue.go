@@ -0,0 +1,39 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	amqp091 "github.com/rabbitmq/amqp091-go"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Queue is an autogenerated mock type for the Queue type
+type Queue struct {
+	mock.Mock
+}
+
+// AddReceiver provides a mock function with given fields: ctx, callback
+func (_m *Queue) AddReceiver(ctx context.Context, callback func(context.Context, amqp091.Delivery) error) {
+	_m.Called(ctx, callback)
+}
+
+// Close provides a mock function with given fields:
+func (_m *Queue) Close() {
+	_m.Called()
+}
+
+// Publish provides a mock function with given fields: ctx, product
+func (_m *Queue) Publish(ctx context.Context, product interface{}) error {
+	ret := _m.Called(ctx, product)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}) error); ok {
+		r0 = rf(ctx, product)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
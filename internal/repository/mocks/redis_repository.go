@@ -0,0 +1,675 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "simcomm-monolith/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// RedisRepository is an autogenerated mock type for the RedisRepository type
+type RedisRepository struct {
+	mock.Mock
+}
+
+// AcquireVerificationCooldown provides a mock function with given fields: ctx, channel, destination, cooldown
+func (_m *RedisRepository) AcquireVerificationCooldown(ctx context.Context, channel string, destination string, cooldown time.Duration) (bool, error) {
+	ret := _m.Called(ctx, channel, destination, cooldown)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) bool); ok {
+		r0 = rf(ctx, channel, destination, cooldown)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Duration) error); ok {
+		r1 = rf(ctx, channel, destination, cooldown)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ConsumeOIDCState provides a mock function with given fields: ctx, state
+func (_m *RedisRepository) ConsumeOIDCState(ctx context.Context, state string) (*model.OIDCState, error) {
+	ret := _m.Called(ctx, state)
+
+	var r0 *model.OIDCState
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.OIDCState); ok {
+		r0 = rf(ctx, state)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.OIDCState)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, state)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ConsumePasswordResetToken provides a mock function with given fields: ctx, tokenHash
+func (_m *RedisRepository) ConsumePasswordResetToken(ctx context.Context, tokenHash string) (int, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteLoginChallenge provides a mock function with given fields: ctx, tokenHash
+func (_m *RedisRepository) DeleteLoginChallenge(ctx context.Context, tokenHash string) error {
+	ret := _m.Called(ctx, tokenHash)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteSession provides a mock function with given fields: ctx, tokenID
+func (_m *RedisRepository) DeleteSession(ctx context.Context, tokenID string) error {
+	ret := _m.Called(ctx, tokenID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, tokenID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteStorefronts provides a mock function with given fields: ctx, shopIDs
+func (_m *RedisRepository) DeleteStorefronts(ctx context.Context, shopIDs ...int) error {
+	_va := make([]interface{}, len(shopIDs))
+	for _i := range shopIDs {
+		_va[_i] = shopIDs[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...int) error); ok {
+		r0 = rf(ctx, shopIDs...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteTokenFamily provides a mock function with given fields: ctx, familyID
+func (_m *RedisRepository) DeleteTokenFamily(ctx context.Context, familyID string) error {
+	ret := _m.Called(ctx, familyID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, familyID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteUserSessions provides a mock function with given fields: ctx, userID
+func (_m *RedisRepository) DeleteUserSessions(ctx context.Context, userID int) error {
+	ret := _m.Called(ctx, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteVerificationCode provides a mock function with given fields: ctx, channel, userID
+func (_m *RedisRepository) DeleteVerificationCode(ctx context.Context, channel string, userID int) error {
+	ret := _m.Called(ctx, channel, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) error); ok {
+		r0 = rf(ctx, channel, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetLoginBackoff provides a mock function with given fields: ctx, key
+func (_m *RedisRepository) GetLoginBackoff(ctx context.Context, key string) (time.Duration, error) {
+	ret := _m.Called(ctx, key)
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func(context.Context, string) time.Duration); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLoginChallenge provides a mock function with given fields: ctx, tokenHash
+func (_m *RedisRepository) GetLoginChallenge(ctx context.Context, tokenHash string) (*model.LoginChallenge, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	var r0 *model.LoginChallenge
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.LoginChallenge); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.LoginChallenge)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRefreshToken provides a mock function with given fields: ctx, tokenHash
+func (_m *RedisRepository) GetRefreshToken(ctx context.Context, tokenHash string) (*model.RefreshToken, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	var r0 *model.RefreshToken
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.RefreshToken); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RefreshToken)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSession provides a mock function with given fields: ctx, tokenID
+func (_m *RedisRepository) GetSession(ctx context.Context, tokenID string) (*model.Session, error) {
+	ret := _m.Called(ctx, tokenID)
+
+	var r0 *model.Session
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Session); ok {
+		r0 = rf(ctx, tokenID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Session)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStorefront provides a mock function with given fields: ctx, shopID, page, limit
+func (_m *RedisRepository) GetStorefront(ctx context.Context, shopID int, page int, limit int) (*model.Storefront, error) {
+	ret := _m.Called(ctx, shopID, page, limit)
+
+	var r0 *model.Storefront
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) *model.Storefront); ok {
+		r0 = rf(ctx, shopID, page, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Storefront)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, int) error); ok {
+		r1 = rf(ctx, shopID, page, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetToken provides a mock function with given fields: ctx, key
+func (_m *RedisRepository) GetToken(ctx context.Context, key string) (string, error) {
+	ret := _m.Called(ctx, key)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTokenFamily provides a mock function with given fields: ctx, familyID
+func (_m *RedisRepository) GetTokenFamily(ctx context.Context, familyID string) (*model.TokenFamily, error) {
+	ret := _m.Called(ctx, familyID)
+
+	var r0 *model.TokenFamily
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.TokenFamily); ok {
+		r0 = rf(ctx, familyID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TokenFamily)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, familyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetVerificationAttempts provides a mock function with given fields: ctx, channel, userID
+func (_m *RedisRepository) GetVerificationAttempts(ctx context.Context, channel string, userID int) (int64, error) {
+	ret := _m.Called(ctx, channel, userID)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) int64); ok {
+		r0 = rf(ctx, channel, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, channel, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetVerificationCode provides a mock function with given fields: ctx, channel, userID
+func (_m *RedisRepository) GetVerificationCode(ctx context.Context, channel string, userID int) (*model.VerificationCode, error) {
+	ret := _m.Called(ctx, channel, userID)
+
+	var r0 *model.VerificationCode
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) *model.VerificationCode); ok {
+		r0 = rf(ctx, channel, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.VerificationCode)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, channel, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IncrLoginAttempt provides a mock function with given fields: ctx, key, window
+func (_m *RedisRepository) IncrLoginAttempt(ctx context.Context, key string, window time.Duration) (int64, error) {
+	ret := _m.Called(ctx, key, window)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) int64); ok {
+		r0 = rf(ctx, key, window)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) error); ok {
+		r1 = rf(ctx, key, window)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IncrLoginChallengeAttempt provides a mock function with given fields: ctx, tokenHash, window
+func (_m *RedisRepository) IncrLoginChallengeAttempt(ctx context.Context, tokenHash string, window time.Duration) (int64, error) {
+	ret := _m.Called(ctx, tokenHash, window)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) int64); ok {
+		r0 = rf(ctx, tokenHash, window)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) error); ok {
+		r1 = rf(ctx, tokenHash, window)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IncrVerificationAttempt provides a mock function with given fields: ctx, channel, userID, window
+func (_m *RedisRepository) IncrVerificationAttempt(ctx context.Context, channel string, userID int, window time.Duration) (int64, error) {
+	ret := _m.Called(ctx, channel, userID, window)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, time.Duration) int64); ok {
+		r0 = rf(ctx, channel, userID, window)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, time.Duration) error); ok {
+		r1 = rf(ctx, channel, userID, window)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsAccountLocked provides a mock function with given fields: ctx, userID
+func (_m *RedisRepository) IsAccountLocked(ctx context.Context, userID int) (bool, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, int) bool); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LockAccount provides a mock function with given fields: ctx, userID, duration
+func (_m *RedisRepository) LockAccount(ctx context.Context, userID int, duration time.Duration) error {
+	ret := _m.Called(ctx, userID, duration)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Duration) error); ok {
+		r0 = rf(ctx, userID, duration)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MarkRefreshTokenUsed provides a mock function with given fields: ctx, refreshToken
+func (_m *RedisRepository) MarkRefreshTokenUsed(ctx context.Context, refreshToken model.RefreshToken) (bool, error) {
+	ret := _m.Called(ctx, refreshToken)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, model.RefreshToken) bool); ok {
+		r0 = rf(ctx, refreshToken)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.RefreshToken) error); ok {
+		r1 = rf(ctx, refreshToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResetLoginAttempt provides a mock function with given fields: ctx, key
+func (_m *RedisRepository) ResetLoginAttempt(ctx context.Context, key string) error {
+	ret := _m.Called(ctx, key)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResetVerificationAttempt provides a mock function with given fields: ctx, channel, userID
+func (_m *RedisRepository) ResetVerificationAttempt(ctx context.Context, channel string, userID int) error {
+	ret := _m.Called(ctx, channel, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) error); ok {
+		r0 = rf(ctx, channel, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetLoginBackoff provides a mock function with given fields: ctx, key, backoff
+func (_m *RedisRepository) SetLoginBackoff(ctx context.Context, key string, backoff time.Duration) error {
+	ret := _m.Called(ctx, key, backoff)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) error); ok {
+		r0 = rf(ctx, key, backoff)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StoreLoginChallenge provides a mock function with given fields: ctx, challenge
+func (_m *RedisRepository) StoreLoginChallenge(ctx context.Context, challenge model.LoginChallenge) error {
+	ret := _m.Called(ctx, challenge)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.LoginChallenge) error); ok {
+		r0 = rf(ctx, challenge)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StoreOIDCState provides a mock function with given fields: ctx, state, oidcState, duration
+func (_m *RedisRepository) StoreOIDCState(ctx context.Context, state string, oidcState model.OIDCState, duration time.Duration) error {
+	ret := _m.Called(ctx, state, oidcState, duration)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.OIDCState, time.Duration) error); ok {
+		r0 = rf(ctx, state, oidcState, duration)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StorePasswordResetToken provides a mock function with given fields: ctx, tokenHash, userID, duration
+func (_m *RedisRepository) StorePasswordResetToken(ctx context.Context, tokenHash string, userID int, duration time.Duration) error {
+	ret := _m.Called(ctx, tokenHash, userID, duration)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, time.Duration) error); ok {
+		r0 = rf(ctx, tokenHash, userID, duration)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StoreRefreshToken provides a mock function with given fields: ctx, refreshToken
+func (_m *RedisRepository) StoreRefreshToken(ctx context.Context, refreshToken model.RefreshToken) error {
+	ret := _m.Called(ctx, refreshToken)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.RefreshToken) error); ok {
+		r0 = rf(ctx, refreshToken)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StoreSession provides a mock function with given fields: ctx, session
+func (_m *RedisRepository) StoreSession(ctx context.Context, session model.Session) error {
+	ret := _m.Called(ctx, session)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Session) error); ok {
+		r0 = rf(ctx, session)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StoreStorefront provides a mock function with given fields: ctx, storefront, duration
+func (_m *RedisRepository) StoreStorefront(ctx context.Context, storefront model.Storefront, duration time.Duration) error {
+	ret := _m.Called(ctx, storefront, duration)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Storefront, time.Duration) error); ok {
+		r0 = rf(ctx, storefront, duration)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StoreToken provides a mock function with given fields: ctx, key, token
+func (_m *RedisRepository) StoreToken(ctx context.Context, key string, token string) error {
+	ret := _m.Called(ctx, key, token)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, key, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StoreTokenFamily provides a mock function with given fields: ctx, family
+func (_m *RedisRepository) StoreTokenFamily(ctx context.Context, family model.TokenFamily) error {
+	ret := _m.Called(ctx, family)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.TokenFamily) error); ok {
+		r0 = rf(ctx, family)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StoreVerificationCode provides a mock function with given fields: ctx, code
+func (_m *RedisRepository) StoreVerificationCode(ctx context.Context, code model.VerificationCode) error {
+	ret := _m.Called(ctx, code)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.VerificationCode) error); ok {
+		r0 = rf(ctx, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnlockAccount provides a mock function with given fields: ctx, userID
+func (_m *RedisRepository) UnlockAccount(ctx context.Context, userID int) error {
+	ret := _m.Called(ctx, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,195 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "simcomm-monolith/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ReviewRepository is an autogenerated mock type for the ReviewRepository type
+type ReviewRepository struct {
+	mock.Mock
+}
+
+// AddFlag provides a mock function with given fields: ctx, flag, hideThreshold
+func (_m *ReviewRepository) AddFlag(ctx context.Context, flag *model.ReviewFlag, hideThreshold int) (*model.Review, error) {
+	ret := _m.Called(ctx, flag, hideThreshold)
+
+	var r0 *model.Review
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ReviewFlag, int) *model.Review); ok {
+		r0 = rf(ctx, flag, hideThreshold)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Review)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *model.ReviewFlag, int) error); ok {
+		r1 = rf(ctx, flag, hideThreshold)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, review
+func (_m *ReviewRepository) Create(ctx context.Context, review *model.Review) error {
+	ret := _m.Called(ctx, review)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Review) error); ok {
+		r0 = rf(ctx, review)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, id
+func (_m *ReviewRepository) Get(ctx context.Context, id int) (*model.Review, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.Review
+	if rf, ok := ret.Get(0).(func(context.Context, int) *model.Review); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Review)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByProductID provides a mock function with given fields: ctx, req
+func (_m *ReviewRepository) GetByProductID(ctx context.Context, req model.ReviewListRequest) ([]model.Review, int64, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 []model.Review
+	if rf, ok := ret.Get(0).(func(context.Context, model.ReviewListRequest) []model.Review); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Review)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, model.ReviewListRequest) int64); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, model.ReviewListRequest) error); ok {
+		r2 = rf(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetFlagged provides a mock function with given fields: ctx, req
+func (_m *ReviewRepository) GetFlagged(ctx context.Context, req model.ReviewListRequest) ([]model.Review, int64, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 []model.Review
+	if rf, ok := ret.Get(0).(func(context.Context, model.ReviewListRequest) []model.Review); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Review)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, model.ReviewListRequest) int64); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, model.ReviewListRequest) error); ok {
+		r2 = rf(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetProductRating provides a mock function with given fields: ctx, productID
+func (_m *ReviewRepository) GetProductRating(ctx context.Context, productID int) (*model.RatingSummary, error) {
+	ret := _m.Called(ctx, productID)
+
+	var r0 *model.RatingSummary
+	if rf, ok := ret.Get(0).(func(context.Context, int) *model.RatingSummary); ok {
+		r0 = rf(ctx, productID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RatingSummary)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetShopRating provides a mock function with given fields: ctx, shopID
+func (_m *ReviewRepository) GetShopRating(ctx context.Context, shopID int) (*model.RatingSummary, error) {
+	ret := _m.Called(ctx, shopID)
+
+	var r0 *model.RatingSummary
+	if rf, ok := ret.Get(0).(func(context.Context, int) *model.RatingSummary); ok {
+		r0 = rf(ctx, shopID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RatingSummary)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, shopID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, review
+func (_m *ReviewRepository) Update(ctx context.Context, review *model.Review) error {
+	ret := _m.Called(ctx, review)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Review) error); ok {
+		r0 = rf(ctx, review)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
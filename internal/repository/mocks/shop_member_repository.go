@@ -0,0 +1,200 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "simcomm-monolith/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ShopMemberRepository is an autogenerated mock type for the ShopMemberRepository type
+type ShopMemberRepository struct {
+	mock.Mock
+}
+
+// ShopMemberRepositoryAcceptInvitation provides a mock function with given fields: ctx, invitation, member
+func (_m *ShopMemberRepository) ShopMemberRepositoryAcceptInvitation(ctx context.Context, invitation *model.ShopInvitation, member *model.ShopMember) error {
+	ret := _m.Called(ctx, invitation, member)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ShopInvitation, *model.ShopMember) error); ok {
+		r0 = rf(ctx, invitation, member)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ShopMemberRepositoryCreateInvitation provides a mock function with given fields: ctx, invitation
+func (_m *ShopMemberRepository) ShopMemberRepositoryCreateInvitation(ctx context.Context, invitation *model.ShopInvitation) error {
+	ret := _m.Called(ctx, invitation)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ShopInvitation) error); ok {
+		r0 = rf(ctx, invitation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ShopMemberRepositoryDelete provides a mock function with given fields: ctx, shopID, userID
+func (_m *ShopMemberRepository) ShopMemberRepositoryDelete(ctx context.Context, shopID int, userID int) error {
+	ret := _m.Called(ctx, shopID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, shopID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ShopMemberRepositoryGet provides a mock function with given fields: ctx, shopID, userID
+func (_m *ShopMemberRepository) ShopMemberRepositoryGet(ctx context.Context, shopID int, userID int) (*model.ShopMember, error) {
+	ret := _m.Called(ctx, shopID, userID)
+
+	var r0 *model.ShopMember
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *model.ShopMember); ok {
+		r0 = rf(ctx, shopID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ShopMember)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, shopID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShopMemberRepositoryGetByShopID provides a mock function with given fields: ctx, shopID
+func (_m *ShopMemberRepository) ShopMemberRepositoryGetByShopID(ctx context.Context, shopID int) ([]model.ShopMember, error) {
+	ret := _m.Called(ctx, shopID)
+
+	var r0 []model.ShopMember
+	if rf, ok := ret.Get(0).(func(context.Context, int) []model.ShopMember); ok {
+		r0 = rf(ctx, shopID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ShopMember)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, shopID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShopMemberRepositoryGetInvitation provides a mock function with given fields: ctx, shopID, id
+func (_m *ShopMemberRepository) ShopMemberRepositoryGetInvitation(ctx context.Context, shopID int, id int) (*model.ShopInvitation, error) {
+	ret := _m.Called(ctx, shopID, id)
+
+	var r0 *model.ShopInvitation
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *model.ShopInvitation); ok {
+		r0 = rf(ctx, shopID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ShopInvitation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, shopID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShopMemberRepositoryGetInvitationByTokenHash provides a mock function with given fields: ctx, tokenHash
+func (_m *ShopMemberRepository) ShopMemberRepositoryGetInvitationByTokenHash(ctx context.Context, tokenHash string) (*model.ShopInvitation, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	var r0 *model.ShopInvitation
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.ShopInvitation); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ShopInvitation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShopMemberRepositoryGetPendingInvitations provides a mock function with given fields: ctx, shopID
+func (_m *ShopMemberRepository) ShopMemberRepositoryGetPendingInvitations(ctx context.Context, shopID int) ([]model.ShopInvitation, error) {
+	ret := _m.Called(ctx, shopID)
+
+	var r0 []model.ShopInvitation
+	if rf, ok := ret.Get(0).(func(context.Context, int) []model.ShopInvitation); ok {
+		r0 = rf(ctx, shopID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ShopInvitation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, shopID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShopMemberRepositoryUpdate provides a mock function with given fields: ctx, member
+func (_m *ShopMemberRepository) ShopMemberRepositoryUpdate(ctx context.Context, member *model.ShopMember) error {
+	ret := _m.Called(ctx, member)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ShopMember) error); ok {
+		r0 = rf(ctx, member)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ShopMemberRepositoryUpdateInvitation provides a mock function with given fields: ctx, invitation
+func (_m *ShopMemberRepository) ShopMemberRepositoryUpdateInvitation(ctx context.Context, invitation *model.ShopInvitation) error {
+	ret := _m.Called(ctx, invitation)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ShopInvitation) error); ok {
+		r0 = rf(ctx, invitation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
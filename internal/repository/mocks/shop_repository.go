@@ -0,0 +1,655 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "simcomm-monolith/internal/model"
+	repository "simcomm-monolith/internal/repository"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ShopRepository is an autogenerated mock type for the ShopRepository type
+type ShopRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, shop
+func (_m *ShopRepository) Create(ctx context.Context, shop *model.Shop) error {
+	ret := _m.Called(ctx, shop)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Shop) error); ok {
+		r0 = rf(ctx, shop)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *ShopRepository) Delete(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, id
+func (_m *ShopRepository) Get(ctx context.Context, id int) (*model.Shop, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.Shop
+	if rf, ok := ret.Get(0).(func(context.Context, int) *model.Shop); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Shop)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: ctx
+func (_m *ShopRepository) GetAll(ctx context.Context) ([]model.Shop, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []model.Shop
+	if rf, ok := ret.Get(0).(func(context.Context) []model.Shop); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Shop)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByUserID provides a mock function with given fields: ctx, userID
+func (_m *ShopRepository) GetByUserID(ctx context.Context, userID int) ([]model.Shop, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []model.Shop
+	if rf, ok := ret.Get(0).(func(context.Context, int) []model.Shop); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Shop)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStatusHistory provides a mock function with given fields: ctx, id
+func (_m *ShopRepository) GetStatusHistory(ctx context.Context, id int) ([]model.StatusChange, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 []model.StatusChange
+	if rf, ok := ret.Get(0).(func(context.Context, int) []model.StatusChange); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.StatusChange)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShopMemberRepositoryAcceptInvitation provides a mock function with given fields: ctx, invitation, member
+func (_m *ShopRepository) ShopMemberRepositoryAcceptInvitation(ctx context.Context, invitation *model.ShopInvitation, member *model.ShopMember) error {
+	ret := _m.Called(ctx, invitation, member)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ShopInvitation, *model.ShopMember) error); ok {
+		r0 = rf(ctx, invitation, member)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ShopMemberRepositoryCreateInvitation provides a mock function with given fields: ctx, invitation
+func (_m *ShopRepository) ShopMemberRepositoryCreateInvitation(ctx context.Context, invitation *model.ShopInvitation) error {
+	ret := _m.Called(ctx, invitation)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ShopInvitation) error); ok {
+		r0 = rf(ctx, invitation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ShopMemberRepositoryDelete provides a mock function with given fields: ctx, shopID, userID
+func (_m *ShopRepository) ShopMemberRepositoryDelete(ctx context.Context, shopID int, userID int) error {
+	ret := _m.Called(ctx, shopID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, shopID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ShopMemberRepositoryGet provides a mock function with given fields: ctx, shopID, userID
+func (_m *ShopRepository) ShopMemberRepositoryGet(ctx context.Context, shopID int, userID int) (*model.ShopMember, error) {
+	ret := _m.Called(ctx, shopID, userID)
+
+	var r0 *model.ShopMember
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *model.ShopMember); ok {
+		r0 = rf(ctx, shopID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ShopMember)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, shopID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShopMemberRepositoryGetByShopID provides a mock function with given fields: ctx, shopID
+func (_m *ShopRepository) ShopMemberRepositoryGetByShopID(ctx context.Context, shopID int) ([]model.ShopMember, error) {
+	ret := _m.Called(ctx, shopID)
+
+	var r0 []model.ShopMember
+	if rf, ok := ret.Get(0).(func(context.Context, int) []model.ShopMember); ok {
+		r0 = rf(ctx, shopID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ShopMember)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, shopID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShopMemberRepositoryGetInvitation provides a mock function with given fields: ctx, shopID, id
+func (_m *ShopRepository) ShopMemberRepositoryGetInvitation(ctx context.Context, shopID int, id int) (*model.ShopInvitation, error) {
+	ret := _m.Called(ctx, shopID, id)
+
+	var r0 *model.ShopInvitation
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *model.ShopInvitation); ok {
+		r0 = rf(ctx, shopID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ShopInvitation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, shopID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShopMemberRepositoryGetInvitationByTokenHash provides a mock function with given fields: ctx, tokenHash
+func (_m *ShopRepository) ShopMemberRepositoryGetInvitationByTokenHash(ctx context.Context, tokenHash string) (*model.ShopInvitation, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	var r0 *model.ShopInvitation
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.ShopInvitation); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ShopInvitation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShopMemberRepositoryGetPendingInvitations provides a mock function with given fields: ctx, shopID
+func (_m *ShopRepository) ShopMemberRepositoryGetPendingInvitations(ctx context.Context, shopID int) ([]model.ShopInvitation, error) {
+	ret := _m.Called(ctx, shopID)
+
+	var r0 []model.ShopInvitation
+	if rf, ok := ret.Get(0).(func(context.Context, int) []model.ShopInvitation); ok {
+		r0 = rf(ctx, shopID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ShopInvitation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, shopID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShopMemberRepositoryUpdate provides a mock function with given fields: ctx, member
+func (_m *ShopRepository) ShopMemberRepositoryUpdate(ctx context.Context, member *model.ShopMember) error {
+	ret := _m.Called(ctx, member)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ShopMember) error); ok {
+		r0 = rf(ctx, member)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ShopMemberRepositoryUpdateInvitation provides a mock function with given fields: ctx, invitation
+func (_m *ShopRepository) ShopMemberRepositoryUpdateInvitation(ctx context.Context, invitation *model.ShopInvitation) error {
+	ret := _m.Called(ctx, invitation)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ShopInvitation) error); ok {
+		r0 = rf(ctx, invitation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ShopProductRepositoryApplyDuePrices provides a mock function with given fields: ctx, at
+func (_m *ShopRepository) ShopProductRepositoryApplyDuePrices(ctx context.Context, at time.Time) ([]int, error) {
+	ret := _m.Called(ctx, at)
+
+	var r0 []int
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []int); ok {
+		r0 = rf(ctx, at)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShopProductRepositoryCreate provides a mock function with given fields: ctx, shopproduct, price
+func (_m *ShopRepository) ShopProductRepositoryCreate(ctx context.Context, shopproduct *model.ShopProduct, price *model.ShopProductPrice) error {
+	ret := _m.Called(ctx, shopproduct, price)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ShopProduct, *model.ShopProductPrice) error); ok {
+		r0 = rf(ctx, shopproduct, price)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ShopProductRepositoryCreatePrice provides a mock function with given fields: ctx, price
+func (_m *ShopRepository) ShopProductRepositoryCreatePrice(ctx context.Context, price *model.ShopProductPrice) error {
+	ret := _m.Called(ctx, price)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ShopProductPrice) error); ok {
+		r0 = rf(ctx, price)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ShopProductRepositoryCreateTransferProduct provides a mock function with given fields: ctx, tp, sp, q
+func (_m *ShopRepository) ShopProductRepositoryCreateTransferProduct(ctx context.Context, tp *model.TransferProduct, sp *model.ShopProduct, q repository.Queue) error {
+	ret := _m.Called(ctx, tp, sp, q)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.TransferProduct, *model.ShopProduct, repository.Queue) error); ok {
+		r0 = rf(ctx, tp, sp, q)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ShopProductRepositoryDelete provides a mock function with given fields: ctx, id
+func (_m *ShopRepository) ShopProductRepositoryDelete(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ShopProductRepositoryDeleteScheduledPrice provides a mock function with given fields: ctx, shopProductID, priceID
+func (_m *ShopRepository) ShopProductRepositoryDeleteScheduledPrice(ctx context.Context, shopProductID int, priceID int) error {
+	ret := _m.Called(ctx, shopProductID, priceID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, shopProductID, priceID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ShopProductRepositoryGet provides a mock function with given fields: ctx, id
+func (_m *ShopRepository) ShopProductRepositoryGet(ctx context.Context, id int) (*model.ShopProduct, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.ShopProduct
+	if rf, ok := ret.Get(0).(func(context.Context, int) *model.ShopProduct); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ShopProduct)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShopProductRepositoryGetAll provides a mock function with given fields: ctx
+func (_m *ShopRepository) ShopProductRepositoryGetAll(ctx context.Context) ([]model.ShopProduct, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []model.ShopProduct
+	if rf, ok := ret.Get(0).(func(context.Context) []model.ShopProduct); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ShopProduct)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShopProductRepositoryGetByShopID provides a mock function with given fields: ctx, req
+func (_m *ShopRepository) ShopProductRepositoryGetByShopID(ctx context.Context, req model.ShopProductListRequest) ([]model.ShopProduct, int64, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 []model.ShopProduct
+	if rf, ok := ret.Get(0).(func(context.Context, model.ShopProductListRequest) []model.ShopProduct); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ShopProduct)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, model.ShopProductListRequest) int64); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, model.ShopProductListRequest) error); ok {
+		r2 = rf(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ShopProductRepositoryGetCurrentPrice provides a mock function with given fields: ctx, shopProductID, at
+func (_m *ShopRepository) ShopProductRepositoryGetCurrentPrice(ctx context.Context, shopProductID int, at time.Time) (*model.ShopProductPrice, error) {
+	ret := _m.Called(ctx, shopProductID, at)
+
+	var r0 *model.ShopProductPrice
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time) *model.ShopProductPrice); ok {
+		r0 = rf(ctx, shopProductID, at)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ShopProductPrice)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, time.Time) error); ok {
+		r1 = rf(ctx, shopProductID, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShopProductRepositoryGetPrices provides a mock function with given fields: ctx, shopProductID
+func (_m *ShopRepository) ShopProductRepositoryGetPrices(ctx context.Context, shopProductID int) ([]model.ShopProductPrice, error) {
+	ret := _m.Called(ctx, shopProductID)
+
+	var r0 []model.ShopProductPrice
+	if rf, ok := ret.Get(0).(func(context.Context, int) []model.ShopProductPrice); ok {
+		r0 = rf(ctx, shopProductID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ShopProductPrice)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, shopProductID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShopProductRepositoryGetStatusHistory provides a mock function with given fields: ctx, id
+func (_m *ShopRepository) ShopProductRepositoryGetStatusHistory(ctx context.Context, id int) ([]model.StatusChange, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 []model.StatusChange
+	if rf, ok := ret.Get(0).(func(context.Context, int) []model.StatusChange); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.StatusChange)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShopProductRepositoryGetStorefront provides a mock function with given fields: ctx, req
+func (_m *ShopRepository) ShopProductRepositoryGetStorefront(ctx context.Context, req model.StorefrontRequest) ([]model.StorefrontListing, int64, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 []model.StorefrontListing
+	if rf, ok := ret.Get(0).(func(context.Context, model.StorefrontRequest) []model.StorefrontListing); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.StorefrontListing)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, model.StorefrontRequest) int64); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, model.StorefrontRequest) error); ok {
+		r2 = rf(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ShopProductRepositoryGetTransferProduct provides a mock function with given fields: ctx, id
+func (_m *ShopRepository) ShopProductRepositoryGetTransferProduct(ctx context.Context, id int) (*model.TransferProduct, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.TransferProduct
+	if rf, ok := ret.Get(0).(func(context.Context, int) *model.TransferProduct); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TransferProduct)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShopProductRepositoryRevertTransferProduct provides a mock function with given fields: ctx, tp, sp, q
+func (_m *ShopRepository) ShopProductRepositoryRevertTransferProduct(ctx context.Context, tp *model.TransferProduct, sp *model.ShopProduct, q repository.Queue) error {
+	ret := _m.Called(ctx, tp, sp, q)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.TransferProduct, *model.ShopProduct, repository.Queue) error); ok {
+		r0 = rf(ctx, tp, sp, q)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ShopProductRepositoryUpdate provides a mock function with given fields: ctx, shopproduct
+func (_m *ShopRepository) ShopProductRepositoryUpdate(ctx context.Context, shopproduct *model.ShopProduct) error {
+	ret := _m.Called(ctx, shopproduct)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ShopProduct) error); ok {
+		r0 = rf(ctx, shopproduct)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: ctx, shop
+func (_m *ShopRepository) Update(ctx context.Context, shop *model.Shop) error {
+	ret := _m.Called(ctx, shop)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Shop) error); ok {
+		r0 = rf(ctx, shop)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,49 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Storage is an autogenerated mock type for the Storage type
+type Storage struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: ctx, fileURL
+func (_m *Storage) Delete(ctx context.Context, fileURL string) error {
+	ret := _m.Called(ctx, fileURL)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, fileURL)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Put provides a mock function with given fields: ctx, key, contentType, data
+func (_m *Storage) Put(ctx context.Context, key string, contentType string, data []byte) (string, error) {
+	ret := _m.Called(ctx, key, contentType, data)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []byte) string); ok {
+		r0 = rf(ctx, key, contentType, data)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, []byte) error); ok {
+		r1 = rf(ctx, key, contentType, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,103 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "simcomm-monolith/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// VariantRepository is an autogenerated mock type for the VariantRepository type
+type VariantRepository struct {
+	mock.Mock
+}
+
+// VariantRepositoryCreate provides a mock function with given fields: ctx, variant
+func (_m *VariantRepository) VariantRepositoryCreate(ctx context.Context, variant *model.ProductVariant) error {
+	ret := _m.Called(ctx, variant)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ProductVariant) error); ok {
+		r0 = rf(ctx, variant)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VariantRepositoryDelete provides a mock function with given fields: ctx, id
+func (_m *VariantRepository) VariantRepositoryDelete(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VariantRepositoryGet provides a mock function with given fields: ctx, id
+func (_m *VariantRepository) VariantRepositoryGet(ctx context.Context, id int) (*model.ProductVariant, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.ProductVariant
+	if rf, ok := ret.Get(0).(func(context.Context, int) *model.ProductVariant); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ProductVariant)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VariantRepositoryGetByProductID provides a mock function with given fields: ctx, productID
+func (_m *VariantRepository) VariantRepositoryGetByProductID(ctx context.Context, productID int) ([]model.ProductVariant, error) {
+	ret := _m.Called(ctx, productID)
+
+	var r0 []model.ProductVariant
+	if rf, ok := ret.Get(0).(func(context.Context, int) []model.ProductVariant); ok {
+		r0 = rf(ctx, productID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ProductVariant)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VariantRepositoryUpdate provides a mock function with given fields: ctx, variant
+func (_m *VariantRepository) VariantRepositoryUpdate(ctx context.Context, variant *model.ProductVariant) error {
+	ret := _m.Called(ctx, variant)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ProductVariant) error); ok {
+		r0 = rf(ctx, variant)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,170 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "simcomm-monolith/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// WarehouseStoredProductRepository is an autogenerated mock type for the WarehouseStoredProductRepository type
+type WarehouseStoredProductRepository struct {
+	mock.Mock
+}
+
+// WSPCreate provides a mock function with given fields: ctx, warehousestoredproduct
+func (_m *WarehouseStoredProductRepository) WSPCreate(ctx context.Context, warehousestoredproduct *model.WarehouseStoredProduct) error {
+	ret := _m.Called(ctx, warehousestoredproduct)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.WarehouseStoredProduct) error); ok {
+		r0 = rf(ctx, warehousestoredproduct)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WSPDelete provides a mock function with given fields: ctx, id
+func (_m *WarehouseStoredProductRepository) WSPDelete(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WSPGet provides a mock function with given fields: ctx, id
+func (_m *WarehouseStoredProductRepository) WSPGet(ctx context.Context, id int) (*model.WarehouseStoredProduct, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.WarehouseStoredProduct
+	if rf, ok := ret.Get(0).(func(context.Context, int) *model.WarehouseStoredProduct); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.WarehouseStoredProduct)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WSPGetAll provides a mock function with given fields: ctx
+func (_m *WarehouseStoredProductRepository) WSPGetAll(ctx context.Context) ([]model.WarehouseStoredProduct, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []model.WarehouseStoredProduct
+	if rf, ok := ret.Get(0).(func(context.Context) []model.WarehouseStoredProduct); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.WarehouseStoredProduct)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WSPGetByShopProductID provides a mock function with given fields: ctx, shopProductID, warehouseID
+func (_m *WarehouseStoredProductRepository) WSPGetByShopProductID(ctx context.Context, shopProductID int, warehouseID int) (*model.WarehouseStoredProduct, error) {
+	ret := _m.Called(ctx, shopProductID, warehouseID)
+
+	var r0 *model.WarehouseStoredProduct
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *model.WarehouseStoredProduct); ok {
+		r0 = rf(ctx, shopProductID, warehouseID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.WarehouseStoredProduct)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, shopProductID, warehouseID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WSPGetByWarehouseID provides a mock function with given fields: ctx, req
+func (_m *WarehouseStoredProductRepository) WSPGetByWarehouseID(ctx context.Context, req model.StoredProductListRequest) ([]model.WarehouseStoredProduct, int64, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 []model.WarehouseStoredProduct
+	if rf, ok := ret.Get(0).(func(context.Context, model.StoredProductListRequest) []model.WarehouseStoredProduct); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.WarehouseStoredProduct)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, model.StoredProductListRequest) int64); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, model.StoredProductListRequest) error); ok {
+		r2 = rf(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// WSPSubstractStock provides a mock function with given fields: ctx, warehousestoredproduct, subtrahend
+func (_m *WarehouseStoredProductRepository) WSPSubstractStock(ctx context.Context, warehousestoredproduct *model.WarehouseStoredProduct, subtrahend int) error {
+	ret := _m.Called(ctx, warehousestoredproduct, subtrahend)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.WarehouseStoredProduct, int) error); ok {
+		r0 = rf(ctx, warehousestoredproduct, subtrahend)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WSPUpdate provides a mock function with given fields: ctx, warehousestoredproduct
+func (_m *WarehouseStoredProductRepository) WSPUpdate(ctx context.Context, warehousestoredproduct *model.WarehouseStoredProduct) error {
+	ret := _m.Called(ctx, warehousestoredproduct)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.WarehouseStoredProduct) error); ok {
+		r0 = rf(ctx, warehousestoredproduct)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"simcomm-monolith/config"
+	"sync"
+	"time"
+)
+
+// Notifier delivers a message to an email address or phone number
+type Notifier interface {
+	Send(ctx context.Context, channel string, destination string, message string) error
+}
+
+func NewNotifier(cfg config.NotifierConfig) Notifier {
+	switch cfg.Driver {
+	case "log", "":
+		return &logNotifier{}
+	case "file":
+		return &fileNotifier{path: cfg.FilePath}
+	default:
+		log.Fatalf("unknown notifier driver : %v", cfg.Driver)
+	}
+	return nil
+}
+
+// logNotifier writes notifications to the application log, for local development
+type logNotifier struct{}
+
+func (n *logNotifier) Send(ctx context.Context, channel string, destination string, message string) error {
+	log.Printf("notification [%v] to %v : %v", channel, destination, message)
+	return nil
+}
+
+// fileNotifier appends notifications to a file, for local development and testing
+type fileNotifier struct {
+	path string
+	mu   sync.Mutex
+}
+
+func (n *fileNotifier) Send(ctx context.Context, channel string, destination string, message string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	f, err := os.OpenFile(n.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = fmt.Fprintf(f, "%v [%v] to %v : %v\n", time.Now().Format(time.RFC3339), channel, destination, message)
+	return err
+}
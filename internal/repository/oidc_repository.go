@@ -0,0 +1,308 @@
+package repository
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"simcomm-monolith/config"
+	"simcomm-monolith/internal/model"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// OIDCProvider performs the OpenID Connect authorization code flow with PKCE
+type OIDCProvider interface {
+	AuthorizationURL(ctx context.Context, state string, nonce string, codeChallenge string) (string, error)
+	Exchange(ctx context.Context, code string, codeVerifier string, nonce string) (*model.OIDCIdentity, error)
+}
+
+type oidcMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type oidcClaims struct {
+	jwt.RegisteredClaims
+	Nonce         string `json:"nonce"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+}
+
+type oidcProvider struct {
+	cfg    config.OIDCProviderConfig
+	client *http.Client
+
+	mu       sync.Mutex
+	metadata *oidcMetadata
+	keys     map[string]crypto.PublicKey
+}
+
+// NewOIDCProviders creates the configured providers keyed by name, provider
+// metadata is discovered lazily so the server can start while a provider is down
+func NewOIDCProviders(cfg config.OIDCConfig) map[string]OIDCProvider {
+	providers := make(map[string]OIDCProvider)
+	for _, providerCfg := range cfg.Providers {
+		providers[providerCfg.Name] = &oidcProvider{
+			cfg:    providerCfg,
+			client: &http.Client{Timeout: 10 * time.Second},
+		}
+	}
+	return providers
+}
+
+func (p *oidcProvider) AuthorizationURL(ctx context.Context, state string, nonce string, codeChallenge string) (string, error) {
+	metadata, err := p.getMetadata(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	scopes := p.cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{"openid", "email", "profile"}
+	}
+
+	query := url.Values{}
+	query.Set("response_type", "code")
+	query.Set("client_id", p.cfg.ClientID)
+	query.Set("redirect_uri", p.cfg.RedirectURL)
+	query.Set("scope", strings.Join(scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+
+	separator := "?"
+	if strings.Contains(metadata.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return metadata.AuthorizationEndpoint + separator + query.Encode(), nil
+}
+
+func (p *oidcProvider) Exchange(ctx context.Context, code string, codeVerifier string, nonce string) (*model.OIDCIdentity, error) {
+	metadata, err := p.getMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.cfg.RedirectURL)
+	form.Set("client_id", p.cfg.ClientID)
+	form.Set("code_verifier", codeVerifier)
+	if p.cfg.ClientSecret != "" {
+		form.Set("client_secret", p.cfg.ClientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, metadata.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token endpoint responded with status %v", resp.StatusCode)
+	}
+
+	var tokenResponse struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResponse); err != nil {
+		return nil, err
+	}
+	if tokenResponse.IDToken == "" {
+		return nil, errors.New("token endpoint did not return an id token")
+	}
+
+	return p.verifyIDToken(ctx, metadata, tokenResponse.IDToken, nonce)
+}
+
+func (p *oidcProvider) verifyIDToken(ctx context.Context, metadata *oidcMetadata, idToken string, nonce string) (*model.OIDCIdentity, error) {
+	parser := jwt.NewParser(jwt.WithValidMethods([]string{"RS256", "ES256", "EdDSA"}))
+	token, err := parser.ParseWithClaims(idToken, &oidcClaims{}, func(token *jwt.Token) (interface{}, error) {
+		keyID, _ := token.Header["kid"].(string)
+		return p.getKey(ctx, metadata, keyID)
+	})
+	if err != nil || !token.Valid {
+		return nil, fmt.Errorf("invalid id token : %v", err)
+	}
+
+	claims := token.Claims.(*oidcClaims)
+	if !claims.VerifyIssuer(metadata.Issuer, true) {
+		return nil, errors.New("invalid id token issuer")
+	}
+	if !claims.VerifyAudience(p.cfg.ClientID, true) {
+		return nil, errors.New("invalid id token audience")
+	}
+	if !claims.VerifyExpiresAt(time.Now(), true) {
+		return nil, errors.New("id token expired")
+	}
+	if claims.Nonce != nonce {
+		return nil, errors.New("invalid id token nonce")
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("id token has no subject")
+	}
+
+	return &model.OIDCIdentity{
+		Provider:      p.cfg.Name,
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		Name:          claims.Name,
+	}, nil
+}
+
+// getMetadata discovers the provider endpoints once, configured endpoints take precedence
+func (p *oidcProvider) getMetadata(ctx context.Context) (*oidcMetadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.metadata != nil {
+		return p.metadata, nil
+	}
+
+	metadata := &oidcMetadata{
+		Issuer:                p.cfg.Issuer,
+		AuthorizationEndpoint: p.cfg.AuthorizationEndpoint,
+		TokenEndpoint:         p.cfg.TokenEndpoint,
+		JWKSURI:               p.cfg.JWKSURI,
+	}
+
+	if metadata.AuthorizationEndpoint == "" || metadata.TokenEndpoint == "" || metadata.JWKSURI == "" {
+		var discovered oidcMetadata
+		discoveryURL := strings.TrimSuffix(p.cfg.Issuer, "/") + "/.well-known/openid-configuration"
+		if err := p.getJSON(ctx, discoveryURL, &discovered); err != nil {
+			return nil, err
+		}
+		if discovered.Issuer != p.cfg.Issuer {
+			return nil, fmt.Errorf("discovered issuer %v does not match %v", discovered.Issuer, p.cfg.Issuer)
+		}
+		if metadata.AuthorizationEndpoint == "" {
+			metadata.AuthorizationEndpoint = discovered.AuthorizationEndpoint
+		}
+		if metadata.TokenEndpoint == "" {
+			metadata.TokenEndpoint = discovered.TokenEndpoint
+		}
+		if metadata.JWKSURI == "" {
+			metadata.JWKSURI = discovered.JWKSURI
+		}
+	}
+
+	p.metadata = metadata
+	return p.metadata, nil
+}
+
+// getKey returns the verification key by its kid, keys are fetched again
+// when the kid is unknown since the provider may have rotated its keys
+func (p *oidcProvider) getKey(ctx context.Context, metadata *oidcMetadata, keyID string) (crypto.PublicKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := p.keys[keyID]; ok {
+		return key, nil
+	}
+
+	var jwks struct {
+		Keys []struct {
+			KeyID   string `json:"kid"`
+			KeyType string `json:"kty"`
+			Curve   string `json:"crv"`
+			N       string `json:"n"`
+			E       string `json:"e"`
+			X       string `json:"x"`
+			Y       string `json:"y"`
+		} `json:"keys"`
+	}
+	if err := p.getJSON(ctx, metadata.JWKSURI, &jwks); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]crypto.PublicKey)
+	for _, jwk := range jwks.Keys {
+		switch jwk.KeyType {
+		case "RSA":
+			n, errN := base64.RawURLEncoding.DecodeString(jwk.N)
+			e, errE := base64.RawURLEncoding.DecodeString(jwk.E)
+			if errN != nil || errE != nil {
+				continue
+			}
+			keys[jwk.KeyID] = &rsa.PublicKey{
+				N: new(big.Int).SetBytes(n),
+				E: int(new(big.Int).SetBytes(e).Int64()),
+			}
+		case "EC":
+			if jwk.Curve != "P-256" {
+				continue
+			}
+			x, errX := base64.RawURLEncoding.DecodeString(jwk.X)
+			y, errY := base64.RawURLEncoding.DecodeString(jwk.Y)
+			if errX != nil || errY != nil {
+				continue
+			}
+			keys[jwk.KeyID] = &ecdsa.PublicKey{
+				Curve: elliptic.P256(),
+				X:     new(big.Int).SetBytes(x),
+				Y:     new(big.Int).SetBytes(y),
+			}
+		case "OKP":
+			if jwk.Curve != "Ed25519" {
+				continue
+			}
+			x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+			if err != nil || len(x) != ed25519.PublicKeySize {
+				continue
+			}
+			keys[jwk.KeyID] = ed25519.PublicKey(x)
+		}
+	}
+	p.keys = keys
+
+	key, ok := p.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("unknown id token key %v", keyID)
+	}
+	return key, nil
+}
+
+func (p *oidcProvider) getJSON(ctx context.Context, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%v responded with status %v", url, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
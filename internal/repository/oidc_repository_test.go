@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"simcomm-monolith/config"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubIdP is an OpenID Connect provider answering discovery, JWKS and token
+// requests, the token endpoint returns the ID token built by idToken
+type stubIdP struct {
+	server  *httptest.Server
+	key     *rsa.PrivateKey
+	idToken func(issuer string) jwt.Claims
+	form    url.Values
+}
+
+func newStubIdP(t *testing.T) *stubIdP {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	idp := &stubIdP{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(oidcMetadata{
+			Issuer:                idp.server.URL,
+			AuthorizationEndpoint: idp.server.URL + "/authorize",
+			TokenEndpoint:         idp.server.URL + "/token",
+			JWKSURI:               idp.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kid": "idp-key",
+				"kty": "RSA",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		idp.form = r.PostForm
+
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, idp.idToken(idp.server.URL))
+		token.Header["kid"] = "idp-key"
+		signed, err := token.SignedString(key)
+		require.NoError(t, err)
+		json.NewEncoder(w).Encode(map[string]string{"id_token": signed})
+	})
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+	return idp
+}
+
+func TestOIDCProviderExchange(t *testing.T) {
+	claims := func(issuer string, audience string, nonce string, expiresAt time.Time) jwt.Claims {
+		return oidcClaims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				Issuer:    issuer,
+				Subject:   "subject-1",
+				Audience:  jwt.ClaimStrings{audience},
+				ExpiresAt: jwt.NewNumericDate(expiresAt),
+			},
+			Nonce:         nonce,
+			Email:         "jane@example.com",
+			EmailVerified: true,
+			Name:          "Jane Doe",
+		}
+	}
+
+	tests := []struct {
+		name    string
+		idToken func(issuer string) jwt.Claims
+		wantErr bool
+	}{
+		{
+			name:    "valid id token",
+			idToken: func(issuer string) jwt.Claims { return claims(issuer, "client", "nonce", time.Now().Add(time.Hour)) },
+		},
+		{
+			name: "other audience",
+			idToken: func(issuer string) jwt.Claims {
+				return claims(issuer, "other-client", "nonce", time.Now().Add(time.Hour))
+			},
+			wantErr: true,
+		},
+		{
+			name: "other issuer",
+			idToken: func(issuer string) jwt.Claims {
+				return claims("https://evil.example", "client", "nonce", time.Now().Add(time.Hour))
+			},
+			wantErr: true,
+		},
+		{
+			name: "replayed nonce",
+			idToken: func(issuer string) jwt.Claims {
+				return claims(issuer, "client", "old-nonce", time.Now().Add(time.Hour))
+			},
+			wantErr: true,
+		},
+		{
+			name:    "expired id token",
+			idToken: func(issuer string) jwt.Claims { return claims(issuer, "client", "nonce", time.Now().Add(-time.Hour)) },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idp := newStubIdP(t)
+			idp.idToken = tt.idToken
+			provider := NewOIDCProviders(config.OIDCConfig{Providers: []config.OIDCProviderConfig{{
+				Name:        "stub",
+				Issuer:      idp.server.URL,
+				ClientID:    "client",
+				RedirectURL: "https://shop.example/callback",
+			}}})["stub"]
+
+			authorizationURL, err := provider.AuthorizationURL(context.Background(), "state", "nonce", "challenge")
+			require.NoError(t, err)
+			assert.Contains(t, authorizationURL, idp.server.URL+"/authorize?")
+			assert.Contains(t, authorizationURL, "code_challenge_method=S256")
+
+			identity, err := provider.Exchange(context.Background(), "code", "verifier", "nonce")
+			assert.Equal(t, "verifier", idp.form.Get("code_verifier"))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "stub", identity.Provider)
+			assert.Equal(t, "subject-1", identity.Subject)
+			assert.Equal(t, "jane@example.com", identity.Email)
+			assert.True(t, identity.EmailVerified)
+		})
+	}
+}
@@ -12,6 +12,8 @@ type OrderRepository interface {
 	Create(ctx context.Context, order *model.Order) error
 	Get(ctx context.Context, id int) (*model.Order, error)
 	GetAll(ctx context.Context) ([]model.Order, error)
+	GetByUserID(ctx context.Context, userID int) ([]model.Order, error)
+	GetVisibleToUser(ctx context.Context, userID int) ([]model.Order, error)
 	Update(ctx context.Context, order *model.Order) error
 	Delete(ctx context.Context, id int) error
 }
@@ -48,6 +50,30 @@ func (r *postgresOrderRepository) GetAll(ctx context.Context) ([]model.Order, er
 	return orders, nil
 }
 
+// GetByUserID retrieves the orders placed by an user
+func (r *postgresOrderRepository) GetByUserID(ctx context.Context, userID int) ([]model.Order, error) {
+	var orders []model.Order
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Find(&orders).Error; err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// GetVisibleToUser retrieves the orders placed by an user and the orders of the shops
+// the user owns or is a member of
+func (r *postgresOrderRepository) GetVisibleToUser(ctx context.Context, userID int) ([]model.Order, error) {
+	var orders []model.Order
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Or("shop_id IN (?)", r.db.Model(&model.Shop{}).Select("id").Where("user_id = ?", userID)).
+		Or("shop_id IN (?)", r.db.Model(&model.ShopMember{}).Select("shop_id").Where("user_id = ?", userID)).
+		Order("created_at DESC").
+		Find(&orders).Error; err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
 // Update updates an existing order
 func (r *postgresOrderRepository) Update(ctx context.Context, order *model.Order) error {
 	if err := r.db.WithContext(ctx).Save(order).Error; err != nil {
@@ -9,6 +9,7 @@ import (
 
 	log "github.com/labstack/gommon/log"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ProductRepository interface {
@@ -17,6 +18,15 @@ type ProductRepository interface {
 	GetAll(ctx context.Context) ([]model.Product, error)
 	Update(ctx context.Context, product *model.Product) error
 	Delete(ctx context.Context, id int) error
+	Search(ctx context.Context, req model.ProductSearchRequest) (*model.ProductSearchResult, error)
+	UpsertByCode(ctx context.Context, product *model.Product, authorize func(existing *model.Product) error) (bool, error)
+	FindInBatches(ctx context.Context, batchSize int, fn func([]model.Product) error) error
+	GetStatusHistory(ctx context.Context, id int) ([]model.StatusChange, error)
+	GetShopIDs(ctx context.Context, ids ...int) ([]int, error)
+
+	CategoryRepository
+	VariantRepository
+	ImportJobRepository
 }
 
 type postgresProductRepository struct {
@@ -75,3 +85,520 @@ func (r *postgresProductRepository) Delete(ctx context.Context, id int) error {
 	}
 	return nil
 }
+
+// UpsertByCode creates the product or updates the product with the same code and
+// reports whether it was created, an updated product keeps its status, its owners,
+// and its image when none is given. The product with the same code is only updated
+// when authorize accepts it
+func (r *postgresProductRepository) UpsertByCode(ctx context.Context, product *model.Product, authorize func(existing *model.Product) error) (bool, error) {
+	created := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing model.Product
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("code = ?", product.Code).
+			First(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			created = true
+			return tx.Create(product).Error
+		}
+		if err != nil {
+			return err
+		}
+		if err := authorize(&existing); err != nil {
+			return err
+		}
+
+		product.ID = existing.ID
+		product.UserID = existing.UserID
+		product.ShopID = existing.ShopID
+		product.CreatedAt = existing.CreatedAt
+		product.KeepStatus(existing.Status)
+		if product.Detail.ImageURL == "" || product.Detail.ImageURL == existing.Detail.ImageURL {
+			product.Detail.ImageURL = existing.Detail.ImageURL
+			product.Detail.ThumbnailURL = existing.Detail.ThumbnailURL
+		}
+		return tx.Save(product).Error
+	})
+	if err != nil {
+		log.Error(err)
+		return false, err
+	}
+	return created, nil
+}
+
+// FindInBatches calls fn with every product ordered by ID, batchSize products at a time
+func (r *postgresProductRepository) FindInBatches(ctx context.Context, batchSize int, fn func([]model.Product) error) error {
+	var products []model.Product
+	return r.db.WithContext(ctx).
+		Order("id ASC").
+		FindInBatches(&products, batchSize, func(tx *gorm.DB, batch int) error {
+			return fn(products)
+		}).Error
+}
+
+// GetStatusHistory retrieves the status changes of a product
+func (r *postgresProductRepository) GetStatusHistory(ctx context.Context, id int) ([]model.StatusChange, error) {
+	return getStatusHistory(ctx, r.db, model.StatusEntityProduct, id)
+}
+
+// GetShopIDs retrieves the shops listing any of the products
+func (r *postgresProductRepository) GetShopIDs(ctx context.Context, ids ...int) ([]int, error) {
+	shopIDs := []int{}
+	if len(ids) == 0 {
+		return shopIDs, nil
+	}
+	if err := r.db.WithContext(ctx).
+		Model(&model.ShopProduct{}).
+		Distinct("shop_id").
+		Where("product_id IN ?", ids).
+		Pluck("shop_id", &shopIDs).Error; err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	return shopIDs, nil
+}
+
+// productSearchDocument is the weighted text searched for a product, the simple
+// configuration is used since names and attributes are not in a single language.
+// It is backed by the products_search_idx GIN index on the same expression, keep
+// both in sync or the index is no longer used
+const productSearchDocument = `setweight(to_tsvector('simple', coalesce(products.name, '')), 'A') || ` +
+	`setweight(to_tsvector('simple', coalesce(products.code, '')), 'A') || ` +
+	`setweight(jsonb_to_tsvector('simple', coalesce(products.detail->'attributes', '{}'::jsonb), '["string", "numeric"]'), 'B')`
+
+const productSearchQuery = `websearch_to_tsquery('simple', ?)`
+
+const searchFacetCategory = "category"
+const searchFacetShop = "shop"
+const searchFacetInStock = "in_stock"
+
+// searchQuery selects the active listings of active products in active shops
+// matching the search, the filter of the skipped facet is left out so its facet
+// counts every value
+func (r *postgresProductRepository) searchQuery(ctx context.Context, req model.ProductSearchRequest, skip string) *gorm.DB {
+	query := r.db.WithContext(ctx).
+		Table("shop_products").
+		Joins("JOIN products ON products.id = shop_products.product_id").
+		Joins("JOIN shops ON shops.id = shop_products.shop_id").
+		Where("shop_products.status = ? AND products.status = ? AND shops.status = ?",
+			model.StatusActive, model.StatusActive, model.StatusActive)
+
+	if req.Query != "" {
+		query = query.Where(productSearchDocument+" @@ "+productSearchQuery, req.Query)
+	}
+	if skip != searchFacetCategory && len(req.CategoryIDs) > 0 {
+		query = query.Where("products.category_id IN ?", req.CategoryIDs)
+	}
+	if skip != searchFacetShop && req.ShopID != nil {
+		query = query.Where("shop_products.shop_id = ?", *req.ShopID)
+	}
+	if req.Currency != "" {
+		query = query.Where("shop_products.currency = ?", req.Currency)
+	}
+	if req.MinPrice != nil {
+		query = query.Where("shop_products.price >= ?", *req.MinPrice)
+	}
+	if req.MaxPrice != nil {
+		query = query.Where("shop_products.price <= ?", *req.MaxPrice)
+	}
+	if skip != searchFacetInStock && req.InStock != nil {
+		if *req.InStock {
+			query = query.Where("shop_products.stock > 0")
+		} else {
+			query = query.Where("shop_products.stock <= 0")
+		}
+	}
+	return query
+}
+
+// Search retrieves a page of shop listings matching the search with the products
+// they sell, ranked by relevance unless another sort is requested
+func (r *postgresProductRepository) Search(ctx context.Context, req model.ProductSearchRequest) (*model.ProductSearchResult, error) {
+	result := &model.ProductSearchResult{
+		Hits:  []model.ProductSearchHit{},
+		Page:  req.Page,
+		Limit: req.Limit,
+	}
+
+	if err := r.searchQuery(ctx, req, "").Count(&result.Total).Error; err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	var ranked []struct {
+		ID        int
+		ProductID int
+		Rank      float64
+	}
+	query := r.searchQuery(ctx, req, "")
+	if req.Query != "" {
+		query = query.Select("shop_products.id, shop_products.product_id, ts_rank("+productSearchDocument+", "+productSearchQuery+") AS rank", req.Query)
+	} else {
+		query = query.Select("shop_products.id, shop_products.product_id, 0 AS rank")
+	}
+	switch req.Sort {
+	case model.SearchSortPriceAsc:
+		query = query.Order("shop_products.price ASC")
+	case model.SearchSortPriceDesc:
+		query = query.Order("shop_products.price DESC")
+	case model.SearchSortNewest:
+		query = query.Order("shop_products.created_at DESC")
+	default:
+		query = query.Order("rank DESC")
+	}
+	if err := query.Order("shop_products.id ASC").
+		Offset(req.Offset()).
+		Limit(req.Limit).
+		Scan(&ranked).Error; err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if len(ranked) > 0 {
+		shopProductIDs := make([]int, 0, len(ranked))
+		productIDs := make([]int, 0, len(ranked))
+		for _, hit := range ranked {
+			shopProductIDs = append(shopProductIDs, hit.ID)
+			productIDs = append(productIDs, hit.ProductID)
+		}
+
+		var shopProducts []model.ShopProduct
+		if err := r.db.WithContext(ctx).Where("id IN ?", shopProductIDs).Find(&shopProducts).Error; err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		var products []model.Product
+		if err := r.db.WithContext(ctx).Where("id IN ?", productIDs).Find(&products).Error; err != nil {
+			log.Error(err)
+			return nil, err
+		}
+
+		shopProductByID := make(map[int]model.ShopProduct, len(shopProducts))
+		for _, shopProduct := range shopProducts {
+			shopProductByID[shopProduct.ID] = shopProduct
+		}
+		productByID := make(map[int]model.Product, len(products))
+		for _, product := range products {
+			productByID[product.ID] = product
+		}
+		for _, hit := range ranked {
+			result.Hits = append(result.Hits, model.ProductSearchHit{
+				ShopProduct: shopProductByID[hit.ID],
+				Product:     productByID[hit.ProductID],
+				Rank:        hit.Rank,
+			})
+		}
+	}
+
+	facets, err := r.searchFacets(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	result.Facets = *facets
+	return result, nil
+}
+
+// searchFacets counts the hits of the search per category, shop and availability
+func (r *postgresProductRepository) searchFacets(ctx context.Context, req model.ProductSearchRequest) (*model.SearchFacets, error) {
+	facets := &model.SearchFacets{
+		Categories: []model.FacetCount{},
+		Shops:      []model.FacetCount{},
+		InStock:    []model.FacetCount{},
+	}
+
+	var categories []struct {
+		Value *int
+		Count int64
+	}
+	if err := r.searchQuery(ctx, req, searchFacetCategory).
+		Select("products.category_id AS value, COUNT(*) AS count").
+		Group("products.category_id").
+		Order("count DESC").
+		Scan(&categories).Error; err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	for _, category := range categories {
+		facets.Categories = append(facets.Categories, model.FacetCount{Value: category.Value, Count: category.Count})
+	}
+
+	var shops []struct {
+		Value int
+		Count int64
+	}
+	if err := r.searchQuery(ctx, req, searchFacetShop).
+		Select("shop_products.shop_id AS value, COUNT(*) AS count").
+		Group("shop_products.shop_id").
+		Order("count DESC").
+		Scan(&shops).Error; err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	for _, shop := range shops {
+		facets.Shops = append(facets.Shops, model.FacetCount{Value: shop.Value, Count: shop.Count})
+	}
+
+	var inStock []struct {
+		Value bool
+		Count int64
+	}
+	if err := r.searchQuery(ctx, req, searchFacetInStock).
+		Select("shop_products.stock > 0 AS value, COUNT(*) AS count").
+		Group("shop_products.stock > 0").
+		Order("value DESC").
+		Scan(&inStock).Error; err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	for _, availability := range inStock {
+		facets.InStock = append(facets.InStock, model.FacetCount{Value: availability.Value, Count: availability.Count})
+	}
+	return facets, nil
+}
+
+type CategoryRepository interface {
+	CategoryRepositoryCreate(ctx context.Context, category *model.Category) error
+	CategoryRepositoryGet(ctx context.Context, id int) (*model.Category, error)
+	CategoryRepositoryGetAll(ctx context.Context) ([]model.Category, error)
+	CategoryRepositoryUpdate(ctx context.Context, category *model.Category) error
+	CategoryRepositoryDelete(ctx context.Context, id int) error
+}
+
+// CategoryRepositoryCreate inserts a new category into the database
+func (r *postgresProductRepository) CategoryRepositoryCreate(ctx context.Context, category *model.Category) error {
+	if err := r.db.WithContext(ctx).Create(category).Error; err != nil {
+		if strings.Contains(err.Error(), util.SQLSTATE_23505) {
+			return errors.New(util.ErrCategorySlugExists)
+		}
+		log.Error(err)
+		return err
+	}
+	return nil
+}
+
+// CategoryRepositoryGet retrieves a category by ID
+func (r *postgresProductRepository) CategoryRepositoryGet(ctx context.Context, id int) (*model.Category, error) {
+	var category model.Category
+	if err := r.db.WithContext(ctx).First(&category, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(util.ErrCategoryNotFound)
+		}
+		log.Error(err)
+		return nil, err
+	}
+	return &category, nil
+}
+
+// CategoryRepositoryGetAll retrieves every category, the service builds the tree from them
+func (r *postgresProductRepository) CategoryRepositoryGetAll(ctx context.Context) ([]model.Category, error) {
+	var categories []model.Category
+	if err := r.db.WithContext(ctx).Order("name ASC").Find(&categories).Error; err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+// CategoryRepositoryUpdate updates an existing category
+func (r *postgresProductRepository) CategoryRepositoryUpdate(ctx context.Context, category *model.Category) error {
+	if err := r.db.WithContext(ctx).Save(category).Error; err != nil {
+		if strings.Contains(err.Error(), util.SQLSTATE_23505) {
+			return errors.New(util.ErrCategorySlugExists)
+		}
+		log.Error(err)
+		return err
+	}
+	return nil
+}
+
+// CategoryRepositoryDelete removes a category that has no sub categories and no products
+func (r *postgresProductRepository) CategoryRepositoryDelete(ctx context.Context, id int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var children, products int64
+		if err := tx.Model(&model.Category{}).Where("parent_id = ?", id).Count(&children).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&model.Product{}).Where("category_id = ?", id).Count(&products).Error; err != nil {
+			return err
+		}
+		if children > 0 || products > 0 {
+			return errors.New(util.ErrCategoryInUse)
+		}
+
+		result := tx.Delete(&model.Category{}, id)
+		if result.Error != nil {
+			log.Error(result.Error)
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New(util.ErrCategoryNotFound)
+		}
+		return nil
+	})
+}
+
+type VariantRepository interface {
+	VariantRepositoryCreate(ctx context.Context, variant *model.ProductVariant) error
+	VariantRepositoryGet(ctx context.Context, id int) (*model.ProductVariant, error)
+	VariantRepositoryGetByProductID(ctx context.Context, productID int) ([]model.ProductVariant, error)
+	VariantRepositoryUpdate(ctx context.Context, variant *model.ProductVariant) error
+	VariantRepositoryDelete(ctx context.Context, id int) error
+}
+
+// VariantRepositoryCreate inserts a new variant into the database, a product that
+// is still listed without a variant cannot get variants as its listings would not
+// tell which variant they sell
+func (r *postgresProductRepository) VariantRepositoryCreate(ctx context.Context, variant *model.ProductVariant) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var product model.Product
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&product, variant.ProductID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New(util.ErrProductNotFound)
+			}
+			log.Error(err)
+			return err
+		}
+
+		var listings int64
+		if err := tx.Model(&model.ShopProduct{}).
+			Where("product_id = ? AND variant_id IS NULL", variant.ProductID).
+			Count(&listings).Error; err != nil {
+			log.Error(err)
+			return err
+		}
+		if listings > 0 {
+			return errors.New(util.ErrProductListedWithoutVariant)
+		}
+
+		if err := tx.Create(variant).Error; err != nil {
+			if strings.Contains(err.Error(), util.SQLSTATE_23505) {
+				return errors.New(util.ErrSKUAlreadyExists)
+			}
+			log.Error(err)
+			return err
+		}
+		return nil
+	})
+}
+
+// VariantRepositoryGet retrieves a variant by ID
+func (r *postgresProductRepository) VariantRepositoryGet(ctx context.Context, id int) (*model.ProductVariant, error) {
+	var variant model.ProductVariant
+	if err := r.db.WithContext(ctx).First(&variant, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(util.ErrVariantNotFound)
+		}
+		log.Error(err)
+		return nil, err
+	}
+	return &variant, nil
+}
+
+// VariantRepositoryGetByProductID retrieves the variants of a product with the
+// stock stored for each variant across every warehouse
+func (r *postgresProductRepository) VariantRepositoryGetByProductID(ctx context.Context, productID int) ([]model.ProductVariant, error) {
+	var variants []model.ProductVariant
+	if err := r.db.WithContext(ctx).
+		Where("product_id = ?", productID).
+		Order("id ASC").
+		Find(&variants).Error; err != nil {
+		return nil, err
+	}
+	if len(variants) == 0 {
+		return variants, nil
+	}
+
+	variantIDs := make([]int, 0, len(variants))
+	for _, variant := range variants {
+		variantIDs = append(variantIDs, variant.ID)
+	}
+
+	var stocks []struct {
+		VariantID int
+		Stock     int
+	}
+	if err := r.db.WithContext(ctx).
+		Model(&model.WarehouseStoredProduct{}).
+		Select("variant_id, COALESCE(SUM(stock), 0) AS stock").
+		Where("variant_id IN ?", variantIDs).
+		Group("variant_id").
+		Scan(&stocks).Error; err != nil {
+		return nil, err
+	}
+
+	stockByVariant := make(map[int]int, len(stocks))
+	for _, stock := range stocks {
+		stockByVariant[stock.VariantID] = stock.Stock
+	}
+	for i := range variants {
+		variants[i].Stock = stockByVariant[variants[i].ID]
+	}
+	return variants, nil
+}
+
+// VariantRepositoryUpdate updates an existing variant
+func (r *postgresProductRepository) VariantRepositoryUpdate(ctx context.Context, variant *model.ProductVariant) error {
+	if err := r.db.WithContext(ctx).Save(variant).Error; err != nil {
+		if strings.Contains(err.Error(), util.SQLSTATE_23505) {
+			return errors.New(util.ErrSKUAlreadyExists)
+		}
+		log.Error(err)
+		return err
+	}
+	return nil
+}
+
+// VariantRepositoryDelete removes a variant that is not listed in any shop
+func (r *postgresProductRepository) VariantRepositoryDelete(ctx context.Context, id int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var listings int64
+		if err := tx.Model(&model.ShopProduct{}).Where("variant_id = ?", id).Count(&listings).Error; err != nil {
+			return err
+		}
+		if listings > 0 {
+			return errors.New(util.ErrVariantInUse)
+		}
+
+		if err := tx.Delete(&model.ProductVariant{}, id).Error; err != nil {
+			log.Error(err)
+			return err
+		}
+		return nil
+	})
+}
+
+type ImportJobRepository interface {
+	ImportJobRepositoryCreate(ctx context.Context, job *model.ProductImportJob) error
+	ImportJobRepositoryGet(ctx context.Context, id int) (*model.ProductImportJob, error)
+	ImportJobRepositoryUpdate(ctx context.Context, job *model.ProductImportJob) error
+}
+
+// ImportJobRepositoryCreate inserts a new import job into the database
+func (r *postgresProductRepository) ImportJobRepositoryCreate(ctx context.Context, job *model.ProductImportJob) error {
+	if err := r.db.WithContext(ctx).Create(job).Error; err != nil {
+		log.Error(err)
+		return err
+	}
+	return nil
+}
+
+// ImportJobRepositoryGet retrieves an import job by ID
+func (r *postgresProductRepository) ImportJobRepositoryGet(ctx context.Context, id int) (*model.ProductImportJob, error) {
+	var job model.ProductImportJob
+	if err := r.db.WithContext(ctx).First(&job, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(util.ErrImportJobNotFound)
+		}
+		log.Error(err)
+		return nil, err
+	}
+	return &job, nil
+}
+
+// ImportJobRepositoryUpdate updates the status and report of an import job
+func (r *postgresProductRepository) ImportJobRepositoryUpdate(ctx context.Context, job *model.ProductImportJob) error {
+	if err := r.db.WithContext(ctx).Save(job).Error; err != nil {
+		log.Error(err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"regexp"
+	"simcomm-monolith/internal/model"
+	"simcomm-monolith/util"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func newMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	require.NoError(t, err)
+	return db, mock
+}
+
+func TestVariantRepositoryCreate(t *testing.T) {
+	tests := []struct {
+		name     string
+		listings int
+		wantErr  string
+	}{
+		{"product listed only through variants", 0, ""},
+		{"product listed without a variant", 1, util.ErrProductListedWithoutVariant},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+			repo := NewPostgreProductRepository(db)
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE "products"."id" = $1 ORDER BY "products"."id" LIMIT $2 FOR UPDATE`)).
+				WithArgs(5, 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "code", "name", "status"}).AddRow(5, "P-5", "Runner", "active"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "shop_products" WHERE product_id = $1 AND variant_id IS NULL`)).
+				WithArgs(5).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(tt.listings))
+			if tt.wantErr == "" {
+				mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "product_variants"`)).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			variant := &model.ProductVariant{ProductID: 5, SKU: "RUN-42-BLK"}
+			err := repo.VariantRepositoryCreate(context.Background(), variant)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, 1, variant.ID)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestProductRepositorySearchOnlyActiveListings(t *testing.T) {
+	db, mock := newMockDB(t)
+	repo := NewPostgreProductRepository(db)
+
+	activeOnly := regexp.QuoteMeta(`FROM "shop_products" JOIN products ON products.id = shop_products.product_id `+
+		`JOIN shops ON shops.id = shop_products.shop_id `) +
+		`WHERE \(shop_products\.status = \$\d+ AND products\.status = \$\d+ AND shops\.status = \$\d+\)`
+	active := model.StatusActive
+
+	mock.ExpectQuery(`SELECT count\(\*\) `+activeOnly).
+		WithArgs(active, active, active, "runner").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(`SELECT shop_products.id, .+ ` + activeOnly).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "rank"}))
+	for _, facet := range []string{"products.category_id", "shop_products.shop_id", "shop_products.stock > 0"} {
+		mock.ExpectQuery(regexp.QuoteMeta(facet) + `.+ ` + activeOnly).
+			WillReturnRows(sqlmock.NewRows([]string{"value", "count"}))
+	}
+
+	result, err := repo.Search(context.Background(), model.ProductSearchRequest{Query: "runner", Page: 1, Limit: 20})
+	require.NoError(t, err)
+	assert.Empty(t, result.Hits)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductSearchIndexMatchesDocument(t *testing.T) {
+	ddl, err := os.ReadFile("../../migrations/0009_product_search_index.sql")
+	require.NoError(t, err)
+
+	whitespace := regexp.MustCompile(`\s+`)
+	normalize := func(s string) string {
+		return whitespace.ReplaceAllString(s, "")
+	}
+	assert.Contains(t, normalize(string(ddl)), normalize(productSearchDocument),
+		"the index expression must match productSearchDocument")
+}
@@ -0,0 +1,234 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"math"
+	"simcomm-monolith/internal/model"
+	"simcomm-monolith/util"
+	"strings"
+
+	log "github.com/labstack/gommon/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReviewRepository interface {
+	Create(ctx context.Context, review *model.Review) error
+	Get(ctx context.Context, id int) (*model.Review, error)
+	Update(ctx context.Context, review *model.Review) error
+	GetByProductID(ctx context.Context, req model.ReviewListRequest) ([]model.Review, int64, error)
+	GetFlagged(ctx context.Context, req model.ReviewListRequest) ([]model.Review, int64, error)
+	GetProductRating(ctx context.Context, productID int) (*model.RatingSummary, error)
+	GetShopRating(ctx context.Context, shopID int) (*model.RatingSummary, error)
+	AddFlag(ctx context.Context, flag *model.ReviewFlag, hideThreshold int) (*model.Review, error)
+}
+
+type postgresReviewRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgreReviewRepository creates a new instance of ReviewRepository
+func NewPostgreReviewRepository(db *gorm.DB) *postgresReviewRepository {
+	return &postgresReviewRepository{db: db}
+}
+
+// Create inserts a new review, an order line is reviewed only once
+func (r *postgresReviewRepository) Create(ctx context.Context, review *model.Review) error {
+	if err := r.db.WithContext(ctx).Create(review).Error; err != nil {
+		if strings.Contains(err.Error(), util.SQLSTATE_23505) {
+			return errors.New(util.ErrReviewAlreadyExists)
+		}
+		log.Error(err)
+		return err
+	}
+	return nil
+}
+
+// Get retrieves a review by ID
+func (r *postgresReviewRepository) Get(ctx context.Context, id int) (*model.Review, error) {
+	var review model.Review
+	if err := r.db.WithContext(ctx).First(&review, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(util.ErrReviewNotFound)
+		}
+		log.Error(err)
+		return nil, err
+	}
+	return &review, nil
+}
+
+// Update updates an existing review
+func (r *postgresReviewRepository) Update(ctx context.Context, review *model.Review) error {
+	if err := r.db.WithContext(ctx).Save(review).Error; err != nil {
+		log.Error(err)
+		return err
+	}
+	return nil
+}
+
+// reviewQuery selects the reviews of the product that are not hidden and match the filters
+func (r *postgresReviewRepository) reviewQuery(ctx context.Context, req model.ReviewListRequest) *gorm.DB {
+	query := r.db.WithContext(ctx).
+		Model(&model.Review{}).
+		Where("product_id = ? AND moderation_status <> ?", req.ProductID, model.ReviewStatusHidden)
+
+	if req.ShopID != nil {
+		query = query.Where("shop_id = ?", *req.ShopID)
+	}
+	if req.VariantID != nil {
+		query = query.Where("variant_id = ?", *req.VariantID)
+	}
+	if req.Rating != nil {
+		query = query.Where("rating = ?", *req.Rating)
+	}
+	if req.MinRating != nil {
+		query = query.Where("rating >= ?", *req.MinRating)
+	}
+	if req.WithComment != nil {
+		if *req.WithComment {
+			query = query.Where("comment <> ''")
+		} else {
+			query = query.Where("comment = ''")
+		}
+	}
+	if req.WithReply != nil {
+		if *req.WithReply {
+			query = query.Where("reply <> ''")
+		} else {
+			query = query.Where("reply = ''")
+		}
+	}
+	return query
+}
+
+// GetByProductID retrieves a page of the reviews of a product and the number of
+// reviews matching the request
+func (r *postgresReviewRepository) GetByProductID(ctx context.Context, req model.ReviewListRequest) ([]model.Review, int64, error) {
+	var total int64
+	if err := r.reviewQuery(ctx, req).Count(&total).Error; err != nil {
+		log.Error(err)
+		return nil, 0, err
+	}
+
+	query := r.reviewQuery(ctx, req)
+	switch req.Sort {
+	case model.ReviewSortOldest:
+		query = query.Order("created_at ASC")
+	case model.ReviewSortRatingDesc:
+		query = query.Order("rating DESC").Order("created_at DESC")
+	case model.ReviewSortRatingAsc:
+		query = query.Order("rating ASC").Order("created_at DESC")
+	default:
+		query = query.Order("created_at DESC")
+	}
+
+	reviews := []model.Review{}
+	if err := query.Order("id ASC").
+		Offset(req.Offset()).
+		Limit(req.Limit).
+		Find(&reviews).Error; err != nil {
+		log.Error(err)
+		return nil, 0, err
+	}
+	return reviews, total, nil
+}
+
+// GetFlagged retrieves a page of the reviews waiting for moderation or hidden by
+// flags, the most flagged first
+func (r *postgresReviewRepository) GetFlagged(ctx context.Context, req model.ReviewListRequest) ([]model.Review, int64, error) {
+	query := r.db.WithContext(ctx).
+		Model(&model.Review{}).
+		Where("flag_count > 0 AND moderation_status IN ?", []string{model.ReviewStatusFlagged, model.ReviewStatusHidden})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		log.Error(err)
+		return nil, 0, err
+	}
+
+	reviews := []model.Review{}
+	if err := query.Order("flag_count DESC").
+		Order("id ASC").
+		Offset(req.Offset()).
+		Limit(req.Limit).
+		Find(&reviews).Error; err != nil {
+		log.Error(err)
+		return nil, 0, err
+	}
+	return reviews, total, nil
+}
+
+// GetProductRating aggregates the ratings of a product across every shop selling it
+func (r *postgresReviewRepository) GetProductRating(ctx context.Context, productID int) (*model.RatingSummary, error) {
+	return r.ratingSummary(ctx, "product_id", productID)
+}
+
+// GetShopRating aggregates the ratings of every product sold by a shop
+func (r *postgresReviewRepository) GetShopRating(ctx context.Context, shopID int) (*model.RatingSummary, error) {
+	return r.ratingSummary(ctx, "shop_id", shopID)
+}
+
+// ratingSummary counts the reviews that are not hidden per rating, the average is
+// derived from the counts and rounded to two decimals
+func (r *postgresReviewRepository) ratingSummary(ctx context.Context, column string, id int) (*model.RatingSummary, error) {
+	var stars []struct {
+		Rating int
+		Count  int64
+	}
+	if err := r.db.WithContext(ctx).
+		Model(&model.Review{}).
+		Select("rating, COUNT(*) AS count").
+		Where(column+" = ? AND moderation_status <> ?", id, model.ReviewStatusHidden).
+		Group("rating").
+		Scan(&stars).Error; err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	summary := &model.RatingSummary{Stars: map[int]int64{}}
+	for rating := model.MinReviewRating; rating <= model.MaxReviewRating; rating++ {
+		summary.Stars[rating] = 0
+	}
+	var sum int64
+	for _, star := range stars {
+		summary.Stars[star.Rating] = star.Count
+		summary.Count += star.Count
+		sum += int64(star.Rating) * star.Count
+	}
+	if summary.Count > 0 {
+		summary.Average = math.Round(float64(sum)/float64(summary.Count)*100) / 100
+	}
+	return summary, nil
+}
+
+// AddFlag stores the flag and counts it on the review in the same transaction, an
+// user flags a review only once
+func (r *postgresReviewRepository) AddFlag(ctx context.Context, flag *model.ReviewFlag, hideThreshold int) (*model.Review, error) {
+	var review model.Review
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&review, flag.ReviewID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New(util.ErrReviewNotFound)
+			}
+			return err
+		}
+
+		if err := tx.Create(flag).Error; err != nil {
+			if strings.Contains(err.Error(), util.SQLSTATE_23505) {
+				return errors.New(util.ErrReviewAlreadyFlagged)
+			}
+			return err
+		}
+
+		review.Flag(hideThreshold, flag.CreatedAt)
+		return tx.Save(&review).Error
+	})
+	if err != nil {
+		if err.Error() != util.ErrReviewNotFound && err.Error() != util.ErrReviewAlreadyFlagged {
+			log.Error(err)
+		}
+		return nil, err
+	}
+	return &review, nil
+}
@@ -2,20 +2,28 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"simcomm-monolith/internal/model"
+	"simcomm-monolith/util"
+	"strings"
+	"time"
 
 	log "github.com/labstack/gommon/log"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ShopRepository interface {
 	Create(ctx context.Context, shop *model.Shop) error
 	Get(ctx context.Context, id int) (*model.Shop, error)
 	GetAll(ctx context.Context) ([]model.Shop, error)
+	GetByUserID(ctx context.Context, userID int) ([]model.Shop, error)
 	Update(ctx context.Context, shop *model.Shop) error
 	Delete(ctx context.Context, id int) error
+	GetStatusHistory(ctx context.Context, id int) ([]model.StatusChange, error)
 
 	ShopProductRepository
+	ShopMemberRepository
 }
 
 type postgresShopRepository struct {
@@ -27,9 +35,21 @@ func NewPostgreShopRepository(db *gorm.DB) *postgresShopRepository {
 	return &postgresShopRepository{db: db}
 }
 
-// Create inserts a new shop into the database
+// Create inserts a new shop into the database along with the membership of its owner
 func (r *postgresShopRepository) Create(ctx context.Context, shop *model.Shop) error {
-	return r.db.WithContext(ctx).Create(shop).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(shop).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&model.ShopMember{
+			ShopID:    shop.ID,
+			UserID:    shop.UserID,
+			Role:      model.ShopRoleOwner,
+			CreatedAt: shop.CreatedAt,
+			UpdatedAt: shop.UpdatedAt,
+		}).Error
+	})
 }
 
 // Get retrieves a shop by ID
@@ -50,6 +70,15 @@ func (r *postgresShopRepository) GetAll(ctx context.Context) ([]model.Shop, erro
 	return shops, nil
 }
 
+// GetByUserID retrieves the shops owned by an user
+func (r *postgresShopRepository) GetByUserID(ctx context.Context, userID int) ([]model.Shop, error) {
+	var shops []model.Shop
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Find(&shops).Error; err != nil {
+		return nil, err
+	}
+	return shops, nil
+}
+
 // Update updates an existing shop
 func (r *postgresShopRepository) Update(ctx context.Context, shop *model.Shop) error {
 	if err := r.db.WithContext(ctx).Save(shop).Error; err != nil {
@@ -58,9 +87,18 @@ func (r *postgresShopRepository) Update(ctx context.Context, shop *model.Shop) e
 	return nil
 }
 
-// Delete removes a shop from the database
+// Delete removes a shop from the database along with its members and invitations
 func (r *postgresShopRepository) Delete(ctx context.Context, id int) error {
-	if err := r.db.WithContext(ctx).Delete(&model.Shop{}, id).Error; err != nil {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("shop_id = ?", id).Delete(&model.ShopMember{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("shop_id = ?", id).Delete(&model.ShopInvitation{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.Shop{}, id).Error
+	})
+	if err != nil {
 		log.Error(err)
 		return err
 	}
@@ -68,20 +106,37 @@ func (r *postgresShopRepository) Delete(ctx context.Context, id int) error {
 }
 
 type ShopProductRepository interface {
-	ShopProductRepositoryCreate(ctx context.Context, shopproduct *model.ShopProduct) error
+	ShopProductRepositoryCreate(ctx context.Context, shopproduct *model.ShopProduct, price *model.ShopProductPrice) error
 	ShopProductRepositoryGet(ctx context.Context, id int) (*model.ShopProduct, error)
 	ShopProductRepositoryGetAll(ctx context.Context) ([]model.ShopProduct, error)
+	ShopProductRepositoryGetByShopID(ctx context.Context, req model.ShopProductListRequest) ([]model.ShopProduct, int64, error)
 	ShopProductRepositoryUpdate(ctx context.Context, shopproduct *model.ShopProduct) error
 	ShopProductRepositoryDelete(ctx context.Context, id int) error
 
 	ShopProductRepositoryCreateTransferProduct(ctx context.Context, tp *model.TransferProduct, sp *model.ShopProduct, q Queue) error
 	ShopProductRepositoryGetTransferProduct(ctx context.Context, id int) (*model.TransferProduct, error)
 	ShopProductRepositoryRevertTransferProduct(ctx context.Context, tp *model.TransferProduct, sp *model.ShopProduct, q Queue) error
+
+	ShopProductRepositoryCreatePrice(ctx context.Context, price *model.ShopProductPrice) error
+	ShopProductRepositoryGetPrices(ctx context.Context, shopProductID int) ([]model.ShopProductPrice, error)
+	ShopProductRepositoryGetCurrentPrice(ctx context.Context, shopProductID int, at time.Time) (*model.ShopProductPrice, error)
+	ShopProductRepositoryDeleteScheduledPrice(ctx context.Context, shopProductID int, priceID int) error
+	ShopProductRepositoryApplyDuePrices(ctx context.Context, at time.Time) ([]int, error)
+	ShopProductRepositoryGetStatusHistory(ctx context.Context, id int) ([]model.StatusChange, error)
+
+	ShopProductRepositoryGetStorefront(ctx context.Context, req model.StorefrontRequest) ([]model.StorefrontListing, int64, error)
 }
 
-// Create inserts a new shopproduct into the database
-func (r *postgresShopRepository) ShopProductRepositoryCreate(ctx context.Context, shopproduct *model.ShopProduct) error {
-	return r.db.WithContext(ctx).Create(shopproduct).Error
+// Create inserts a new shopproduct into the database along with its initial price history
+func (r *postgresShopRepository) ShopProductRepositoryCreate(ctx context.Context, shopproduct *model.ShopProduct, price *model.ShopProductPrice) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(shopproduct).Error; err != nil {
+			return err
+		}
+
+		price.ShopProductID = shopproduct.ID
+		return tx.Create(price).Error
+	})
 }
 
 // Get retrieves a shopproduct by ID
@@ -102,6 +157,53 @@ func (r *postgresShopRepository) ShopProductRepositoryGetAll(ctx context.Context
 	return shopproducts, nil
 }
 
+// shopProductListQuery selects the shop products of a shop matching the filters
+func (r *postgresShopRepository) shopProductListQuery(ctx context.Context, req model.ShopProductListRequest) *gorm.DB {
+	query := r.db.WithContext(ctx).
+		Model(&model.ShopProduct{}).
+		Where("shop_id = ?", req.ShopID)
+
+	if req.Status != "" {
+		query = query.Where("status = ?", req.Status)
+	}
+	if req.ProductID != nil {
+		query = query.Where("product_id = ?", *req.ProductID)
+	}
+	if req.VariantID != nil {
+		query = query.Where("variant_id = ?", *req.VariantID)
+	}
+	if req.InStock != nil {
+		if *req.InStock {
+			query = query.Where("stock > 0")
+		} else {
+			query = query.Where("stock <= 0")
+		}
+	}
+	return query
+}
+
+// ShopProductRepositoryGetByShopID retrieves a page of the shop products of a shop,
+// newest first, and the number of shop products matching the request
+func (r *postgresShopRepository) ShopProductRepositoryGetByShopID(ctx context.Context, req model.ShopProductListRequest) ([]model.ShopProduct, int64, error) {
+	var total int64
+	if err := r.shopProductListQuery(ctx, req).Count(&total).Error; err != nil {
+		log.Error(err)
+		return nil, 0, err
+	}
+
+	shopproducts := []model.ShopProduct{}
+	if err := r.shopProductListQuery(ctx, req).
+		Order("created_at DESC").
+		Order("id ASC").
+		Offset(req.Offset()).
+		Limit(req.Limit).
+		Find(&shopproducts).Error; err != nil {
+		log.Error(err)
+		return nil, 0, err
+	}
+	return shopproducts, total, nil
+}
+
 // Update updates an existing shopproduct
 func (r *postgresShopRepository) ShopProductRepositoryUpdate(ctx context.Context, shopproduct *model.ShopProduct) error {
 	if err := r.db.WithContext(ctx).Save(shopproduct).Error; err != nil {
@@ -179,3 +281,420 @@ func (r *postgresShopRepository) ShopProductRepositoryGetTransferProduct(
 
 	return &tp, nil
 }
+
+// ShopProductRepositoryCreatePrice records a price change, a change that is
+// already applied also updates the current price of the shop product
+func (r *postgresShopRepository) ShopProductRepositoryCreatePrice(ctx context.Context, price *model.ShopProductPrice) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(price).Error; err != nil {
+			return err
+		}
+		if price.AppliedAt == nil {
+			return nil
+		}
+		return applyPrice(tx, price)
+	})
+}
+
+// ShopProductRepositoryGetPrices retrieves every price change of a shop product, newest first
+func (r *postgresShopRepository) ShopProductRepositoryGetPrices(ctx context.Context, shopProductID int) ([]model.ShopProductPrice, error) {
+	var prices []model.ShopProductPrice
+	if err := r.db.WithContext(ctx).
+		Where("shop_product_id = ?", shopProductID).
+		Order("effective_at DESC, id DESC").
+		Find(&prices).Error; err != nil {
+		return nil, err
+	}
+	return prices, nil
+}
+
+// ShopProductRepositoryGetCurrentPrice retrieves the price in effect at the given time,
+// it does not depend on the scheduler having applied the change yet
+func (r *postgresShopRepository) ShopProductRepositoryGetCurrentPrice(ctx context.Context, shopProductID int, at time.Time) (*model.ShopProductPrice, error) {
+	var price model.ShopProductPrice
+	if err := r.db.WithContext(ctx).
+		Where("shop_product_id = ? AND effective_at <= ?", shopProductID, at).
+		Order("effective_at DESC, id DESC").
+		First(&price).Error; err != nil {
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(util.ErrPriceNotSet)
+		}
+		return nil, err
+	}
+	return &price, nil
+}
+
+// ShopProductRepositoryDeleteScheduledPrice cancels a price change that is not applied yet
+func (r *postgresShopRepository) ShopProductRepositoryDeleteScheduledPrice(ctx context.Context, shopProductID int, priceID int) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND shop_product_id = ? AND applied_at IS NULL", priceID, shopProductID).
+		Delete(&model.ShopProductPrice{})
+	if result.Error != nil {
+		log.Error(result.Error)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New(util.ErrPriceNotFound)
+	}
+	return nil
+}
+
+// ShopProductRepositoryApplyDuePrices applies every scheduled price change that
+// became effective, oldest first so the latest change wins, and returns the shops
+// whose prices changed. A change older than the price already applied to its shop
+// product is stale, it is marked as applied without overwriting the newer price
+func (r *postgresShopRepository) ShopProductRepositoryApplyDuePrices(ctx context.Context, at time.Time) ([]int, error) {
+	var shopProductIDs []int
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var prices []model.ShopProductPrice
+		if err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("applied_at IS NULL AND effective_at <= ?", at).
+			Order("effective_at ASC, id ASC").
+			Find(&prices).Error; err != nil {
+			return err
+		}
+		if len(prices) == 0 {
+			return nil
+		}
+
+		dueIDs := make([]int, 0, len(prices))
+		for _, price := range prices {
+			dueIDs = append(dueIDs, price.ShopProductID)
+		}
+		var latest []model.ShopProductPrice
+		if err := tx.
+			Model(&model.ShopProductPrice{}).
+			Select("shop_product_id, MAX(effective_at) AS effective_at").
+			Where("applied_at IS NOT NULL AND shop_product_id IN ?", dueIDs).
+			Group("shop_product_id").
+			Find(&latest).Error; err != nil {
+			return err
+		}
+		latestAt := make(map[int]time.Time, len(latest))
+		for _, price := range latest {
+			latestAt[price.ShopProductID] = price.EffectiveAt
+		}
+
+		for i := range prices {
+			price := &prices[i]
+			price.AppliedAt = &at
+			if err := tx.Model(price).Update("applied_at", at).Error; err != nil {
+				return err
+			}
+			if price.EffectiveAt.Before(latestAt[price.ShopProductID]) {
+				continue
+			}
+			latestAt[price.ShopProductID] = price.EffectiveAt
+			if err := applyPrice(tx, price); err != nil {
+				return err
+			}
+			shopProductIDs = append(shopProductIDs, price.ShopProductID)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	if len(shopProductIDs) == 0 {
+		return []int{}, nil
+	}
+
+	var shopIDs []int
+	if err := r.db.WithContext(ctx).
+		Model(&model.ShopProduct{}).
+		Distinct("shop_id").
+		Where("id IN ?", shopProductIDs).
+		Pluck("shop_id", &shopIDs).Error; err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	return shopIDs, nil
+}
+
+func applyPrice(tx *gorm.DB, price *model.ShopProductPrice) error {
+	return tx.Model(&model.ShopProduct{}).
+		Where("id = ?", price.ShopProductID).
+		Updates(map[string]interface{}{
+			"price":      price.Price,
+			"currency":   price.Currency,
+			"updated_at": price.AppliedAt,
+		}).Error
+}
+
+// storefrontQuery selects the active listings of an active product in the shop
+func (r *postgresShopRepository) storefrontQuery(ctx context.Context, shopID int) *gorm.DB {
+	return r.db.WithContext(ctx).
+		Table("shop_products").
+		Joins("JOIN products ON products.id = shop_products.product_id").
+		Where("shop_products.shop_id = ?", shopID).
+		Where("shop_products.status = ? AND products.status = ?", model.StatusActive, model.StatusActive)
+}
+
+// storefrontSellableStock sums the stock of a listing stored in the active
+// warehouses of its shop
+const storefrontSellableStock = `COALESCE((SELECT SUM(warehouse_stored_products.stock) FROM warehouse_stored_products ` +
+	`JOIN warehouses ON warehouses.id = warehouse_stored_products.warehouse_id ` +
+	`WHERE warehouse_stored_products.shop_product_id = shop_products.id ` +
+	`AND warehouses.shop_id = shop_products.shop_id AND warehouses.status = 'active'), 0)`
+
+// ShopProductRepositoryGetStorefront retrieves a page of the active listings of a
+// shop, newest first, with the products and variants they sell
+func (r *postgresShopRepository) ShopProductRepositoryGetStorefront(ctx context.Context, req model.StorefrontRequest) ([]model.StorefrontListing, int64, error) {
+	var total int64
+	if err := r.storefrontQuery(ctx, req.ShopID).Count(&total).Error; err != nil {
+		log.Error(err)
+		return nil, 0, err
+	}
+
+	var rows []struct {
+		ID            int
+		SellableStock int
+	}
+	if err := r.storefrontQuery(ctx, req.ShopID).
+		Select("shop_products.id, " + storefrontSellableStock + " AS sellable_stock").
+		Order("shop_products.created_at DESC, shop_products.id ASC").
+		Offset(req.Offset()).
+		Limit(req.Limit).
+		Scan(&rows).Error; err != nil {
+		log.Error(err)
+		return nil, 0, err
+	}
+
+	listings := make([]model.StorefrontListing, 0, len(rows))
+	if len(rows) == 0 {
+		return listings, total, nil
+	}
+
+	shopProductIDs := make([]int, 0, len(rows))
+	for _, row := range rows {
+		shopProductIDs = append(shopProductIDs, row.ID)
+	}
+	var shopProducts []model.ShopProduct
+	if err := r.db.WithContext(ctx).Where("id IN ?", shopProductIDs).Find(&shopProducts).Error; err != nil {
+		log.Error(err)
+		return nil, 0, err
+	}
+
+	productIDs := make([]int, 0, len(shopProducts))
+	variantIDs := make([]int, 0, len(shopProducts))
+	shopProductByID := make(map[int]model.ShopProduct, len(shopProducts))
+	for _, shopProduct := range shopProducts {
+		shopProductByID[shopProduct.ID] = shopProduct
+		productIDs = append(productIDs, shopProduct.ProductID)
+		if shopProduct.VariantID != nil {
+			variantIDs = append(variantIDs, *shopProduct.VariantID)
+		}
+	}
+
+	var products []model.Product
+	if err := r.db.WithContext(ctx).Where("id IN ?", productIDs).Find(&products).Error; err != nil {
+		log.Error(err)
+		return nil, 0, err
+	}
+	productByID := make(map[int]model.Product, len(products))
+	for _, product := range products {
+		productByID[product.ID] = product
+	}
+
+	variantByID := make(map[int]model.ProductVariant)
+	if len(variantIDs) > 0 {
+		var variants []model.ProductVariant
+		if err := r.db.WithContext(ctx).Where("id IN ?", variantIDs).Find(&variants).Error; err != nil {
+			log.Error(err)
+			return nil, 0, err
+		}
+		for _, variant := range variants {
+			variantByID[variant.ID] = variant
+		}
+	}
+
+	for _, row := range rows {
+		shopProduct := shopProductByID[row.ID]
+		listing := model.StorefrontListing{
+			ShopProduct:   shopProduct,
+			Product:       productByID[shopProduct.ProductID],
+			SellableStock: row.SellableStock,
+		}
+		if shopProduct.VariantID != nil {
+			if variant, ok := variantByID[*shopProduct.VariantID]; ok {
+				variant.Stock = row.SellableStock
+				listing.Variant = &variant
+			}
+		}
+		listings = append(listings, listing)
+	}
+	return listings, total, nil
+}
+
+// GetStatusHistory retrieves the status changes of a shop
+func (r *postgresShopRepository) GetStatusHistory(ctx context.Context, id int) ([]model.StatusChange, error) {
+	return getStatusHistory(ctx, r.db, model.StatusEntityShop, id)
+}
+
+// ShopProductRepositoryGetStatusHistory retrieves the status changes of a shop product
+func (r *postgresShopRepository) ShopProductRepositoryGetStatusHistory(ctx context.Context, id int) ([]model.StatusChange, error) {
+	return getStatusHistory(ctx, r.db, model.StatusEntityShopProduct, id)
+}
+
+type ShopMemberRepository interface {
+	ShopMemberRepositoryGet(ctx context.Context, shopID int, userID int) (*model.ShopMember, error)
+	ShopMemberRepositoryGetByShopID(ctx context.Context, shopID int) ([]model.ShopMember, error)
+	ShopMemberRepositoryUpdate(ctx context.Context, member *model.ShopMember) error
+	ShopMemberRepositoryDelete(ctx context.Context, shopID int, userID int) error
+
+	ShopMemberRepositoryCreateInvitation(ctx context.Context, invitation *model.ShopInvitation) error
+	ShopMemberRepositoryGetInvitation(ctx context.Context, shopID int, id int) (*model.ShopInvitation, error)
+	ShopMemberRepositoryGetInvitationByTokenHash(ctx context.Context, tokenHash string) (*model.ShopInvitation, error)
+	ShopMemberRepositoryGetPendingInvitations(ctx context.Context, shopID int) ([]model.ShopInvitation, error)
+	ShopMemberRepositoryUpdateInvitation(ctx context.Context, invitation *model.ShopInvitation) error
+	ShopMemberRepositoryAcceptInvitation(ctx context.Context, invitation *model.ShopInvitation, member *model.ShopMember) error
+}
+
+// ShopMemberRepositoryGet retrieves the membership of an user in a shop
+func (r *postgresShopRepository) ShopMemberRepositoryGet(ctx context.Context, shopID int, userID int) (*model.ShopMember, error) {
+	var member model.ShopMember
+	if err := r.db.WithContext(ctx).
+		Where("shop_id = ? AND user_id = ?", shopID, userID).
+		First(&member).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(util.ErrShopMemberNotFound)
+		}
+		log.Error(err)
+		return nil, err
+	}
+	return &member, nil
+}
+
+// ShopMemberRepositoryGetByShopID retrieves the members of a shop, the owner first
+func (r *postgresShopRepository) ShopMemberRepositoryGetByShopID(ctx context.Context, shopID int) ([]model.ShopMember, error) {
+	members := []model.ShopMember{}
+	if err := r.db.WithContext(ctx).
+		Where("shop_id = ?", shopID).
+		Order("role = 'owner' DESC").
+		Order("created_at ASC").
+		Find(&members).Error; err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	return members, nil
+}
+
+// ShopMemberRepositoryUpdate updates the role of a member
+func (r *postgresShopRepository) ShopMemberRepositoryUpdate(ctx context.Context, member *model.ShopMember) error {
+	if err := r.db.WithContext(ctx).Save(member).Error; err != nil {
+		log.Error(err)
+		return err
+	}
+	return nil
+}
+
+// ShopMemberRepositoryDelete removes an user from a shop
+func (r *postgresShopRepository) ShopMemberRepositoryDelete(ctx context.Context, shopID int, userID int) error {
+	result := r.db.WithContext(ctx).
+		Where("shop_id = ? AND user_id = ?", shopID, userID).
+		Delete(&model.ShopMember{})
+	if result.Error != nil {
+		log.Error(result.Error)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New(util.ErrShopMemberNotFound)
+	}
+	return nil
+}
+
+// ShopMemberRepositoryCreateInvitation inserts a new invitation into the database
+func (r *postgresShopRepository) ShopMemberRepositoryCreateInvitation(ctx context.Context, invitation *model.ShopInvitation) error {
+	if err := r.db.WithContext(ctx).Create(invitation).Error; err != nil {
+		log.Error(err)
+		return err
+	}
+	return nil
+}
+
+// ShopMemberRepositoryGetInvitation retrieves an invitation of a shop by ID
+func (r *postgresShopRepository) ShopMemberRepositoryGetInvitation(ctx context.Context, shopID int, id int) (*model.ShopInvitation, error) {
+	var invitation model.ShopInvitation
+	if err := r.db.WithContext(ctx).
+		Where("shop_id = ?", shopID).
+		First(&invitation, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(util.ErrShopInvitationNotFound)
+		}
+		log.Error(err)
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// ShopMemberRepositoryGetInvitationByTokenHash retrieves the invitation sent with a token
+func (r *postgresShopRepository) ShopMemberRepositoryGetInvitationByTokenHash(ctx context.Context, tokenHash string) (*model.ShopInvitation, error) {
+	var invitation model.ShopInvitation
+	if err := r.db.WithContext(ctx).
+		Where("token_hash = ?", tokenHash).
+		First(&invitation).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(util.ErrInvalidShopInvitation)
+		}
+		log.Error(err)
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// ShopMemberRepositoryGetPendingInvitations retrieves the invitations of a shop that
+// are not accepted or revoked yet, expired invitations included
+func (r *postgresShopRepository) ShopMemberRepositoryGetPendingInvitations(ctx context.Context, shopID int) ([]model.ShopInvitation, error) {
+	invitations := []model.ShopInvitation{}
+	if err := r.db.WithContext(ctx).
+		Where("shop_id = ? AND status = ?", shopID, model.ShopInvitationPending).
+		Order("created_at DESC").
+		Find(&invitations).Error; err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	return invitations, nil
+}
+
+// ShopMemberRepositoryUpdateInvitation updates an existing invitation
+func (r *postgresShopRepository) ShopMemberRepositoryUpdateInvitation(ctx context.Context, invitation *model.ShopInvitation) error {
+	if err := r.db.WithContext(ctx).Save(invitation).Error; err != nil {
+		log.Error(err)
+		return err
+	}
+	return nil
+}
+
+// ShopMemberRepositoryAcceptInvitation marks the invitation accepted and adds the member
+// in the same transaction, an invitation that is no longer pending is rejected
+func (r *postgresShopRepository) ShopMemberRepositoryAcceptInvitation(ctx context.Context, invitation *model.ShopInvitation, member *model.ShopMember) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.ShopInvitation{}).
+			Where("id = ? AND status = ?", invitation.ID, model.ShopInvitationPending).
+			Updates(map[string]interface{}{
+				"status":      invitation.Status,
+				"accepted_by": invitation.AcceptedBy,
+				"accepted_at": invitation.AcceptedAt,
+				"updated_at":  invitation.UpdatedAt,
+			})
+		if result.Error != nil {
+			log.Error(result.Error)
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New(util.ErrInvalidShopInvitation)
+		}
+
+		if err := tx.Create(member).Error; err != nil {
+			if strings.Contains(err.Error(), util.SQLSTATE_23505) {
+				return errors.New(util.ErrShopMemberAlreadyExists)
+			}
+			log.Error(err)
+			return err
+		}
+		return nil
+	})
+}
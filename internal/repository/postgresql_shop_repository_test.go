@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"simcomm-monolith/internal/model"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShopProductRepositoryGetStorefront(t *testing.T) {
+	db, mock := newMockDB(t)
+	repo := NewPostgreShopRepository(db)
+
+	activeListings := regexp.QuoteMeta(`FROM "shop_products" JOIN products ON products.id = shop_products.product_id `) +
+		`WHERE shop_products\.shop_id = \$1 AND \(shop_products\.status = \$2 AND products\.status = \$3\)`
+	active := model.StatusActive
+
+	mock.ExpectQuery(`SELECT count\(\*\) `+activeListings).
+		WithArgs(7, active, active).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(21))
+	mock.ExpectQuery(`SELECT shop_products\.id, COALESCE\(\(SELECT SUM\(warehouse_stored_products\.stock\).*warehouses\.status = 'active'\), 0\) AS sellable_stock `+
+		activeListings+` ORDER BY shop_products\.created_at DESC, shop_products\.id ASC LIMIT \$4 OFFSET \$5`).
+		WithArgs(7, active, active, 20, 20).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "sellable_stock"}).AddRow(3, 5))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "shop_products" WHERE id IN ($1)`)).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "shop_id", "product_id", "variant_id", "status"}).AddRow(3, 7, 10, 4, active))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE id IN ($1)`)).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "status"}).AddRow(10, "Runner", active))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "product_variants" WHERE id IN ($1)`)).
+		WithArgs(4).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "sku", "stock"}).AddRow(4, 10, "RUN-42", 99))
+
+	listings, total, err := repo.ShopProductRepositoryGetStorefront(context.Background(), model.StorefrontRequest{ShopID: 7, Page: 2, Limit: 20})
+	require.NoError(t, err)
+	assert.Equal(t, int64(21), total)
+	require.Len(t, listings, 1)
+	assert.Equal(t, "Runner", listings[0].Product.Name)
+	assert.Equal(t, 5, listings[0].SellableStock)
+	require.NotNil(t, listings[0].Variant)
+	assert.Equal(t, 5, listings[0].Variant.Stock, "the variant shows the sellable stock")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestShopProductRepositoryApplyDuePrices(t *testing.T) {
+	db, mock := newMockDB(t)
+	repo := NewPostgreShopRepository(db)
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "shop_product_prices" WHERE applied_at IS NULL AND effective_at <= $1 ORDER BY effective_at ASC, id ASC FOR UPDATE SKIP LOCKED`)).
+		WithArgs(at).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "shop_product_id", "price", "currency", "effective_at"}).
+			AddRow(1, 3, 10000, "IDR", at.Add(-2*time.Hour)).
+			AddRow(2, 4, 20000, "IDR", at.Add(-time.Hour)))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT shop_product_id, MAX(effective_at) AS effective_at FROM "shop_product_prices" WHERE applied_at IS NOT NULL AND shop_product_id IN ($1,$2) GROUP BY "shop_product_id"`)).
+		WithArgs(3, 4).
+		WillReturnRows(sqlmock.NewRows([]string{"shop_product_id", "effective_at"}).AddRow(3, at.Add(-time.Hour)))
+	// the change of shop product 3 is older than its applied price and only marked
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "shop_product_prices" SET "applied_at"=$1 WHERE "id" = $2`)).
+		WithArgs(at, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "shop_product_prices" SET "applied_at"=$1 WHERE "id" = $2`)).
+		WithArgs(at, 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "shop_products" SET "currency"=$1,"price"=$2,"updated_at"=$3 WHERE id = $4`)).
+		WithArgs("IDR", 20000, at, 4).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT DISTINCT "shop_id" FROM "shop_products" WHERE id IN ($1)`)).
+		WithArgs(4).
+		WillReturnRows(sqlmock.NewRows([]string{"shop_id"}).AddRow(8))
+
+	shopIDs, err := repo.ShopProductRepositoryApplyDuePrices(context.Background(), at)
+	require.NoError(t, err)
+	assert.Equal(t, []int{8}, shopIDs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"simcomm-monolith/internal/model"
+
+	log "github.com/labstack/gommon/log"
+	"gorm.io/gorm"
+)
+
+// getStatusHistory retrieves the status changes of an entity, oldest first. The
+// changes are written by the AfterSave hook of the entity
+func getStatusHistory(ctx context.Context, db *gorm.DB, entityType string, entityID int) ([]model.StatusChange, error) {
+	var changes []model.StatusChange
+	if err := db.WithContext(ctx).
+		Where("entity_type = ? AND entity_id = ?", entityType, entityID).
+		Order("changed_at ASC, id ASC").
+		Find(&changes).Error; err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	return changes, nil
+}
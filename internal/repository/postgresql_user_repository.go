@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"simcomm-monolith/internal/model"
 	"simcomm-monolith/util"
@@ -19,6 +20,8 @@ type UserRepository interface {
 	Delete(ctx context.Context, id int) error
 
 	GetByIdentifier(ctx context.Context, identifier string) (*model.User, error)
+	GetByIdentity(ctx context.Context, identity model.Identity) (*model.User, error)
+	Anonymize(ctx context.Context, user *model.User) error
 }
 
 type postgresUserRepository struct {
@@ -89,6 +92,11 @@ func (r *postgresUserRepository) Delete(ctx context.Context, id int) error {
 }
 
 func (r *postgresUserRepository) GetByIdentifier(ctx context.Context, identifier string) (*model.User, error) {
+	// users without a phone are stored with an empty one and must not match an empty identifier
+	if identifier == "" {
+		return nil, errors.New(util.ErrUserNotFound)
+	}
+
 	var user model.User
 	if err := r.db.WithContext(ctx).
 		Where("email = ? OR phone = ?", identifier, identifier).
@@ -103,3 +111,56 @@ func (r *postgresUserRepository) GetByIdentifier(ctx context.Context, identifier
 	}
 	return &user, nil
 }
+
+// GetByIdentity retrieves the user linked to an external identity provider subject
+func (r *postgresUserRepository) GetByIdentity(ctx context.Context, identity model.Identity) (*model.User, error) {
+	filter, err := json.Marshal([]model.Identity{identity})
+	if err != nil {
+		return nil, err
+	}
+
+	var user model.User
+	if err := r.db.WithContext(ctx).
+		Where("detail->'identities' @> ?::jsonb", string(filter)).
+		First(&user).Error; err != nil {
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(util.ErrUserNotFound)
+		}
+
+		log.Error(err)
+		return nil, err
+	}
+	return &user, nil
+}
+
+// Anonymize stores the anonymized user, strips the personal data kept in its
+// orders and soft deletes it in a single transaction
+func (r *postgresUserRepository) Anonymize(ctx context.Context, user *model.User) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(user).Error; err != nil {
+			return err
+		}
+
+		var orders []model.Order
+		if err := tx.Where("user_id = ?", user.ID).Find(&orders).Error; err != nil {
+			return err
+		}
+		for i := range orders {
+			orders[i].Detail.UserDetail = model.UserDetail{}
+			if orders[i].Detail.ShippingAddress != nil {
+				orders[i].Detail.ShippingAddress.Anonymize()
+			}
+			if err := tx.Model(&orders[i]).Update("detail", orders[i].Detail).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Delete(user).Error
+	})
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"simcomm-monolith/internal/model"
 	"simcomm-monolith/util"
+	"strings"
 
 	log "github.com/labstack/gommon/log"
 	"gorm.io/gorm"
@@ -14,8 +15,10 @@ type WarehouseRepository interface {
 	Create(ctx context.Context, warehouse *model.Warehouse) error
 	Get(ctx context.Context, id int) (*model.Warehouse, error)
 	GetAll(ctx context.Context) ([]model.Warehouse, error)
+	GetByShopID(ctx context.Context, req model.WarehouseListRequest) ([]model.Warehouse, int64, error)
 	Update(ctx context.Context, warehouse *model.Warehouse) error
 	Delete(ctx context.Context, id int) error
+	GetStatusHistory(ctx context.Context, id int) ([]model.StatusChange, error)
 
 	WarehouseStoredProductRepository
 }
@@ -52,6 +55,51 @@ func (r *postgresWarehouseRepository) GetAll(ctx context.Context) ([]model.Wareh
 	return warehouses, nil
 }
 
+// likeEscaper escapes the LIKE wildcards of a search so they match literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func escapeLike(value string) string {
+	return likeEscaper.Replace(value)
+}
+
+// warehouseListQuery selects the warehouses of a shop matching the filters
+func (r *postgresWarehouseRepository) warehouseListQuery(ctx context.Context, req model.WarehouseListRequest) *gorm.DB {
+	query := r.db.WithContext(ctx).
+		Model(&model.Warehouse{}).
+		Where("shop_id = ?", req.ShopID)
+
+	if req.Status != "" {
+		query = query.Where("status = ?", req.Status)
+	}
+	if req.Query != "" {
+		pattern := "%" + escapeLike(req.Query) + "%"
+		query = query.Where("(name ILIKE ? OR location ILIKE ?)", pattern, pattern)
+	}
+	return query
+}
+
+// GetByShopID retrieves a page of the warehouses of a shop ordered by name and the
+// number of warehouses matching the request
+func (r *postgresWarehouseRepository) GetByShopID(ctx context.Context, req model.WarehouseListRequest) ([]model.Warehouse, int64, error) {
+	var total int64
+	if err := r.warehouseListQuery(ctx, req).Count(&total).Error; err != nil {
+		log.Error(err)
+		return nil, 0, err
+	}
+
+	warehouses := []model.Warehouse{}
+	if err := r.warehouseListQuery(ctx, req).
+		Order("name ASC").
+		Order("id ASC").
+		Offset(req.Offset()).
+		Limit(req.Limit).
+		Find(&warehouses).Error; err != nil {
+		log.Error(err)
+		return nil, 0, err
+	}
+	return warehouses, total, nil
+}
+
 // Update updates an existing warehouse
 func (r *postgresWarehouseRepository) Update(ctx context.Context, warehouse *model.Warehouse) error {
 	if err := r.db.WithContext(ctx).Save(warehouse).Error; err != nil {
@@ -73,6 +121,7 @@ type WarehouseStoredProductRepository interface {
 	WSPCreate(ctx context.Context, warehousestoredproduct *model.WarehouseStoredProduct) error
 	WSPGet(ctx context.Context, id int) (*model.WarehouseStoredProduct, error)
 	WSPGetAll(ctx context.Context) ([]model.WarehouseStoredProduct, error)
+	WSPGetByWarehouseID(ctx context.Context, req model.StoredProductListRequest) ([]model.WarehouseStoredProduct, int64, error)
 	WSPUpdate(ctx context.Context, warehousestoredproduct *model.WarehouseStoredProduct) error
 	WSPDelete(ctx context.Context, id int) error
 
@@ -80,9 +129,21 @@ type WarehouseStoredProductRepository interface {
 	WSPSubstractStock(ctx context.Context, warehousestoredproduct *model.WarehouseStoredProduct, subtrahend int) error
 }
 
-// Create inserts a new warehousestoredproduct into the database
+// Create inserts a new warehousestoredproduct into the database, the variant is
+// copied from the shop product so stock can be counted per variant
 func (r *postgresWarehouseRepository) WSPCreate(ctx context.Context, warehousestoredproduct *model.WarehouseStoredProduct) error {
-	return r.db.WithContext(ctx).Create(warehousestoredproduct).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var shopProduct model.ShopProduct
+		if err := tx.Select("variant_id").First(&shopProduct, warehousestoredproduct.ShopProductID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New(util.ErrShopProductNotFound)
+			}
+			return err
+		}
+
+		warehousestoredproduct.VariantID = shopProduct.VariantID
+		return tx.Create(warehousestoredproduct).Error
+	})
 }
 
 // Get retrieves a warehousestoredproduct by ID
@@ -103,6 +164,49 @@ func (r *postgresWarehouseRepository) WSPGetAll(ctx context.Context) ([]model.Wa
 	return warehousestoredproducts, nil
 }
 
+// storedProductListQuery selects the stored products of a warehouse matching the filters
+func (r *postgresWarehouseRepository) storedProductListQuery(ctx context.Context, req model.StoredProductListRequest) *gorm.DB {
+	query := r.db.WithContext(ctx).
+		Model(&model.WarehouseStoredProduct{}).
+		Where("warehouse_id = ?", req.WarehouseID)
+
+	if req.ShopProductID != nil {
+		query = query.Where("shop_product_id = ?", *req.ShopProductID)
+	}
+	if req.VariantID != nil {
+		query = query.Where("variant_id = ?", *req.VariantID)
+	}
+	if req.InStock != nil {
+		if *req.InStock {
+			query = query.Where("stock > 0")
+		} else {
+			query = query.Where("stock <= 0")
+		}
+	}
+	return query
+}
+
+// WSPGetByWarehouseID retrieves a page of the products stored in a warehouse and the
+// number of stored products matching the request
+func (r *postgresWarehouseRepository) WSPGetByWarehouseID(ctx context.Context, req model.StoredProductListRequest) ([]model.WarehouseStoredProduct, int64, error) {
+	var total int64
+	if err := r.storedProductListQuery(ctx, req).Count(&total).Error; err != nil {
+		log.Error(err)
+		return nil, 0, err
+	}
+
+	warehousestoredproducts := []model.WarehouseStoredProduct{}
+	if err := r.storedProductListQuery(ctx, req).
+		Order("id ASC").
+		Offset(req.Offset()).
+		Limit(req.Limit).
+		Find(&warehousestoredproducts).Error; err != nil {
+		log.Error(err)
+		return nil, 0, err
+	}
+	return warehousestoredproducts, total, nil
+}
+
 // Update updates an existing warehousestoredproduct
 func (r *postgresWarehouseRepository) WSPUpdate(ctx context.Context, warehousestoredproduct *model.WarehouseStoredProduct) error {
 	if err := r.db.WithContext(ctx).Save(warehousestoredproduct).Error; err != nil {
@@ -145,3 +249,8 @@ func (r *postgresWarehouseRepository) WSPGetByShopProductID(ctx context.Context,
 	}
 	return &warehousestoredproduct, nil
 }
+
+// GetStatusHistory retrieves the status changes of a warehouse
+func (r *postgresWarehouseRepository) GetStatusHistory(ctx context.Context, id int) ([]model.StatusChange, error) {
+	return getStatusHistory(ctx, r.db, model.StatusEntityWarehouse, id)
+}
@@ -2,14 +2,80 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"simcomm-monolith/config"
+	"simcomm-monolith/internal/model"
+	"time"
 
 	"github.com/redis/go-redis/v9"
 )
 
+const sessionKeyFormat = "session:%v"
+const userSessionsKeyFormat = "user_sessions:%v"
+const refreshTokenKeyFormat = "refresh_token:%v"
+const refreshTokenUsedKeyFormat = "refresh_token_used:%v"
+const tokenFamilyKeyFormat = "token_family:%v"
+const userTokenFamiliesKeyFormat = "user_token_families:%v"
+const loginAttemptKeyFormat = "login_attempt:%v"
+const loginBackoffKeyFormat = "login_backoff:%v"
+const accountLockKeyFormat = "account_lock:%v"
+const verificationCodeKeyFormat = "verification_code:%v:%v"
+const verificationAttemptKeyFormat = "verification_attempt:%v:%v"
+const verificationCooldownKeyFormat = "verification_cooldown:%v:%v"
+const passwordResetKeyFormat = "password_reset:%v"
+const oidcStateKeyFormat = "oidc_state:%v"
+const loginChallengeKeyFormat = "login_challenge:%v"
+const loginChallengeAttemptKeyFormat = "login_challenge_attempt:%v"
+const storefrontKeyFormat = "storefront:%v:%v:%v"
+const shopStorefrontsKeyFormat = "shop_storefronts:%v"
+
 type RedisRepository interface {
 	StoreToken(ctx context.Context, key string, token string) error
 	GetToken(ctx context.Context, key string) (string, error)
+
+	StoreSession(ctx context.Context, session model.Session) error
+	GetSession(ctx context.Context, tokenID string) (*model.Session, error)
+	DeleteSession(ctx context.Context, tokenID string) error
+	DeleteUserSessions(ctx context.Context, userID int) error
+
+	StoreRefreshToken(ctx context.Context, refreshToken model.RefreshToken) error
+	GetRefreshToken(ctx context.Context, tokenHash string) (*model.RefreshToken, error)
+	MarkRefreshTokenUsed(ctx context.Context, refreshToken model.RefreshToken) (bool, error)
+	StoreTokenFamily(ctx context.Context, family model.TokenFamily) error
+	GetTokenFamily(ctx context.Context, familyID string) (*model.TokenFamily, error)
+	DeleteTokenFamily(ctx context.Context, familyID string) error
+
+	IncrLoginAttempt(ctx context.Context, key string, window time.Duration) (int64, error)
+	ResetLoginAttempt(ctx context.Context, key string) error
+	SetLoginBackoff(ctx context.Context, key string, backoff time.Duration) error
+	GetLoginBackoff(ctx context.Context, key string) (time.Duration, error)
+	LockAccount(ctx context.Context, userID int, duration time.Duration) error
+	IsAccountLocked(ctx context.Context, userID int) (bool, error)
+	UnlockAccount(ctx context.Context, userID int) error
+
+	StoreVerificationCode(ctx context.Context, code model.VerificationCode) error
+	GetVerificationCode(ctx context.Context, channel string, userID int) (*model.VerificationCode, error)
+	DeleteVerificationCode(ctx context.Context, channel string, userID int) error
+	IncrVerificationAttempt(ctx context.Context, channel string, userID int, window time.Duration) (int64, error)
+	GetVerificationAttempts(ctx context.Context, channel string, userID int) (int64, error)
+	ResetVerificationAttempt(ctx context.Context, channel string, userID int) error
+	AcquireVerificationCooldown(ctx context.Context, channel string, destination string, cooldown time.Duration) (bool, error)
+
+	StorePasswordResetToken(ctx context.Context, tokenHash string, userID int, duration time.Duration) error
+	ConsumePasswordResetToken(ctx context.Context, tokenHash string) (int, error)
+
+	StoreOIDCState(ctx context.Context, state string, oidcState model.OIDCState, duration time.Duration) error
+	ConsumeOIDCState(ctx context.Context, state string) (*model.OIDCState, error)
+
+	StoreLoginChallenge(ctx context.Context, challenge model.LoginChallenge) error
+	GetLoginChallenge(ctx context.Context, tokenHash string) (*model.LoginChallenge, error)
+	IncrLoginChallengeAttempt(ctx context.Context, tokenHash string, window time.Duration) (int64, error)
+	DeleteLoginChallenge(ctx context.Context, tokenHash string) error
+
+	StoreStorefront(ctx context.Context, storefront model.Storefront, duration time.Duration) error
+	GetStorefront(ctx context.Context, shopID int, page int, limit int) (*model.Storefront, error)
+	DeleteStorefronts(ctx context.Context, shopIDs ...int) error
 }
 
 type redisRepository struct {
@@ -25,9 +91,401 @@ func NewRedisRepository(rc *redis.Client, cfg *config.Config) *redisRepository {
 }
 
 func (ar *redisRepository) StoreToken(ctx context.Context, key string, token string) error {
-	return ar.RC.Set(ctx, key, token, ar.cfg.AuthTokenConfig.Duration).Err()
+	return ar.RC.Set(ctx, key, token, ar.cfg.AuthTokenConfig.Duration*time.Second).Err()
 }
 
 func (ar *redisRepository) GetToken(ctx context.Context, key string) (string, error) {
 	return ar.RC.Get(ctx, key).Result()
 }
+
+// StoreSession stores a session keyed by its token ID until the session expires
+func (ar *redisRepository) StoreSession(ctx context.Context, session model.Session) error {
+	value, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	key := fmt.Sprintf(sessionKeyFormat, session.TokenID)
+	userKey := fmt.Sprintf(userSessionsKeyFormat, session.UserID)
+
+	pipe := ar.RC.TxPipeline()
+	pipe.Set(ctx, key, value, time.Until(session.ExpiredAt))
+	pipe.SAdd(ctx, userKey, session.TokenID)
+	pipe.Expire(ctx, userKey, time.Until(session.ExpiredAt))
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// GetSession retrieves a session by its token ID
+func (ar *redisRepository) GetSession(ctx context.Context, tokenID string) (*model.Session, error) {
+	key := fmt.Sprintf(sessionKeyFormat, tokenID)
+	value, err := ar.RC.Get(ctx, key).Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	var session model.Session
+	if err := json.Unmarshal(value, &session); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// DeleteSession revokes a session by its token ID
+func (ar *redisRepository) DeleteSession(ctx context.Context, tokenID string) error {
+	session, err := ar.GetSession(ctx, tokenID)
+	if err != nil {
+		if err == redis.Nil {
+			return nil
+		}
+		return err
+	}
+
+	pipe := ar.RC.TxPipeline()
+	pipe.Del(ctx, fmt.Sprintf(sessionKeyFormat, tokenID))
+	pipe.SRem(ctx, fmt.Sprintf(userSessionsKeyFormat, session.UserID), tokenID)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// DeleteUserSessions revokes every session and every refresh token family of a user,
+// families are tracked separately since they outlive the sessions
+func (ar *redisRepository) DeleteUserSessions(ctx context.Context, userID int) error {
+	userKey := fmt.Sprintf(userSessionsKeyFormat, userID)
+	tokenIDs, err := ar.RC.SMembers(ctx, userKey).Result()
+	if err != nil {
+		return err
+	}
+	familiesKey := fmt.Sprintf(userTokenFamiliesKeyFormat, userID)
+	familyIDs, err := ar.RC.SMembers(ctx, familiesKey).Result()
+	if err != nil {
+		return err
+	}
+
+	keys := []string{userKey, familiesKey}
+	for _, tokenID := range tokenIDs {
+		session, err := ar.GetSession(ctx, tokenID)
+		if err != nil && err != redis.Nil {
+			return err
+		}
+		if session != nil && session.FamilyID != "" {
+			keys = append(keys, fmt.Sprintf(tokenFamilyKeyFormat, session.FamilyID))
+		}
+		keys = append(keys, fmt.Sprintf(sessionKeyFormat, tokenID))
+	}
+	for _, familyID := range familyIDs {
+		keys = append(keys, fmt.Sprintf(tokenFamilyKeyFormat, familyID))
+	}
+	return ar.RC.Del(ctx, keys...).Err()
+}
+
+// StoreRefreshToken stores a refresh token keyed by its hash until it expires
+func (ar *redisRepository) StoreRefreshToken(ctx context.Context, refreshToken model.RefreshToken) error {
+	value, err := json.Marshal(refreshToken)
+	if err != nil {
+		return err
+	}
+	key := fmt.Sprintf(refreshTokenKeyFormat, refreshToken.TokenHash)
+	return ar.RC.Set(ctx, key, value, time.Until(refreshToken.ExpiredAt)).Err()
+}
+
+// GetRefreshToken retrieves a refresh token by its hash
+func (ar *redisRepository) GetRefreshToken(ctx context.Context, tokenHash string) (*model.RefreshToken, error) {
+	key := fmt.Sprintf(refreshTokenKeyFormat, tokenHash)
+	value, err := ar.RC.Get(ctx, key).Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	var refreshToken model.RefreshToken
+	if err := json.Unmarshal(value, &refreshToken); err != nil {
+		return nil, err
+	}
+	return &refreshToken, nil
+}
+
+// MarkRefreshTokenUsed atomically flags a refresh token as used,
+// it returns false when the token has already been used before
+func (ar *redisRepository) MarkRefreshTokenUsed(ctx context.Context, refreshToken model.RefreshToken) (bool, error) {
+	key := fmt.Sprintf(refreshTokenUsedKeyFormat, refreshToken.TokenHash)
+	return ar.RC.SetNX(ctx, key, refreshToken.FamilyID, time.Until(refreshToken.ExpiredAt)).Result()
+}
+
+// StoreTokenFamily stores a refresh token family until it expires, the family is
+// tracked per user until then so DeleteUserSessions can revoke it
+func (ar *redisRepository) StoreTokenFamily(ctx context.Context, family model.TokenFamily) error {
+	value, err := json.Marshal(family)
+	if err != nil {
+		return err
+	}
+	key := fmt.Sprintf(tokenFamilyKeyFormat, family.ID)
+	userKey := fmt.Sprintf(userTokenFamiliesKeyFormat, family.UserID)
+
+	pipe := ar.RC.TxPipeline()
+	pipe.Set(ctx, key, value, time.Until(family.ExpiredAt))
+	pipe.SAdd(ctx, userKey, family.ID)
+	pipe.Expire(ctx, userKey, time.Until(family.ExpiredAt))
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// GetTokenFamily retrieves a refresh token family by its ID
+func (ar *redisRepository) GetTokenFamily(ctx context.Context, familyID string) (*model.TokenFamily, error) {
+	key := fmt.Sprintf(tokenFamilyKeyFormat, familyID)
+	value, err := ar.RC.Get(ctx, key).Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	var family model.TokenFamily
+	if err := json.Unmarshal(value, &family); err != nil {
+		return nil, err
+	}
+	return &family, nil
+}
+
+// DeleteTokenFamily revokes a refresh token family along with its current session
+func (ar *redisRepository) DeleteTokenFamily(ctx context.Context, familyID string) error {
+	family, err := ar.GetTokenFamily(ctx, familyID)
+	if err != nil {
+		if err == redis.Nil {
+			return nil
+		}
+		return err
+	}
+
+	if err := ar.DeleteSession(ctx, family.TokenID); err != nil {
+		return err
+	}
+
+	pipe := ar.RC.TxPipeline()
+	pipe.Del(ctx, fmt.Sprintf(tokenFamilyKeyFormat, familyID))
+	pipe.SRem(ctx, fmt.Sprintf(userTokenFamiliesKeyFormat, family.UserID), familyID)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// incrWithExpiryScript increments a counter and starts its expiry on the first
+// increment in a single step, so a counter can never be left without an expiry
+var incrWithExpiryScript = redis.NewScript(`
+local count = redis.call("INCR", KEYS[1])
+if count == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return count
+`)
+
+// IncrLoginAttempt counts a failed login within the attempt window
+func (ar *redisRepository) IncrLoginAttempt(ctx context.Context, key string, window time.Duration) (int64, error) {
+	attemptKey := fmt.Sprintf(loginAttemptKeyFormat, key)
+	return incrWithExpiryScript.Run(ctx, ar.RC, []string{attemptKey}, window.Milliseconds()).Int64()
+}
+
+func (ar *redisRepository) ResetLoginAttempt(ctx context.Context, key string) error {
+	return ar.RC.Del(ctx,
+		fmt.Sprintf(loginAttemptKeyFormat, key),
+		fmt.Sprintf(loginBackoffKeyFormat, key),
+	).Err()
+}
+
+func (ar *redisRepository) SetLoginBackoff(ctx context.Context, key string, backoff time.Duration) error {
+	return ar.RC.Set(ctx, fmt.Sprintf(loginBackoffKeyFormat, key), 1, backoff).Err()
+}
+
+// GetLoginBackoff returns the remaining backoff, zero when login is allowed
+func (ar *redisRepository) GetLoginBackoff(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := ar.RC.PTTL(ctx, fmt.Sprintf(loginBackoffKeyFormat, key)).Result()
+	if err != nil {
+		return 0, err
+	}
+	if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
+
+func (ar *redisRepository) LockAccount(ctx context.Context, userID int, duration time.Duration) error {
+	return ar.RC.Set(ctx, fmt.Sprintf(accountLockKeyFormat, userID), 1, duration).Err()
+}
+
+func (ar *redisRepository) IsAccountLocked(ctx context.Context, userID int) (bool, error) {
+	count, err := ar.RC.Exists(ctx, fmt.Sprintf(accountLockKeyFormat, userID)).Result()
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (ar *redisRepository) UnlockAccount(ctx context.Context, userID int) error {
+	return ar.RC.Del(ctx, fmt.Sprintf(accountLockKeyFormat, userID)).Err()
+}
+
+// StoreVerificationCode stores a verification code until it expires, replacing any previous code
+func (ar *redisRepository) StoreVerificationCode(ctx context.Context, code model.VerificationCode) error {
+	value, err := json.Marshal(code)
+	if err != nil {
+		return err
+	}
+	key := fmt.Sprintf(verificationCodeKeyFormat, code.Channel, code.UserID)
+	return ar.RC.Set(ctx, key, value, time.Until(code.ExpiredAt)).Err()
+}
+
+func (ar *redisRepository) GetVerificationCode(ctx context.Context, channel string, userID int) (*model.VerificationCode, error) {
+	key := fmt.Sprintf(verificationCodeKeyFormat, channel, userID)
+	value, err := ar.RC.Get(ctx, key).Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	var code model.VerificationCode
+	if err := json.Unmarshal(value, &code); err != nil {
+		return nil, err
+	}
+	return &code, nil
+}
+
+func (ar *redisRepository) DeleteVerificationCode(ctx context.Context, channel string, userID int) error {
+	return ar.RC.Del(ctx, fmt.Sprintf(verificationCodeKeyFormat, channel, userID)).Err()
+}
+
+// IncrVerificationAttempt counts a wrong verification code within the window, the
+// count is kept apart from the code so sending a new code does not reset it
+func (ar *redisRepository) IncrVerificationAttempt(ctx context.Context, channel string, userID int, window time.Duration) (int64, error) {
+	key := fmt.Sprintf(verificationAttemptKeyFormat, channel, userID)
+	return incrWithExpiryScript.Run(ctx, ar.RC, []string{key}, window.Milliseconds()).Int64()
+}
+
+// GetVerificationAttempts returns the wrong verification codes within the window
+func (ar *redisRepository) GetVerificationAttempts(ctx context.Context, channel string, userID int) (int64, error) {
+	count, err := ar.RC.Get(ctx, fmt.Sprintf(verificationAttemptKeyFormat, channel, userID)).Int64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	return count, err
+}
+
+func (ar *redisRepository) ResetVerificationAttempt(ctx context.Context, channel string, userID int) error {
+	return ar.RC.Del(ctx, fmt.Sprintf(verificationAttemptKeyFormat, channel, userID)).Err()
+}
+
+// AcquireVerificationCooldown starts the cooldown of a destination, it returns false
+// when a code has already been sent to the destination within the cooldown
+func (ar *redisRepository) AcquireVerificationCooldown(ctx context.Context, channel string, destination string, cooldown time.Duration) (bool, error) {
+	key := fmt.Sprintf(verificationCooldownKeyFormat, channel, destination)
+	return ar.RC.SetNX(ctx, key, 1, cooldown).Result()
+}
+
+func (ar *redisRepository) StorePasswordResetToken(ctx context.Context, tokenHash string, userID int, duration time.Duration) error {
+	return ar.RC.Set(ctx, fmt.Sprintf(passwordResetKeyFormat, tokenHash), userID, duration).Err()
+}
+
+// ConsumePasswordResetToken returns the user of a reset token and deletes it so it can only be used once
+func (ar *redisRepository) ConsumePasswordResetToken(ctx context.Context, tokenHash string) (int, error) {
+	return ar.RC.GetDel(ctx, fmt.Sprintf(passwordResetKeyFormat, tokenHash)).Int()
+}
+
+func (ar *redisRepository) StoreOIDCState(ctx context.Context, state string, oidcState model.OIDCState, duration time.Duration) error {
+	value, err := json.Marshal(oidcState)
+	if err != nil {
+		return err
+	}
+	return ar.RC.Set(ctx, fmt.Sprintf(oidcStateKeyFormat, state), value, duration).Err()
+}
+
+// ConsumeOIDCState returns a pending login state and deletes it so it can only be used once
+func (ar *redisRepository) ConsumeOIDCState(ctx context.Context, state string) (*model.OIDCState, error) {
+	value, err := ar.RC.GetDel(ctx, fmt.Sprintf(oidcStateKeyFormat, state)).Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	var oidcState model.OIDCState
+	if err := json.Unmarshal(value, &oidcState); err != nil {
+		return nil, err
+	}
+	return &oidcState, nil
+}
+
+func (ar *redisRepository) StoreLoginChallenge(ctx context.Context, challenge model.LoginChallenge) error {
+	value, err := json.Marshal(challenge)
+	if err != nil {
+		return err
+	}
+	key := fmt.Sprintf(loginChallengeKeyFormat, challenge.TokenHash)
+	return ar.RC.Set(ctx, key, value, time.Until(challenge.ExpiredAt)).Err()
+}
+
+func (ar *redisRepository) GetLoginChallenge(ctx context.Context, tokenHash string) (*model.LoginChallenge, error) {
+	value, err := ar.RC.Get(ctx, fmt.Sprintf(loginChallengeKeyFormat, tokenHash)).Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	var challenge model.LoginChallenge
+	if err := json.Unmarshal(value, &challenge); err != nil {
+		return nil, err
+	}
+	return &challenge, nil
+}
+
+// IncrLoginChallengeAttempt counts a wrong code of a login challenge, the window
+// is the remaining lifetime of the challenge
+func (ar *redisRepository) IncrLoginChallengeAttempt(ctx context.Context, tokenHash string, window time.Duration) (int64, error) {
+	key := fmt.Sprintf(loginChallengeAttemptKeyFormat, tokenHash)
+	return incrWithExpiryScript.Run(ctx, ar.RC, []string{key}, window.Milliseconds()).Int64()
+}
+
+func (ar *redisRepository) DeleteLoginChallenge(ctx context.Context, tokenHash string) error {
+	return ar.RC.Del(ctx,
+		fmt.Sprintf(loginChallengeKeyFormat, tokenHash),
+		fmt.Sprintf(loginChallengeAttemptKeyFormat, tokenHash),
+	).Err()
+}
+
+// StoreStorefront caches a page of a storefront, the pages of a shop are tracked so
+// they are deleted together
+func (ar *redisRepository) StoreStorefront(ctx context.Context, storefront model.Storefront, duration time.Duration) error {
+	value, err := json.Marshal(storefront)
+	if err != nil {
+		return err
+	}
+	key := fmt.Sprintf(storefrontKeyFormat, storefront.Shop.ID, storefront.Page, storefront.Limit)
+	shopKey := fmt.Sprintf(shopStorefrontsKeyFormat, storefront.Shop.ID)
+
+	pipe := ar.RC.TxPipeline()
+	pipe.Set(ctx, key, value, duration)
+	pipe.SAdd(ctx, shopKey, key)
+	pipe.Expire(ctx, shopKey, duration)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+func (ar *redisRepository) GetStorefront(ctx context.Context, shopID int, page int, limit int) (*model.Storefront, error) {
+	value, err := ar.RC.Get(ctx, fmt.Sprintf(storefrontKeyFormat, shopID, page, limit)).Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	var storefront model.Storefront
+	if err := json.Unmarshal(value, &storefront); err != nil {
+		return nil, err
+	}
+	return &storefront, nil
+}
+
+// DeleteStorefronts deletes every cached page of the storefronts of the shops
+func (ar *redisRepository) DeleteStorefronts(ctx context.Context, shopIDs ...int) error {
+	var keys []string
+	for _, shopID := range shopIDs {
+		shopKey := fmt.Sprintf(shopStorefrontsKeyFormat, shopID)
+		pageKeys, err := ar.RC.SMembers(ctx, shopKey).Result()
+		if err != nil {
+			return err
+		}
+		keys = append(keys, shopKey)
+		keys = append(keys, pageKeys...)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	return ar.RC.Del(ctx, keys...).Err()
+}
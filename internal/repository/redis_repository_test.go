@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"simcomm-monolith/config"
+	"simcomm-monolith/internal/model"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRedisRepository(t *testing.T) (*redisRepository, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	rc := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rc.Close() })
+	return NewRedisRepository(rc, &config.Config{}), mr
+}
+
+func TestRedisRepositoryMarkRefreshTokenUsed(t *testing.T) {
+	ctx := context.Background()
+	repo, _ := newTestRedisRepository(t)
+	refreshToken := model.RefreshToken{
+		TokenHash: "hash",
+		FamilyID:  "family",
+		UserID:    1,
+		ExpiredAt: time.Now().Add(time.Hour),
+	}
+
+	firstUse, err := repo.MarkRefreshTokenUsed(ctx, refreshToken)
+	require.NoError(t, err)
+	assert.True(t, firstUse)
+
+	firstUse, err = repo.MarkRefreshTokenUsed(ctx, refreshToken)
+	require.NoError(t, err)
+	assert.False(t, firstUse, "a reused refresh token must be detected")
+}
+
+func TestRedisRepositoryDeleteTokenFamily(t *testing.T) {
+	ctx := context.Background()
+	repo, mr := newTestRedisRepository(t)
+	expiredAt := time.Now().Add(time.Hour)
+
+	require.NoError(t, repo.StoreSession(ctx, model.Session{TokenID: "token", UserID: 1, FamilyID: "family", ExpiredAt: expiredAt}))
+	require.NoError(t, repo.StoreTokenFamily(ctx, model.TokenFamily{ID: "family", UserID: 1, TokenID: "token", ExpiredAt: expiredAt}))
+
+	require.NoError(t, repo.DeleteTokenFamily(ctx, "family"))
+
+	_, err := repo.GetSession(ctx, "token")
+	assert.ErrorIs(t, err, redis.Nil)
+	_, err = repo.GetTokenFamily(ctx, "family")
+	assert.ErrorIs(t, err, redis.Nil)
+	assert.False(t, mr.Exists("user_sessions:1"))
+	assert.False(t, mr.Exists("user_token_families:1"))
+
+	// deleting an unknown family is a no-op
+	assert.NoError(t, repo.DeleteTokenFamily(ctx, "unknown"))
+}
+
+func TestRedisRepositoryDeleteUserSessions(t *testing.T) {
+	ctx := context.Background()
+	repo, mr := newTestRedisRepository(t)
+	sessionExpiredAt := time.Now().Add(15 * time.Minute)
+	familyExpiredAt := time.Now().Add(24 * time.Hour)
+
+	require.NoError(t, repo.StoreSession(ctx, model.Session{TokenID: "token", UserID: 1, FamilyID: "family", ExpiredAt: sessionExpiredAt}))
+	require.NoError(t, repo.StoreTokenFamily(ctx, model.TokenFamily{ID: "family", UserID: 1, TokenID: "token", ExpiredAt: familyExpiredAt}))
+
+	// the session expires long before the refresh token family
+	mr.FastForward(time.Hour)
+	assert.False(t, mr.Exists("user_sessions:1"))
+
+	require.NoError(t, repo.DeleteUserSessions(ctx, 1))
+
+	_, err := repo.GetTokenFamily(ctx, "family")
+	assert.ErrorIs(t, err, redis.Nil, "a family must not outlive the revoked sessions")
+	assert.False(t, mr.Exists("user_token_families:1"))
+}
+
+func TestRedisRepositoryIncrLoginAttempt(t *testing.T) {
+	ctx := context.Background()
+	repo, mr := newTestRedisRepository(t)
+
+	for want := int64(1); want <= 3; want++ {
+		count, err := repo.IncrLoginAttempt(ctx, "ip:203.0.113.7", time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, want, count)
+		assert.Equal(t, time.Minute, mr.TTL("login_attempt:ip:203.0.113.7"), "the window starts with the first attempt")
+	}
+
+	mr.FastForward(time.Minute)
+	count, err := repo.IncrLoginAttempt(ctx, "ip:203.0.113.7", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	require.NoError(t, repo.ResetLoginAttempt(ctx, "ip:203.0.113.7"))
+	assert.False(t, mr.Exists("login_attempt:ip:203.0.113.7"))
+}
+
+func TestRedisRepositoryVerificationThrottling(t *testing.T) {
+	ctx := context.Background()
+	repo, mr := newTestRedisRepository(t)
+
+	acquired, err := repo.AcquireVerificationCooldown(ctx, "email", "jane@example.com", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+	acquired, err = repo.AcquireVerificationCooldown(ctx, "email", "jane@example.com", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, acquired, "a second code within the cooldown is rejected")
+	mr.FastForward(time.Minute)
+	acquired, err = repo.AcquireVerificationCooldown(ctx, "email", "jane@example.com", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+
+	// a new code does not reset the wrong attempts
+	_, err = repo.IncrVerificationAttempt(ctx, "email", 1, 10*time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, repo.StoreVerificationCode(ctx, model.VerificationCode{UserID: 1, Channel: "email", CodeHash: "hash", ExpiredAt: time.Now().Add(time.Minute)}))
+	attempts, err := repo.GetVerificationAttempts(ctx, "email", 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), attempts)
+
+	require.NoError(t, repo.ResetVerificationAttempt(ctx, "email", 1))
+	attempts, err = repo.GetVerificationAttempts(ctx, "email", 1)
+	require.NoError(t, err)
+	assert.Zero(t, attempts)
+}
+
+func TestRedisRepositoryIncrLoginChallengeAttempt(t *testing.T) {
+	ctx := context.Background()
+	repo, mr := newTestRedisRepository(t)
+	require.NoError(t, repo.StoreLoginChallenge(ctx, model.LoginChallenge{TokenHash: "hash", UserID: 1, ExpiredAt: time.Now().Add(time.Minute)}))
+
+	for want := int64(1); want <= 3; want++ {
+		attempts, err := repo.IncrLoginChallengeAttempt(ctx, "hash", time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, want, attempts)
+	}
+	assert.Equal(t, time.Minute, mr.TTL("login_challenge_attempt:hash"))
+
+	require.NoError(t, repo.DeleteLoginChallenge(ctx, "hash"))
+	assert.False(t, mr.Exists("login_challenge:hash"))
+	assert.False(t, mr.Exists("login_challenge_attempt:hash"), "the attempts go with the challenge")
+}
+
+func TestRedisRepositoryStorefronts(t *testing.T) {
+	ctx := context.Background()
+	repo, mr := newTestRedisRepository(t)
+
+	for _, page := range []int{1, 2} {
+		require.NoError(t, repo.StoreStorefront(ctx, model.Storefront{Shop: model.StorefrontShop{ID: 7}, Page: page, Limit: 20, Total: 25}, time.Minute))
+	}
+	require.NoError(t, repo.StoreStorefront(ctx, model.Storefront{Shop: model.StorefrontShop{ID: 8}, Page: 1, Limit: 20}, time.Minute))
+
+	storefront, err := repo.GetStorefront(ctx, 7, 2, 20)
+	require.NoError(t, err)
+	assert.Equal(t, 2, storefront.Page)
+	assert.Equal(t, int64(25), storefront.Total)
+	assert.Equal(t, time.Minute, mr.TTL("shop_storefronts:7"))
+
+	_, err = repo.GetStorefront(ctx, 7, 1, 50)
+	assert.ErrorIs(t, err, redis.Nil, "pages are cached per limit")
+
+	require.NoError(t, repo.DeleteStorefronts(ctx, 7))
+	for _, page := range []int{1, 2} {
+		_, err = repo.GetStorefront(ctx, 7, page, 20)
+		assert.ErrorIs(t, err, redis.Nil)
+	}
+	assert.False(t, mr.Exists("shop_storefronts:7"))
+
+	_, err = repo.GetStorefront(ctx, 8, 1, 20)
+	assert.NoError(t, err, "other shops stay cached")
+
+	assert.NoError(t, repo.DeleteStorefronts(ctx))
+}
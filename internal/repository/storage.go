@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"simcomm-monolith/config"
+	"simcomm-monolith/util"
+	"strings"
+	"time"
+)
+
+// Storage stores uploaded files under a key and serves them from a public URL
+type Storage interface {
+	Put(ctx context.Context, key string, contentType string, data []byte) (string, error)
+	// Delete removes the file served from the URL, URLs not served by the storage are ignored
+	Delete(ctx context.Context, fileURL string) error
+}
+
+func NewStorage(cfg config.StorageConfig) Storage {
+	switch cfg.Driver {
+	case "local", "":
+		return &localStorage{path: cfg.LocalPath, baseURL: strings.TrimSuffix(cfg.BaseURL, "/")}
+	case "s3":
+		return newS3Storage(cfg)
+	default:
+		log.Fatalf("unknown storage driver : %v", cfg.Driver)
+	}
+	return nil
+}
+
+// localStorage writes files to a directory served by the application, for
+// local development and single instance deployments
+type localStorage struct {
+	path    string
+	baseURL string
+}
+
+func (s *localStorage) Put(ctx context.Context, key string, contentType string, data []byte) (string, error) {
+	path := filepath.Join(s.path, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", err
+	}
+	return s.baseURL + "/" + key, nil
+}
+
+func (s *localStorage) Delete(ctx context.Context, fileURL string) error {
+	key, ok := strings.CutPrefix(fileURL, s.baseURL+"/")
+	if !ok || key == "" || strings.Contains(key, "..") {
+		return nil
+	}
+	err := os.Remove(filepath.Join(s.path, filepath.FromSlash(key)))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// s3Storage stores files in a bucket of any S3-compatible storage, requests
+// are signed with AWS Signature Version 4
+type s3Storage struct {
+	cfg      config.S3StorageConfig
+	endpoint *url.URL
+	baseURL  string
+	client   *http.Client
+}
+
+func newS3Storage(cfg config.StorageConfig) *s3Storage {
+	endpoint, err := url.Parse(cfg.S3.Endpoint)
+	if err != nil || endpoint.Host == "" {
+		log.Fatalf("invalid s3 storage endpoint : %v", cfg.S3.Endpoint)
+	}
+
+	s := &s3Storage{
+		cfg:      cfg.S3,
+		endpoint: endpoint,
+		baseURL:  strings.TrimSuffix(cfg.BaseURL, "/"),
+		client:   &http.Client{Timeout: 30 * time.Second},
+	}
+	if s.baseURL == "" {
+		s.baseURL = strings.TrimSuffix(s.objectURL("").String(), "/")
+	}
+	return s
+}
+
+// objectURL addresses the object with path style, e.g. for MinIO, or with the
+// bucket as sub domain like AWS
+func (s *s3Storage) objectURL(key string) *url.URL {
+	objectURL := *s.endpoint
+	if s.cfg.PathStyle {
+		objectURL.Path = strings.TrimSuffix(objectURL.Path, "/") + "/" + s.cfg.Bucket + "/" + key
+	} else {
+		objectURL.Host = s.cfg.Bucket + "." + objectURL.Host
+		objectURL.Path = strings.TrimSuffix(objectURL.Path, "/") + "/" + key
+	}
+	return &objectURL
+}
+
+func (s *s3Storage) Put(ctx context.Context, key string, contentType string, data []byte) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(key).String(), bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", contentType)
+	if err := s.do(req, data); err != nil {
+		return "", err
+	}
+	return s.baseURL + "/" + key, nil
+}
+
+func (s *s3Storage) Delete(ctx context.Context, fileURL string) error {
+	key, ok := strings.CutPrefix(fileURL, s.baseURL+"/")
+	if !ok || key == "" {
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.objectURL(key).String(), nil)
+	if err != nil {
+		return err
+	}
+	return s.do(req, nil)
+}
+
+func (s *s3Storage) do(req *http.Request, payload []byte) error {
+	util.SignAWSV4(req, util.SHA256Hex(payload), s.cfg.AccessKey, s.cfg.SecretKey, s.cfg.Region, "s3", time.Now())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("s3 storage %v %v responded %v : %s", req.Method, req.URL.Path, resp.StatusCode, body)
+	}
+	return nil
+}
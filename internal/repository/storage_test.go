@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"simcomm-monolith/config"
+	"simcomm-monolith/util"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// s3StandIn is an S3-compatible endpoint that only accepts requests carrying the
+// Signature Version 4 it computes itself for the signed headers of the request
+type s3StandIn struct {
+	server  *httptest.Server
+	mu      sync.Mutex
+	objects map[string]string
+}
+
+func newS3StandIn(t *testing.T, accessKey string, secretKey string) *s3StandIn {
+	t.Helper()
+	s3 := &s3StandIn{objects: map[string]string{}}
+	s3.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		if !s3.validSignature(r, body, accessKey, secretKey) {
+			http.Error(w, "SignatureDoesNotMatch", http.StatusForbidden)
+			return
+		}
+
+		s3.mu.Lock()
+		defer s3.mu.Unlock()
+		switch r.Method {
+		case http.MethodPut:
+			s3.objects[r.URL.Path] = r.Header.Get("Content-Type") + ";" + string(body)
+		case http.MethodDelete:
+			delete(s3.objects, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	t.Cleanup(s3.server.Close)
+	return s3
+}
+
+func (s3 *s3StandIn) validSignature(r *http.Request, body []byte, accessKey string, secretKey string) bool {
+	authorization := r.Header.Get("Authorization")
+	_, signedHeaders, ok := strings.Cut(authorization, "SignedHeaders=")
+	if !ok {
+		return false
+	}
+	signedHeaders, _, _ = strings.Cut(signedHeaders, ",")
+	signedAt, err := time.Parse("20060102T150405Z", r.Header.Get("X-Amz-Date"))
+	if err != nil {
+		return false
+	}
+
+	expected, err := http.NewRequest(r.Method, "http://"+r.Host+r.URL.RequestURI(), nil)
+	if err != nil {
+		return false
+	}
+	for _, name := range strings.Split(signedHeaders, ";") {
+		if name != "host" && name != "x-amz-date" && name != "x-amz-content-sha256" {
+			expected.Header.Set(name, r.Header.Get(name))
+		}
+	}
+	util.SignAWSV4(expected, util.SHA256Hex(body), accessKey, secretKey, "us-east-1", "s3", signedAt)
+	return r.Header.Get("X-Amz-Content-Sha256") == util.SHA256Hex(body) &&
+		authorization == expected.Header.Get("Authorization")
+}
+
+func TestS3Storage(t *testing.T) {
+	s3 := newS3StandIn(t, "access", "secret")
+	cfg := func(secretKey string) config.StorageConfig {
+		return config.StorageConfig{
+			Driver: "s3",
+			S3: config.S3StorageConfig{
+				Endpoint:  s3.server.URL,
+				Region:    "us-east-1",
+				Bucket:    "images",
+				AccessKey: "access",
+				SecretKey: secretKey,
+				PathStyle: true,
+			},
+		}
+	}
+	ctx := context.Background()
+
+	storage := NewStorage(cfg("secret"))
+	fileURL, err := storage.Put(ctx, "products/1/image 1.png", "image/png", []byte("png"))
+	require.NoError(t, err)
+	assert.Equal(t, s3.server.URL+"/images/products/1/image 1.png", fileURL)
+	assert.Equal(t, "image/png;png", s3.objects["/images/products/1/image 1.png"])
+
+	require.NoError(t, storage.Delete(ctx, fileURL))
+	assert.Empty(t, s3.objects)
+	assert.NoError(t, storage.Delete(ctx, "https://elsewhere.example/image.png"), "foreign URLs are ignored")
+
+	_, err = NewStorage(cfg("wrong-secret")).Put(ctx, "products/1/image.png", "image/png", []byte("png"))
+	assert.ErrorContains(t, err, "responded 403")
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"simcomm-monolith/config"
+	"simcomm-monolith/internal/model"
+	"simcomm-monolith/internal/repository"
+	"simcomm-monolith/util"
+
+	log "github.com/labstack/gommon/log"
+)
+
+// uploadImage validates an uploaded image and stores it with its thumbnail under folder
+func uploadImage(ctx context.Context, storage repository.Storage, cfg config.StorageConfig, folder string, file io.Reader) (*model.ImageData, error) {
+	data, err := io.ReadAll(io.LimitReader(file, cfg.MaxUploadSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, errors.New(util.ErrImageRequired)
+	}
+	if int64(len(data)) > cfg.MaxUploadSize {
+		return nil, errors.New(util.ErrImageTooLarge)
+	}
+
+	img, contentType, err := util.DecodeImage(data)
+	if err != nil {
+		return nil, err
+	}
+	thumbnail, thumbnailType, err := util.EncodeThumbnail(util.Thumbnail(img, cfg.ThumbnailSize), contentType)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	name, err := util.GenerateRandomString(16)
+	if err != nil {
+		return nil, err
+	}
+
+	imageURL, err := storage.Put(ctx, fmt.Sprintf("%v/%v%v", folder, name, util.ImageExtension(contentType)), contentType, data)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	thumbnailURL, err := storage.Put(ctx, fmt.Sprintf("%v/%v_thumb%v", folder, name, util.ImageExtension(thumbnailType)), thumbnailType, thumbnail)
+	if err != nil {
+		log.Error(err)
+		deleteImage(ctx, storage, model.ImageData{ImageURL: imageURL})
+		return nil, err
+	}
+
+	return &model.ImageData{ImageURL: imageURL, ThumbnailURL: thumbnailURL}, nil
+}
+
+// deleteImage removes a replaced image, a file left behind is only logged
+func deleteImage(ctx context.Context, storage repository.Storage, image model.ImageData) {
+	for _, fileURL := range []string{image.ImageURL, image.ThumbnailURL} {
+		if fileURL == "" {
+			continue
+		}
+		if err := storage.Delete(ctx, fileURL); err != nil {
+			log.Error(err)
+		}
+	}
+}
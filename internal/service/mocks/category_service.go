@@ -0,0 +1,103 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "simcomm-monolith/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CategoryService is an autogenerated mock type for the CategoryService type
+type CategoryService struct {
+	mock.Mock
+}
+
+// CategoryServiceCreate provides a mock function with given fields: ctx, category
+func (_m *CategoryService) CategoryServiceCreate(ctx context.Context, category *model.Category) error {
+	ret := _m.Called(ctx, category)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Category) error); ok {
+		r0 = rf(ctx, category)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CategoryServiceDelete provides a mock function with given fields: ctx, id
+func (_m *CategoryService) CategoryServiceDelete(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CategoryServiceGet provides a mock function with given fields: ctx, id
+func (_m *CategoryService) CategoryServiceGet(ctx context.Context, id int) (*model.CategorySchema, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.CategorySchema
+	if rf, ok := ret.Get(0).(func(context.Context, int) *model.CategorySchema); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.CategorySchema)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CategoryServiceGetTree provides a mock function with given fields: ctx
+func (_m *CategoryService) CategoryServiceGetTree(ctx context.Context) ([]*model.CategoryNode, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*model.CategoryNode
+	if rf, ok := ret.Get(0).(func(context.Context) []*model.CategoryNode); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.CategoryNode)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CategoryServiceUpdate provides a mock function with given fields: ctx, category
+func (_m *CategoryService) CategoryServiceUpdate(ctx context.Context, category *model.Category) error {
+	ret := _m.Called(ctx, category)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Category) error); ok {
+		r0 = rf(ctx, category)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,91 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	io "io"
+	model "simcomm-monolith/internal/model"
+
+	amqp091 "github.com/rabbitmq/amqp091-go"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ProductImportService is an autogenerated mock type for the ProductImportService type
+type ProductImportService struct {
+	mock.Mock
+}
+
+// ImportServiceCreate provides a mock function with given fields: ctx, format, file
+func (_m *ProductImportService) ImportServiceCreate(ctx context.Context, format string, file io.Reader) (*model.ProductImportJob, error) {
+	ret := _m.Called(ctx, format, file)
+
+	var r0 *model.ProductImportJob
+	if rf, ok := ret.Get(0).(func(context.Context, string, io.Reader) *model.ProductImportJob); ok {
+		r0 = rf(ctx, format, file)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ProductImportJob)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, io.Reader) error); ok {
+		r1 = rf(ctx, format, file)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportServiceExport provides a mock function with given fields: ctx, format, w
+func (_m *ProductImportService) ImportServiceExport(ctx context.Context, format string, w io.Writer) error {
+	ret := _m.Called(ctx, format, w)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, io.Writer) error); ok {
+		r0 = rf(ctx, format, w)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ImportServiceGet provides a mock function with given fields: ctx, id
+func (_m *ProductImportService) ImportServiceGet(ctx context.Context, id int) (*model.ProductImportJob, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.ProductImportJob
+	if rf, ok := ret.Get(0).(func(context.Context, int) *model.ProductImportJob); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ProductImportJob)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProcessImportQueue provides a mock function with given fields: ctx, msg
+func (_m *ProductImportService) ProcessImportQueue(ctx context.Context, msg amqp091.Delivery) error {
+	ret := _m.Called(ctx, msg)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, amqp091.Delivery) error); ok {
+		r0 = rf(ctx, msg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
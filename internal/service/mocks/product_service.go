@@ -0,0 +1,401 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	io "io"
+	model "simcomm-monolith/internal/model"
+
+	amqp091 "github.com/rabbitmq/amqp091-go"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ProductService is an autogenerated mock type for the ProductService type
+type ProductService struct {
+	mock.Mock
+}
+
+// CategoryServiceCreate provides a mock function with given fields: ctx, category
+func (_m *ProductService) CategoryServiceCreate(ctx context.Context, category *model.Category) error {
+	ret := _m.Called(ctx, category)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Category) error); ok {
+		r0 = rf(ctx, category)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CategoryServiceDelete provides a mock function with given fields: ctx, id
+func (_m *ProductService) CategoryServiceDelete(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CategoryServiceGet provides a mock function with given fields: ctx, id
+func (_m *ProductService) CategoryServiceGet(ctx context.Context, id int) (*model.CategorySchema, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.CategorySchema
+	if rf, ok := ret.Get(0).(func(context.Context, int) *model.CategorySchema); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.CategorySchema)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CategoryServiceGetTree provides a mock function with given fields: ctx
+func (_m *ProductService) CategoryServiceGetTree(ctx context.Context) ([]*model.CategoryNode, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*model.CategoryNode
+	if rf, ok := ret.Get(0).(func(context.Context) []*model.CategoryNode); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.CategoryNode)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CategoryServiceUpdate provides a mock function with given fields: ctx, category
+func (_m *ProductService) CategoryServiceUpdate(ctx context.Context, category *model.Category) error {
+	ret := _m.Called(ctx, category)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Category) error); ok {
+		r0 = rf(ctx, category)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Create provides a mock function with given fields: ctx, product
+func (_m *ProductService) Create(ctx context.Context, product *model.Product) error {
+	ret := _m.Called(ctx, product)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Product) error); ok {
+		r0 = rf(ctx, product)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *ProductService) Delete(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, id
+func (_m *ProductService) Get(ctx context.Context, id int) (*model.Product, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.Product
+	if rf, ok := ret.Get(0).(func(context.Context, int) *model.Product); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Product)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: ctx
+func (_m *ProductService) GetAll(ctx context.Context) ([]model.Product, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []model.Product
+	if rf, ok := ret.Get(0).(func(context.Context) []model.Product); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Product)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStatusHistory provides a mock function with given fields: ctx, id
+func (_m *ProductService) GetStatusHistory(ctx context.Context, id int) ([]model.StatusChange, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 []model.StatusChange
+	if rf, ok := ret.Get(0).(func(context.Context, int) []model.StatusChange); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.StatusChange)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportServiceCreate provides a mock function with given fields: ctx, format, file
+func (_m *ProductService) ImportServiceCreate(ctx context.Context, format string, file io.Reader) (*model.ProductImportJob, error) {
+	ret := _m.Called(ctx, format, file)
+
+	var r0 *model.ProductImportJob
+	if rf, ok := ret.Get(0).(func(context.Context, string, io.Reader) *model.ProductImportJob); ok {
+		r0 = rf(ctx, format, file)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ProductImportJob)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, io.Reader) error); ok {
+		r1 = rf(ctx, format, file)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportServiceExport provides a mock function with given fields: ctx, format, w
+func (_m *ProductService) ImportServiceExport(ctx context.Context, format string, w io.Writer) error {
+	ret := _m.Called(ctx, format, w)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, io.Writer) error); ok {
+		r0 = rf(ctx, format, w)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ImportServiceGet provides a mock function with given fields: ctx, id
+func (_m *ProductService) ImportServiceGet(ctx context.Context, id int) (*model.ProductImportJob, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.ProductImportJob
+	if rf, ok := ret.Get(0).(func(context.Context, int) *model.ProductImportJob); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ProductImportJob)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProcessImportQueue provides a mock function with given fields: ctx, msg
+func (_m *ProductService) ProcessImportQueue(ctx context.Context, msg amqp091.Delivery) error {
+	ret := _m.Called(ctx, msg)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, amqp091.Delivery) error); ok {
+		r0 = rf(ctx, msg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Search provides a mock function with given fields: ctx, req
+func (_m *ProductService) Search(ctx context.Context, req model.ProductSearchRequest) (*model.ProductSearchResult, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *model.ProductSearchResult
+	if rf, ok := ret.Get(0).(func(context.Context, model.ProductSearchRequest) *model.ProductSearchResult); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ProductSearchResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.ProductSearchRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, product
+func (_m *ProductService) Update(ctx context.Context, product *model.Product) error {
+	ret := _m.Called(ctx, product)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Product) error); ok {
+		r0 = rf(ctx, product)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UploadImage provides a mock function with given fields: ctx, id, file
+func (_m *ProductService) UploadImage(ctx context.Context, id int, file io.Reader) (*model.ImageData, error) {
+	ret := _m.Called(ctx, id, file)
+
+	var r0 *model.ImageData
+	if rf, ok := ret.Get(0).(func(context.Context, int, io.Reader) *model.ImageData); ok {
+		r0 = rf(ctx, id, file)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ImageData)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, io.Reader) error); ok {
+		r1 = rf(ctx, id, file)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VariantServiceCreate provides a mock function with given fields: ctx, variant
+func (_m *ProductService) VariantServiceCreate(ctx context.Context, variant *model.ProductVariant) error {
+	ret := _m.Called(ctx, variant)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ProductVariant) error); ok {
+		r0 = rf(ctx, variant)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VariantServiceDelete provides a mock function with given fields: ctx, productID, id
+func (_m *ProductService) VariantServiceDelete(ctx context.Context, productID int, id int) error {
+	ret := _m.Called(ctx, productID, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, productID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VariantServiceGetByProductID provides a mock function with given fields: ctx, productID
+func (_m *ProductService) VariantServiceGetByProductID(ctx context.Context, productID int) ([]model.ProductVariant, error) {
+	ret := _m.Called(ctx, productID)
+
+	var r0 []model.ProductVariant
+	if rf, ok := ret.Get(0).(func(context.Context, int) []model.ProductVariant); ok {
+		r0 = rf(ctx, productID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ProductVariant)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VariantServiceUpdate provides a mock function with given fields: ctx, variant
+func (_m *ProductService) VariantServiceUpdate(ctx context.Context, variant *model.ProductVariant) error {
+	ret := _m.Called(ctx, variant)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ProductVariant) error); ok {
+		r0 = rf(ctx, variant)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
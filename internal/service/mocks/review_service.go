@@ -0,0 +1,199 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "simcomm-monolith/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ReviewService is an autogenerated mock type for the ReviewService type
+type ReviewService struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, req
+func (_m *ReviewService) Create(ctx context.Context, req model.ReviewRequest) (*model.Review, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *model.Review
+	if rf, ok := ret.Get(0).(func(context.Context, model.ReviewRequest) *model.Review); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Review)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.ReviewRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Flag provides a mock function with given fields: ctx, id, req
+func (_m *ReviewService) Flag(ctx context.Context, id int, req model.ReviewFlagRequest) (*model.Review, error) {
+	ret := _m.Called(ctx, id, req)
+
+	var r0 *model.Review
+	if rf, ok := ret.Get(0).(func(context.Context, int, model.ReviewFlagRequest) *model.Review); ok {
+		r0 = rf(ctx, id, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Review)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, model.ReviewFlagRequest) error); ok {
+		r1 = rf(ctx, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByProductID provides a mock function with given fields: ctx, req
+func (_m *ReviewService) GetByProductID(ctx context.Context, req model.ReviewListRequest) (*model.ReviewList, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *model.ReviewList
+	if rf, ok := ret.Get(0).(func(context.Context, model.ReviewListRequest) *model.ReviewList); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ReviewList)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.ReviewListRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFlagged provides a mock function with given fields: ctx, req
+func (_m *ReviewService) GetFlagged(ctx context.Context, req model.ReviewListRequest) (*model.ReviewList, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *model.ReviewList
+	if rf, ok := ret.Get(0).(func(context.Context, model.ReviewListRequest) *model.ReviewList); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ReviewList)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.ReviewListRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProductRating provides a mock function with given fields: ctx, productID
+func (_m *ReviewService) GetProductRating(ctx context.Context, productID int) (*model.RatingSummary, error) {
+	ret := _m.Called(ctx, productID)
+
+	var r0 *model.RatingSummary
+	if rf, ok := ret.Get(0).(func(context.Context, int) *model.RatingSummary); ok {
+		r0 = rf(ctx, productID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RatingSummary)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetShopRating provides a mock function with given fields: ctx, shopID
+func (_m *ReviewService) GetShopRating(ctx context.Context, shopID int) (*model.RatingSummary, error) {
+	ret := _m.Called(ctx, shopID)
+
+	var r0 *model.RatingSummary
+	if rf, ok := ret.Get(0).(func(context.Context, int) *model.RatingSummary); ok {
+		r0 = rf(ctx, shopID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RatingSummary)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, shopID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Moderate provides a mock function with given fields: ctx, id, req
+func (_m *ReviewService) Moderate(ctx context.Context, id int, req model.ReviewModerationRequest) (*model.Review, error) {
+	ret := _m.Called(ctx, id, req)
+
+	var r0 *model.Review
+	if rf, ok := ret.Get(0).(func(context.Context, int, model.ReviewModerationRequest) *model.Review); ok {
+		r0 = rf(ctx, id, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Review)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, model.ReviewModerationRequest) error); ok {
+		r1 = rf(ctx, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Reply provides a mock function with given fields: ctx, id, req
+func (_m *ReviewService) Reply(ctx context.Context, id int, req model.ReviewReplyRequest) (*model.Review, error) {
+	ret := _m.Called(ctx, id, req)
+
+	var r0 *model.Review
+	if rf, ok := ret.Get(0).(func(context.Context, int, model.ReviewReplyRequest) *model.Review); ok {
+		r0 = rf(ctx, id, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Review)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, model.ReviewReplyRequest) error); ok {
+		r1 = rf(ctx, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
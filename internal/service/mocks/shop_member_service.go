@@ -0,0 +1,158 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "simcomm-monolith/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ShopMemberService is an autogenerated mock type for the ShopMemberService type
+type ShopMemberService struct {
+	mock.Mock
+}
+
+// ShopMemberServiceAcceptInvitation provides a mock function with given fields: ctx, req
+func (_m *ShopMemberService) ShopMemberServiceAcceptInvitation(ctx context.Context, req model.AcceptShopInvitationRequest) (*model.ShopMember, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *model.ShopMember
+	if rf, ok := ret.Get(0).(func(context.Context, model.AcceptShopInvitationRequest) *model.ShopMember); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ShopMember)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.AcceptShopInvitationRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShopMemberServiceDelete provides a mock function with given fields: ctx, shopID, userID
+func (_m *ShopMemberService) ShopMemberServiceDelete(ctx context.Context, shopID int, userID int) error {
+	ret := _m.Called(ctx, shopID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, shopID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ShopMemberServiceGetAll provides a mock function with given fields: ctx, shopID
+func (_m *ShopMemberService) ShopMemberServiceGetAll(ctx context.Context, shopID int) ([]model.ShopMember, error) {
+	ret := _m.Called(ctx, shopID)
+
+	var r0 []model.ShopMember
+	if rf, ok := ret.Get(0).(func(context.Context, int) []model.ShopMember); ok {
+		r0 = rf(ctx, shopID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ShopMember)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, shopID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShopMemberServiceGetInvitations provides a mock function with given fields: ctx, shopID
+func (_m *ShopMemberService) ShopMemberServiceGetInvitations(ctx context.Context, shopID int) ([]model.ShopInvitation, error) {
+	ret := _m.Called(ctx, shopID)
+
+	var r0 []model.ShopInvitation
+	if rf, ok := ret.Get(0).(func(context.Context, int) []model.ShopInvitation); ok {
+		r0 = rf(ctx, shopID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ShopInvitation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, shopID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShopMemberServiceInvite provides a mock function with given fields: ctx, shopID, req
+func (_m *ShopMemberService) ShopMemberServiceInvite(ctx context.Context, shopID int, req model.ShopInvitationRequest) (*model.ShopInvitation, error) {
+	ret := _m.Called(ctx, shopID, req)
+
+	var r0 *model.ShopInvitation
+	if rf, ok := ret.Get(0).(func(context.Context, int, model.ShopInvitationRequest) *model.ShopInvitation); ok {
+		r0 = rf(ctx, shopID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ShopInvitation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, model.ShopInvitationRequest) error); ok {
+		r1 = rf(ctx, shopID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShopMemberServiceRevokeInvitation provides a mock function with given fields: ctx, shopID, invitationID
+func (_m *ShopMemberService) ShopMemberServiceRevokeInvitation(ctx context.Context, shopID int, invitationID int) error {
+	ret := _m.Called(ctx, shopID, invitationID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, shopID, invitationID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ShopMemberServiceUpdateRole provides a mock function with given fields: ctx, shopID, userID, req
+func (_m *ShopMemberService) ShopMemberServiceUpdateRole(ctx context.Context, shopID int, userID int, req model.ShopMemberRoleRequest) (*model.ShopMember, error) {
+	ret := _m.Called(ctx, shopID, userID, req)
+
+	var r0 *model.ShopMember
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, model.ShopMemberRoleRequest) *model.ShopMember); ok {
+		r0 = rf(ctx, shopID, userID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ShopMember)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, model.ShopMemberRoleRequest) error); ok {
+		r1 = rf(ctx, shopID, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,214 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "simcomm-monolith/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ShopProductService is an autogenerated mock type for the ShopProductService type
+type ShopProductService struct {
+	mock.Mock
+}
+
+// RunPriceScheduler provides a mock function with given fields: ctx
+func (_m *ShopProductService) RunPriceScheduler(ctx context.Context) {
+	_m.Called(ctx)
+}
+
+// ShopProductServiceCancelPrice provides a mock function with given fields: ctx, id, priceID
+func (_m *ShopProductService) ShopProductServiceCancelPrice(ctx context.Context, id int, priceID int) error {
+	ret := _m.Called(ctx, id, priceID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, id, priceID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ShopProductServiceCreate provides a mock function with given fields: ctx, shopproduct
+func (_m *ShopProductService) ShopProductServiceCreate(ctx context.Context, shopproduct *model.ShopProduct) error {
+	ret := _m.Called(ctx, shopproduct)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ShopProduct) error); ok {
+		r0 = rf(ctx, shopproduct)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ShopProductServiceDelete provides a mock function with given fields: ctx, id
+func (_m *ShopProductService) ShopProductServiceDelete(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ShopProductServiceGet provides a mock function with given fields: ctx, id
+func (_m *ShopProductService) ShopProductServiceGet(ctx context.Context, id int) (*model.ShopProduct, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.ShopProduct
+	if rf, ok := ret.Get(0).(func(context.Context, int) *model.ShopProduct); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ShopProduct)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShopProductServiceGetAll provides a mock function with given fields: ctx
+func (_m *ShopProductService) ShopProductServiceGetAll(ctx context.Context) ([]model.ShopProduct, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []model.ShopProduct
+	if rf, ok := ret.Get(0).(func(context.Context) []model.ShopProduct); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ShopProduct)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShopProductServiceGetByShopID provides a mock function with given fields: ctx, req
+func (_m *ShopProductService) ShopProductServiceGetByShopID(ctx context.Context, req model.ShopProductListRequest) (*model.ShopProductList, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *model.ShopProductList
+	if rf, ok := ret.Get(0).(func(context.Context, model.ShopProductListRequest) *model.ShopProductList); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ShopProductList)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.ShopProductListRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShopProductServiceGetPrices provides a mock function with given fields: ctx, id
+func (_m *ShopProductService) ShopProductServiceGetPrices(ctx context.Context, id int) (*model.ShopProductPrices, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.ShopProductPrices
+	if rf, ok := ret.Get(0).(func(context.Context, int) *model.ShopProductPrices); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ShopProductPrices)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShopProductServiceGetStatusHistory provides a mock function with given fields: ctx, id
+func (_m *ShopProductService) ShopProductServiceGetStatusHistory(ctx context.Context, id int) ([]model.StatusChange, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 []model.StatusChange
+	if rf, ok := ret.Get(0).(func(context.Context, int) []model.StatusChange); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.StatusChange)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShopProductServiceSetPrice provides a mock function with given fields: ctx, id, req
+func (_m *ShopProductService) ShopProductServiceSetPrice(ctx context.Context, id int, req model.ShopProductPriceRequest) (*model.ShopProductPrice, error) {
+	ret := _m.Called(ctx, id, req)
+
+	var r0 *model.ShopProductPrice
+	if rf, ok := ret.Get(0).(func(context.Context, int, model.ShopProductPriceRequest) *model.ShopProductPrice); ok {
+		r0 = rf(ctx, id, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ShopProductPrice)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, model.ShopProductPriceRequest) error); ok {
+		r1 = rf(ctx, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShopProductServiceUpdate provides a mock function with given fields: ctx, shopproduct
+func (_m *ShopProductService) ShopProductServiceUpdate(ctx context.Context, shopproduct *model.ShopProduct) error {
+	ret := _m.Called(ctx, shopproduct)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ShopProduct) error); ok {
+		r0 = rf(ctx, shopproduct)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
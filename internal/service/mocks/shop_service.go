@@ -0,0 +1,544 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	io "io"
+	model "simcomm-monolith/internal/model"
+
+	amqp091 "github.com/rabbitmq/amqp091-go"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ShopService is an autogenerated mock type for the ShopService type
+type ShopService struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, shop
+func (_m *ShopService) Create(ctx context.Context, shop *model.Shop) error {
+	ret := _m.Called(ctx, shop)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Shop) error); ok {
+		r0 = rf(ctx, shop)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateTransferProduct provides a mock function with given fields: ctx, tp
+func (_m *ShopService) CreateTransferProduct(ctx context.Context, tp *model.TransferProduct) error {
+	ret := _m.Called(ctx, tp)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.TransferProduct) error); ok {
+		r0 = rf(ctx, tp)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *ShopService) Delete(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, id
+func (_m *ShopService) Get(ctx context.Context, id int) (*model.Shop, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.Shop
+	if rf, ok := ret.Get(0).(func(context.Context, int) *model.Shop); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Shop)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: ctx
+func (_m *ShopService) GetAll(ctx context.Context) ([]model.Shop, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []model.Shop
+	if rf, ok := ret.Get(0).(func(context.Context) []model.Shop); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Shop)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStatusHistory provides a mock function with given fields: ctx, id
+func (_m *ShopService) GetStatusHistory(ctx context.Context, id int) ([]model.StatusChange, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 []model.StatusChange
+	if rf, ok := ret.Get(0).(func(context.Context, int) []model.StatusChange); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.StatusChange)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStorefront provides a mock function with given fields: ctx, req
+func (_m *ShopService) GetStorefront(ctx context.Context, req model.StorefrontRequest) (*model.Storefront, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *model.Storefront
+	if rf, ok := ret.Get(0).(func(context.Context, model.StorefrontRequest) *model.Storefront); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Storefront)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.StorefrontRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProcessRTPQueue provides a mock function with given fields: ctx, msg
+func (_m *ShopService) ProcessRTPQueue(ctx context.Context, msg amqp091.Delivery) error {
+	ret := _m.Called(ctx, msg)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, amqp091.Delivery) error); ok {
+		r0 = rf(ctx, msg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RunPriceScheduler provides a mock function with given fields: ctx
+func (_m *ShopService) RunPriceScheduler(ctx context.Context) {
+	_m.Called(ctx)
+}
+
+// ShopMemberServiceAcceptInvitation provides a mock function with given fields: ctx, req
+func (_m *ShopService) ShopMemberServiceAcceptInvitation(ctx context.Context, req model.AcceptShopInvitationRequest) (*model.ShopMember, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *model.ShopMember
+	if rf, ok := ret.Get(0).(func(context.Context, model.AcceptShopInvitationRequest) *model.ShopMember); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ShopMember)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.AcceptShopInvitationRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShopMemberServiceDelete provides a mock function with given fields: ctx, shopID, userID
+func (_m *ShopService) ShopMemberServiceDelete(ctx context.Context, shopID int, userID int) error {
+	ret := _m.Called(ctx, shopID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, shopID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ShopMemberServiceGetAll provides a mock function with given fields: ctx, shopID
+func (_m *ShopService) ShopMemberServiceGetAll(ctx context.Context, shopID int) ([]model.ShopMember, error) {
+	ret := _m.Called(ctx, shopID)
+
+	var r0 []model.ShopMember
+	if rf, ok := ret.Get(0).(func(context.Context, int) []model.ShopMember); ok {
+		r0 = rf(ctx, shopID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ShopMember)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, shopID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShopMemberServiceGetInvitations provides a mock function with given fields: ctx, shopID
+func (_m *ShopService) ShopMemberServiceGetInvitations(ctx context.Context, shopID int) ([]model.ShopInvitation, error) {
+	ret := _m.Called(ctx, shopID)
+
+	var r0 []model.ShopInvitation
+	if rf, ok := ret.Get(0).(func(context.Context, int) []model.ShopInvitation); ok {
+		r0 = rf(ctx, shopID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ShopInvitation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, shopID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShopMemberServiceInvite provides a mock function with given fields: ctx, shopID, req
+func (_m *ShopService) ShopMemberServiceInvite(ctx context.Context, shopID int, req model.ShopInvitationRequest) (*model.ShopInvitation, error) {
+	ret := _m.Called(ctx, shopID, req)
+
+	var r0 *model.ShopInvitation
+	if rf, ok := ret.Get(0).(func(context.Context, int, model.ShopInvitationRequest) *model.ShopInvitation); ok {
+		r0 = rf(ctx, shopID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ShopInvitation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, model.ShopInvitationRequest) error); ok {
+		r1 = rf(ctx, shopID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShopMemberServiceRevokeInvitation provides a mock function with given fields: ctx, shopID, invitationID
+func (_m *ShopService) ShopMemberServiceRevokeInvitation(ctx context.Context, shopID int, invitationID int) error {
+	ret := _m.Called(ctx, shopID, invitationID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, shopID, invitationID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ShopMemberServiceUpdateRole provides a mock function with given fields: ctx, shopID, userID, req
+func (_m *ShopService) ShopMemberServiceUpdateRole(ctx context.Context, shopID int, userID int, req model.ShopMemberRoleRequest) (*model.ShopMember, error) {
+	ret := _m.Called(ctx, shopID, userID, req)
+
+	var r0 *model.ShopMember
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, model.ShopMemberRoleRequest) *model.ShopMember); ok {
+		r0 = rf(ctx, shopID, userID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ShopMember)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, model.ShopMemberRoleRequest) error); ok {
+		r1 = rf(ctx, shopID, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShopProductServiceCancelPrice provides a mock function with given fields: ctx, id, priceID
+func (_m *ShopService) ShopProductServiceCancelPrice(ctx context.Context, id int, priceID int) error {
+	ret := _m.Called(ctx, id, priceID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, id, priceID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ShopProductServiceCreate provides a mock function with given fields: ctx, shopproduct
+func (_m *ShopService) ShopProductServiceCreate(ctx context.Context, shopproduct *model.ShopProduct) error {
+	ret := _m.Called(ctx, shopproduct)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ShopProduct) error); ok {
+		r0 = rf(ctx, shopproduct)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ShopProductServiceDelete provides a mock function with given fields: ctx, id
+func (_m *ShopService) ShopProductServiceDelete(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ShopProductServiceGet provides a mock function with given fields: ctx, id
+func (_m *ShopService) ShopProductServiceGet(ctx context.Context, id int) (*model.ShopProduct, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.ShopProduct
+	if rf, ok := ret.Get(0).(func(context.Context, int) *model.ShopProduct); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ShopProduct)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShopProductServiceGetAll provides a mock function with given fields: ctx
+func (_m *ShopService) ShopProductServiceGetAll(ctx context.Context) ([]model.ShopProduct, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []model.ShopProduct
+	if rf, ok := ret.Get(0).(func(context.Context) []model.ShopProduct); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ShopProduct)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShopProductServiceGetByShopID provides a mock function with given fields: ctx, req
+func (_m *ShopService) ShopProductServiceGetByShopID(ctx context.Context, req model.ShopProductListRequest) (*model.ShopProductList, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *model.ShopProductList
+	if rf, ok := ret.Get(0).(func(context.Context, model.ShopProductListRequest) *model.ShopProductList); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ShopProductList)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.ShopProductListRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShopProductServiceGetPrices provides a mock function with given fields: ctx, id
+func (_m *ShopService) ShopProductServiceGetPrices(ctx context.Context, id int) (*model.ShopProductPrices, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.ShopProductPrices
+	if rf, ok := ret.Get(0).(func(context.Context, int) *model.ShopProductPrices); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ShopProductPrices)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShopProductServiceGetStatusHistory provides a mock function with given fields: ctx, id
+func (_m *ShopService) ShopProductServiceGetStatusHistory(ctx context.Context, id int) ([]model.StatusChange, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 []model.StatusChange
+	if rf, ok := ret.Get(0).(func(context.Context, int) []model.StatusChange); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.StatusChange)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShopProductServiceSetPrice provides a mock function with given fields: ctx, id, req
+func (_m *ShopService) ShopProductServiceSetPrice(ctx context.Context, id int, req model.ShopProductPriceRequest) (*model.ShopProductPrice, error) {
+	ret := _m.Called(ctx, id, req)
+
+	var r0 *model.ShopProductPrice
+	if rf, ok := ret.Get(0).(func(context.Context, int, model.ShopProductPriceRequest) *model.ShopProductPrice); ok {
+		r0 = rf(ctx, id, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ShopProductPrice)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, model.ShopProductPriceRequest) error); ok {
+		r1 = rf(ctx, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShopProductServiceUpdate provides a mock function with given fields: ctx, shopproduct
+func (_m *ShopService) ShopProductServiceUpdate(ctx context.Context, shopproduct *model.ShopProduct) error {
+	ret := _m.Called(ctx, shopproduct)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ShopProduct) error); ok {
+		r0 = rf(ctx, shopproduct)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: ctx, shop
+func (_m *ShopService) Update(ctx context.Context, shop *model.Shop) error {
+	ret := _m.Called(ctx, shop)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Shop) error); ok {
+		r0 = rf(ctx, shop)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UploadImage provides a mock function with given fields: ctx, id, file
+func (_m *ShopService) UploadImage(ctx context.Context, id int, file io.Reader) (*model.ImageData, error) {
+	ret := _m.Called(ctx, id, file)
+
+	var r0 *model.ImageData
+	if rf, ok := ret.Get(0).(func(context.Context, int, io.Reader) *model.ImageData); ok {
+		r0 = rf(ctx, id, file)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ImageData)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, io.Reader) error); ok {
+		r1 = rf(ctx, id, file)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,26 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	model "simcomm-monolith/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// statusEntity is an autogenerated mock type for the statusEntity type
+type statusEntity struct {
+	mock.Mock
+}
+
+// KeepStatus provides a mock function with given fields: status
+func (_m *statusEntity) KeepStatus(status model.Status) {
+	_m.Called(status)
+}
+
+// RecordStatusChange provides a mock function with given fields: from, to, by, at
+func (_m *statusEntity) RecordStatusChange(from model.Status, to model.Status, by int, at time.Time) {
+	_m.Called(from, to, by, at)
+}
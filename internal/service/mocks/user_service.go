@@ -0,0 +1,631 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "simcomm-monolith/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// UserService is an autogenerated mock type for the UserService type
+type UserService struct {
+	mock.Mock
+}
+
+// AddAddress provides a mock function with given fields: ctx, userID, req
+func (_m *UserService) AddAddress(ctx context.Context, userID int, req model.AddressRequest) (*model.UserAddress, error) {
+	ret := _m.Called(ctx, userID, req)
+
+	var r0 *model.UserAddress
+	if rf, ok := ret.Get(0).(func(context.Context, int, model.AddressRequest) *model.UserAddress); ok {
+		r0 = rf(ctx, userID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UserAddress)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, model.AddressRequest) error); ok {
+		r1 = rf(ctx, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChangePassword provides a mock function with given fields: ctx, userID, req
+func (_m *UserService) ChangePassword(ctx context.Context, userID int, req model.ChangePasswordRequest) error {
+	ret := _m.Called(ctx, userID, req)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, model.ChangePasswordRequest) error); ok {
+		r0 = rf(ctx, userID, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ConfirmTOTP provides a mock function with given fields: ctx, userID, req
+func (_m *UserService) ConfirmTOTP(ctx context.Context, userID int, req model.TOTPCodeRequest) (model.RecoveryCodesData, error) {
+	ret := _m.Called(ctx, userID, req)
+
+	var r0 model.RecoveryCodesData
+	if rf, ok := ret.Get(0).(func(context.Context, int, model.TOTPCodeRequest) model.RecoveryCodesData); ok {
+		r0 = rf(ctx, userID, req)
+	} else {
+		r0 = ret.Get(0).(model.RecoveryCodesData)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, model.TOTPCodeRequest) error); ok {
+		r1 = rf(ctx, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, user
+func (_m *UserService) Create(ctx context.Context, user *model.User) error {
+	ret := _m.Called(ctx, user)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Deactivate provides a mock function with given fields: ctx, userID
+func (_m *UserService) Deactivate(ctx context.Context, userID int) error {
+	ret := _m.Called(ctx, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeactivateSelf provides a mock function with given fields: ctx, userID, req
+func (_m *UserService) DeactivateSelf(ctx context.Context, userID int, req model.PasswordConfirmationRequest) error {
+	ret := _m.Called(ctx, userID, req)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, model.PasswordConfirmationRequest) error); ok {
+		r0 = rf(ctx, userID, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *UserService) Delete(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteAddress provides a mock function with given fields: ctx, userID, addressID
+func (_m *UserService) DeleteAddress(ctx context.Context, userID int, addressID int) error {
+	ret := _m.Called(ctx, userID, addressID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, userID, addressID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DisableTOTP provides a mock function with given fields: ctx, userID, req
+func (_m *UserService) DisableTOTP(ctx context.Context, userID int, req model.DisableTOTPRequest) error {
+	ret := _m.Called(ctx, userID, req)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, model.DisableTOTPRequest) error); ok {
+		r0 = rf(ctx, userID, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnrollTOTP provides a mock function with given fields: ctx, userID
+func (_m *UserService) EnrollTOTP(ctx context.Context, userID int) (model.TOTPEnrolmentData, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 model.TOTPEnrolmentData
+	if rf, ok := ret.Get(0).(func(context.Context, int) model.TOTPEnrolmentData); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(model.TOTPEnrolmentData)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Erase provides a mock function with given fields: ctx, userID, req
+func (_m *UserService) Erase(ctx context.Context, userID int, req model.PasswordConfirmationRequest) error {
+	ret := _m.Called(ctx, userID, req)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, model.PasswordConfirmationRequest) error); ok {
+		r0 = rf(ctx, userID, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Export provides a mock function with given fields: ctx, userID
+func (_m *UserService) Export(ctx context.Context, userID int) (model.UserExport, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 model.UserExport
+	if rf, ok := ret.Get(0).(func(context.Context, int) model.UserExport); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(model.UserExport)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ForgotPassword provides a mock function with given fields: ctx, req
+func (_m *UserService) ForgotPassword(ctx context.Context, req model.ForgotPasswordRequest) error {
+	ret := _m.Called(ctx, req)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.ForgotPasswordRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, id
+func (_m *UserService) Get(ctx context.Context, id int) (*model.User, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.User
+	if rf, ok := ret.Get(0).(func(context.Context, int) *model.User); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAddresses provides a mock function with given fields: ctx, userID
+func (_m *UserService) GetAddresses(ctx context.Context, userID int) ([]model.UserAddress, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []model.UserAddress
+	if rf, ok := ret.Get(0).(func(context.Context, int) []model.UserAddress); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.UserAddress)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: ctx
+func (_m *UserService) GetAll(ctx context.Context) ([]model.User, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []model.User
+	if rf, ok := ret.Get(0).(func(context.Context) []model.User); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserByIdentifier provides a mock function with given fields: ctx, identifier
+func (_m *UserService) GetUserByIdentifier(ctx context.Context, identifier string) (*model.User, error) {
+	ret := _m.Called(ctx, identifier)
+
+	var r0 *model.User
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.User); ok {
+		r0 = rf(ctx, identifier)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, identifier)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GrantRole provides a mock function with given fields: ctx, userID, role
+func (_m *UserService) GrantRole(ctx context.Context, userID int, role string) error {
+	ret := _m.Called(ctx, userID, role)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) error); ok {
+		r0 = rf(ctx, userID, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Login provides a mock function with given fields: ctx, req
+func (_m *UserService) Login(ctx context.Context, req model.LoginRequest) (model.LoginData, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 model.LoginData
+	if rf, ok := ret.Get(0).(func(context.Context, model.LoginRequest) model.LoginData); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(model.LoginData)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.LoginRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoginTwoFactor provides a mock function with given fields: ctx, req
+func (_m *UserService) LoginTwoFactor(ctx context.Context, req model.TwoFactorLoginRequest) (model.LoginData, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 model.LoginData
+	if rf, ok := ret.Get(0).(func(context.Context, model.TwoFactorLoginRequest) model.LoginData); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(model.LoginData)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.TwoFactorLoginRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logout provides a mock function with given fields: ctx, token
+func (_m *UserService) Logout(ctx context.Context, token string) error {
+	ret := _m.Called(ctx, token)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OIDCCallback provides a mock function with given fields: ctx, provider, code, state
+func (_m *UserService) OIDCCallback(ctx context.Context, provider string, code string, state string) (model.LoginData, error) {
+	ret := _m.Called(ctx, provider, code, state)
+
+	var r0 model.LoginData
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) model.LoginData); ok {
+		r0 = rf(ctx, provider, code, state)
+	} else {
+		r0 = ret.Get(0).(model.LoginData)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, provider, code, state)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OIDCLogin provides a mock function with given fields: ctx, provider
+func (_m *UserService) OIDCLogin(ctx context.Context, provider string) (model.OIDCLoginData, error) {
+	ret := _m.Called(ctx, provider)
+
+	var r0 model.OIDCLoginData
+	if rf, ok := ret.Get(0).(func(context.Context, string) model.OIDCLoginData); ok {
+		r0 = rf(ctx, provider)
+	} else {
+		r0 = ret.Get(0).(model.OIDCLoginData)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, provider)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Reactivate provides a mock function with given fields: ctx, userID
+func (_m *UserService) Reactivate(ctx context.Context, userID int) error {
+	ret := _m.Called(ctx, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RefreshToken provides a mock function with given fields: ctx, req
+func (_m *UserService) RefreshToken(ctx context.Context, req model.RefreshTokenRequest) (model.LoginData, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 model.LoginData
+	if rf, ok := ret.Get(0).(func(context.Context, model.RefreshTokenRequest) model.LoginData); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(model.LoginData)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.RefreshTokenRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RegenerateRecoveryCodes provides a mock function with given fields: ctx, userID, req
+func (_m *UserService) RegenerateRecoveryCodes(ctx context.Context, userID int, req model.TOTPCodeRequest) (model.RecoveryCodesData, error) {
+	ret := _m.Called(ctx, userID, req)
+
+	var r0 model.RecoveryCodesData
+	if rf, ok := ret.Get(0).(func(context.Context, int, model.TOTPCodeRequest) model.RecoveryCodesData); ok {
+		r0 = rf(ctx, userID, req)
+	} else {
+		r0 = ret.Get(0).(model.RecoveryCodesData)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, model.TOTPCodeRequest) error); ok {
+		r1 = rf(ctx, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResetPassword provides a mock function with given fields: ctx, req
+func (_m *UserService) ResetPassword(ctx context.Context, req model.ResetPasswordRequest) error {
+	ret := _m.Called(ctx, req)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.ResetPasswordRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeRole provides a mock function with given fields: ctx, userID, role
+func (_m *UserService) RevokeRole(ctx context.Context, userID int, role string) error {
+	ret := _m.Called(ctx, userID, role)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) error); ok {
+		r0 = rf(ctx, userID, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SendVerification provides a mock function with given fields: ctx, req
+func (_m *UserService) SendVerification(ctx context.Context, req model.SendVerificationRequest) error {
+	ret := _m.Called(ctx, req)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.SendVerificationRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SignUp provides a mock function with given fields: ctx, req
+func (_m *UserService) SignUp(ctx context.Context, req model.SignUpRequest) error {
+	ret := _m.Called(ctx, req)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.SignUpRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Unlock provides a mock function with given fields: ctx, userID
+func (_m *UserService) Unlock(ctx context.Context, userID int) error {
+	ret := _m.Called(ctx, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: ctx, user
+func (_m *UserService) Update(ctx context.Context, user *model.User) error {
+	ret := _m.Called(ctx, user)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateAddress provides a mock function with given fields: ctx, userID, addressID, req
+func (_m *UserService) UpdateAddress(ctx context.Context, userID int, addressID int, req model.AddressRequest) (*model.UserAddress, error) {
+	ret := _m.Called(ctx, userID, addressID, req)
+
+	var r0 *model.UserAddress
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, model.AddressRequest) *model.UserAddress); ok {
+		r0 = rf(ctx, userID, addressID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UserAddress)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, model.AddressRequest) error); ok {
+		r1 = rf(ctx, userID, addressID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateProfile provides a mock function with given fields: ctx, userID, req
+func (_m *UserService) UpdateProfile(ctx context.Context, userID int, req model.UpdateProfileRequest) (*model.User, error) {
+	ret := _m.Called(ctx, userID, req)
+
+	var r0 *model.User
+	if rf, ok := ret.Get(0).(func(context.Context, int, model.UpdateProfileRequest) *model.User); ok {
+		r0 = rf(ctx, userID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, model.UpdateProfileRequest) error); ok {
+		r1 = rf(ctx, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyContact provides a mock function with given fields: ctx, req
+func (_m *UserService) VerifyContact(ctx context.Context, req model.VerifyContactRequest) error {
+	ret := _m.Called(ctx, req)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.VerifyContactRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,80 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "simcomm-monolith/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// VariantService is an autogenerated mock type for the VariantService type
+type VariantService struct {
+	mock.Mock
+}
+
+// VariantServiceCreate provides a mock function with given fields: ctx, variant
+func (_m *VariantService) VariantServiceCreate(ctx context.Context, variant *model.ProductVariant) error {
+	ret := _m.Called(ctx, variant)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ProductVariant) error); ok {
+		r0 = rf(ctx, variant)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VariantServiceDelete provides a mock function with given fields: ctx, productID, id
+func (_m *VariantService) VariantServiceDelete(ctx context.Context, productID int, id int) error {
+	ret := _m.Called(ctx, productID, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, productID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VariantServiceGetByProductID provides a mock function with given fields: ctx, productID
+func (_m *VariantService) VariantServiceGetByProductID(ctx context.Context, productID int) ([]model.ProductVariant, error) {
+	ret := _m.Called(ctx, productID)
+
+	var r0 []model.ProductVariant
+	if rf, ok := ret.Get(0).(func(context.Context, int) []model.ProductVariant); ok {
+		r0 = rf(ctx, productID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ProductVariant)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VariantServiceUpdate provides a mock function with given fields: ctx, variant
+func (_m *VariantService) VariantServiceUpdate(ctx context.Context, variant *model.ProductVariant) error {
+	ret := _m.Called(ctx, variant)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ProductVariant) error); ok {
+		r0 = rf(ctx, variant)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
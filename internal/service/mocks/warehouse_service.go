@@ -0,0 +1,322 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	io "io"
+	model "simcomm-monolith/internal/model"
+
+	amqp091 "github.com/rabbitmq/amqp091-go"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// WarehouseService is an autogenerated mock type for the WarehouseService type
+type WarehouseService struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, warehouse
+func (_m *WarehouseService) Create(ctx context.Context, warehouse *model.Warehouse) error {
+	ret := _m.Called(ctx, warehouse)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Warehouse) error); ok {
+		r0 = rf(ctx, warehouse)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *WarehouseService) Delete(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, id
+func (_m *WarehouseService) Get(ctx context.Context, id int) (*model.Warehouse, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.Warehouse
+	if rf, ok := ret.Get(0).(func(context.Context, int) *model.Warehouse); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Warehouse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: ctx
+func (_m *WarehouseService) GetAll(ctx context.Context) ([]model.Warehouse, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []model.Warehouse
+	if rf, ok := ret.Get(0).(func(context.Context) []model.Warehouse); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Warehouse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByShopID provides a mock function with given fields: ctx, req
+func (_m *WarehouseService) GetByShopID(ctx context.Context, req model.WarehouseListRequest) (*model.WarehouseList, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *model.WarehouseList
+	if rf, ok := ret.Get(0).(func(context.Context, model.WarehouseListRequest) *model.WarehouseList); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.WarehouseList)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.WarehouseListRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStatusHistory provides a mock function with given fields: ctx, id
+func (_m *WarehouseService) GetStatusHistory(ctx context.Context, id int) ([]model.StatusChange, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 []model.StatusChange
+	if rf, ok := ret.Get(0).(func(context.Context, int) []model.StatusChange); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.StatusChange)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProcessTPQueue provides a mock function with given fields: ctx, msg
+func (_m *WarehouseService) ProcessTPQueue(ctx context.Context, msg amqp091.Delivery) error {
+	ret := _m.Called(ctx, msg)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, amqp091.Delivery) error); ok {
+		r0 = rf(ctx, msg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: ctx, warehouse
+func (_m *WarehouseService) Update(ctx context.Context, warehouse *model.Warehouse) error {
+	ret := _m.Called(ctx, warehouse)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Warehouse) error); ok {
+		r0 = rf(ctx, warehouse)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UploadImage provides a mock function with given fields: ctx, id, file
+func (_m *WarehouseService) UploadImage(ctx context.Context, id int, file io.Reader) (*model.ImageData, error) {
+	ret := _m.Called(ctx, id, file)
+
+	var r0 *model.ImageData
+	if rf, ok := ret.Get(0).(func(context.Context, int, io.Reader) *model.ImageData); ok {
+		r0 = rf(ctx, id, file)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ImageData)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, io.Reader) error); ok {
+		r1 = rf(ctx, id, file)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WSPCreate provides a mock function with given fields: ctx, warehousestoredproduct
+func (_m *WarehouseService) WSPCreate(ctx context.Context, warehousestoredproduct *model.WarehouseStoredProduct) error {
+	ret := _m.Called(ctx, warehousestoredproduct)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.WarehouseStoredProduct) error); ok {
+		r0 = rf(ctx, warehousestoredproduct)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WSPDelete provides a mock function with given fields: ctx, id
+func (_m *WarehouseService) WSPDelete(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WSPGet provides a mock function with given fields: ctx, id
+func (_m *WarehouseService) WSPGet(ctx context.Context, id int) (*model.WarehouseStoredProduct, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.WarehouseStoredProduct
+	if rf, ok := ret.Get(0).(func(context.Context, int) *model.WarehouseStoredProduct); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.WarehouseStoredProduct)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WSPGetAll provides a mock function with given fields: ctx
+func (_m *WarehouseService) WSPGetAll(ctx context.Context) ([]model.WarehouseStoredProduct, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []model.WarehouseStoredProduct
+	if rf, ok := ret.Get(0).(func(context.Context) []model.WarehouseStoredProduct); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.WarehouseStoredProduct)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WSPGetByShopProductID provides a mock function with given fields: ctx, shopProductID, warehouseID
+func (_m *WarehouseService) WSPGetByShopProductID(ctx context.Context, shopProductID int, warehouseID int) (*model.WarehouseStoredProduct, error) {
+	ret := _m.Called(ctx, shopProductID, warehouseID)
+
+	var r0 *model.WarehouseStoredProduct
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *model.WarehouseStoredProduct); ok {
+		r0 = rf(ctx, shopProductID, warehouseID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.WarehouseStoredProduct)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, shopProductID, warehouseID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WSPGetByWarehouseID provides a mock function with given fields: ctx, req
+func (_m *WarehouseService) WSPGetByWarehouseID(ctx context.Context, req model.StoredProductListRequest) (*model.StoredProductList, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *model.StoredProductList
+	if rf, ok := ret.Get(0).(func(context.Context, model.StoredProductListRequest) *model.StoredProductList); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.StoredProductList)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.StoredProductListRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WSPUpdate provides a mock function with given fields: ctx, warehousestoredproduct
+func (_m *WarehouseService) WSPUpdate(ctx context.Context, warehousestoredproduct *model.WarehouseStoredProduct) error {
+	ret := _m.Called(ctx, warehousestoredproduct)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.WarehouseStoredProduct) error); ok {
+		r0 = rf(ctx, warehousestoredproduct)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,126 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "simcomm-monolith/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// WarehouseStoredProductService is an autogenerated mock type for the WarehouseStoredProductService type
+type WarehouseStoredProductService struct {
+	mock.Mock
+}
+
+// WSPCreate provides a mock function with given fields: ctx, warehousestoredproduct
+func (_m *WarehouseStoredProductService) WSPCreate(ctx context.Context, warehousestoredproduct *model.WarehouseStoredProduct) error {
+	ret := _m.Called(ctx, warehousestoredproduct)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.WarehouseStoredProduct) error); ok {
+		r0 = rf(ctx, warehousestoredproduct)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WSPDelete provides a mock function with given fields: ctx, id
+func (_m *WarehouseStoredProductService) WSPDelete(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WSPGet provides a mock function with given fields: ctx, id
+func (_m *WarehouseStoredProductService) WSPGet(ctx context.Context, id int) (*model.WarehouseStoredProduct, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.WarehouseStoredProduct
+	if rf, ok := ret.Get(0).(func(context.Context, int) *model.WarehouseStoredProduct); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.WarehouseStoredProduct)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WSPGetAll provides a mock function with given fields: ctx
+func (_m *WarehouseStoredProductService) WSPGetAll(ctx context.Context) ([]model.WarehouseStoredProduct, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []model.WarehouseStoredProduct
+	if rf, ok := ret.Get(0).(func(context.Context) []model.WarehouseStoredProduct); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.WarehouseStoredProduct)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WSPGetByWarehouseID provides a mock function with given fields: ctx, req
+func (_m *WarehouseStoredProductService) WSPGetByWarehouseID(ctx context.Context, req model.StoredProductListRequest) (*model.StoredProductList, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *model.StoredProductList
+	if rf, ok := ret.Get(0).(func(context.Context, model.StoredProductListRequest) *model.StoredProductList); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.StoredProductList)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.StoredProductListRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WSPUpdate provides a mock function with given fields: ctx, warehousestoredproduct
+func (_m *WarehouseStoredProductService) WSPUpdate(ctx context.Context, warehousestoredproduct *model.WarehouseStoredProduct) error {
+	ret := _m.Called(ctx, warehousestoredproduct)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.WarehouseStoredProduct) error); ok {
+		r0 = rf(ctx, warehousestoredproduct)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -2,60 +2,265 @@ package service
 
 import (
 	"context"
+	"errors"
 	"simcomm-monolith/config"
 	"simcomm-monolith/internal/model"
 	"simcomm-monolith/internal/repository"
 	"simcomm-monolith/util"
+	"time"
 
 	log "github.com/labstack/gommon/log"
+	"gorm.io/gorm"
 )
 
+// maxOrderItemQuantity caps the quantity of a single order item
+const maxOrderItemQuantity = 10000
+
 // OrderService defines the methods for the Order service
 type OrderService interface {
 	Create(ctx context.Context, order *model.Order) error
 	Get(ctx context.Context, id int) (*model.Order, error)
 	GetAll(ctx context.Context) ([]model.Order, error)
 	Update(ctx context.Context, order *model.Order) error
+	UpdateStatus(ctx context.Context, id int, req model.OrderStatusRequest) (*model.Order, error)
 	Delete(ctx context.Context, id int) error
 }
 
 type orderService struct {
-	repo      repository.OrderRepository
-	redisRepo repository.RedisRepository
-	cfg       *config.Config
+	repo        repository.OrderRepository
+	userRepo    repository.UserRepository
+	shopRepo    repository.ShopRepository
+	productRepo repository.ProductRepository
+	redisRepo   repository.RedisRepository
+	cfg         *config.Config
 }
 
-func NewOrderService(repo repository.OrderRepository, redisRepo repository.RedisRepository, cfg *config.Config) *orderService {
+func NewOrderService(repo repository.OrderRepository, userRepo repository.UserRepository, shopRepo repository.ShopRepository, productRepo repository.ProductRepository, redisRepo repository.RedisRepository, cfg *config.Config) *orderService {
 	return &orderService{
-		repo:      repo,
-		redisRepo: redisRepo,
-		cfg:       cfg,
+		repo:        repo,
+		userRepo:    userRepo,
+		shopRepo:    shopRepo,
+		productRepo: productRepo,
+		redisRepo:   redisRepo,
+		cfg:         cfg,
 	}
 }
 
+// Create places an order, customers always order for themselves and the order
+// is shipped to the default address of the user when no address is given. Only an
+// user with a verified email or phone can order. Every order starts pending, a
+// status sent by the client is ignored
 func (s *orderService) Create(ctx context.Context, order *model.Order) error {
+	authUser, ok := util.GetAuthUser(ctx)
+	if !ok {
+		return errors.New(util.ErrForbidden)
+	}
+	if !authUser.HasRole(util.RoleAdmin) {
+		order.UserID = authUser.ID
+	}
+
+	user, err := s.userRepo.Get(ctx, order.UserID)
+	if err != nil {
+		log.Error(err)
+		return errors.New(util.ErrUserNotFound)
+	}
+	if !user.UserDetail.EmailVerified && !user.UserDetail.PhoneVerified {
+		return errors.New(util.ErrContactNotVerified)
+	}
+
+	if order.Detail.ShippingAddress != nil {
+		if err := model.ValidateAddress(order.Detail.ShippingAddress); err != nil {
+			return err
+		}
+	} else {
+		order.Detail.ShippingAddress = user.UserDetail.DefaultAddress()
+		if order.Detail.ShippingAddress == nil {
+			return errors.New(util.ErrShippingAddressRequired)
+		}
+	}
+
 	timeNow := util.TimeNow()
+	if err := s.priceItems(ctx, order, timeNow); err != nil {
+		return err
+	}
+
+	order.Status = model.OrderStatusPending
 	order.CreatedAt = timeNow
 	order.UpdatedAt = timeNow
-	err := s.repo.Create(ctx, order)
+	if err := s.repo.Create(ctx, order); err != nil {
+		log.Error(err)
+		return err
+	}
+	return nil
+}
+
+// priceItems fills the order items with the prices in effect when the order is
+// placed and totals the order, prices sent by the client are ignored. Only active
+// listings of active products in an active shop can be ordered
+func (s *orderService) priceItems(ctx context.Context, order *model.Order, at time.Time) error {
+	if len(order.Detail.Items) == 0 {
+		return errors.New(util.ErrOrderItemsRequired)
+	}
+
+	shop, err := s.shopRepo.Get(ctx, order.ShopID)
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New(util.ErrShopNotFound)
+		}
 		log.Error(err)
+		return err
+	}
+	if shop.Status != model.StatusActive {
+		return errors.New(util.ErrShopProductNotAvailable)
+	}
+
+	order.Detail.Currency = ""
+	order.Detail.Total = 0
+	for i := range order.Detail.Items {
+		item := &order.Detail.Items[i]
+		if item.Quantity < 1 || item.Quantity > maxOrderItemQuantity {
+			return errors.New(util.ErrInvalidOrderItem)
+		}
+
+		shopProduct, err := s.shopRepo.ShopProductRepositoryGet(ctx, item.ShopProductID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New(util.ErrShopProductNotFound)
+			}
+			log.Error(err)
+			return err
+		}
+		if shopProduct.ShopID != order.ShopID {
+			return errors.New(util.ErrInvalidOrderItem)
+		}
+		if shopProduct.Status != model.StatusActive {
+			return errors.New(util.ErrShopProductNotAvailable)
+		}
+
+		product, err := s.productRepo.Get(ctx, shopProduct.ProductID)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+		if product.Status != model.StatusActive {
+			return errors.New(util.ErrShopProductNotAvailable)
+		}
+
+		price, err := s.shopRepo.ShopProductRepositoryGetCurrentPrice(ctx, item.ShopProductID, at)
+		if err != nil {
+			if err.Error() != util.ErrPriceNotSet {
+				log.Error(err)
+			}
+			return err
+		}
+
+		if order.Detail.Currency == "" {
+			order.Detail.Currency = price.Currency
+		} else if order.Detail.Currency != price.Currency {
+			return errors.New(util.ErrCurrencyMismatch)
+		}
+
+		item.ProductID = shopProduct.ProductID
+		item.VariantID = shopProduct.VariantID
+		item.UnitPrice = price.Price
+		var ok bool
+		if item.Subtotal, ok = util.MultiplyAmount(price.Price, int64(item.Quantity)); !ok {
+			return errors.New(util.ErrOrderTotalTooLarge)
+		}
+		if order.Detail.Total, ok = util.AddAmount(order.Detail.Total, item.Subtotal); !ok {
+			return errors.New(util.ErrOrderTotalTooLarge)
+		}
 	}
-	return err
+	return nil
 }
 
+// Get returns an order to the user who placed it, to the members of the shop of the
+// order and to admins
 func (s *orderService) Get(ctx context.Context, id int) (*model.Order, error) {
-	return s.repo.Get(ctx, id)
+	order, err := s.getOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	authUser, ok := util.GetAuthUser(ctx)
+	if !ok {
+		return nil, errors.New(util.ErrForbidden)
+	}
+	if order.UserID == authUser.ID {
+		return order, nil
+	}
+	if _, _, err := authorizeShopMember(ctx, s.shopRepo, order.ShopID, shopMemberRoles...); err != nil {
+		return nil, err
+	}
+	return order, nil
 }
 
+// GetAll lists every order to admins, other users only see the orders they placed
+// and the orders of the shops they are members of
 func (s *orderService) GetAll(ctx context.Context) ([]model.Order, error) {
-	return s.repo.GetAll(ctx)
+	authUser, ok := util.GetAuthUser(ctx)
+	if !ok {
+		return nil, errors.New(util.ErrForbidden)
+	}
+	if authUser.HasRole(util.RoleAdmin) {
+		return s.repo.GetAll(ctx)
+	}
+	return s.repo.GetVisibleToUser(ctx, authUser.ID)
 }
 
+// Update changes an order but keeps its stored status, the status only moves
+// through UpdateStatus
 func (s *orderService) Update(ctx context.Context, order *model.Order) error {
+	existing, err := s.getOrder(ctx, order.ID)
+	if err != nil {
+		return err
+	}
+
+	order.Status = existing.Status
+	order.CreatedAt = existing.CreatedAt
+	order.UpdatedAt = util.TimeNow()
 	return s.repo.Update(ctx, order)
 }
 
+// UpdateStatus moves an order along its fulfilment, only the members of the shop
+// of the order and admins can ship, complete or cancel it
+func (s *orderService) UpdateStatus(ctx context.Context, id int, req model.OrderStatusRequest) (*model.Order, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	order, err := s.getOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, err := authorizeShopMember(ctx, s.shopRepo, order.ShopID, shopMemberRoles...); err != nil {
+		return nil, err
+	}
+	if !order.CanTransitionTo(req.Status) {
+		return nil, errors.New(util.ErrInvalidStatusTransition)
+	}
+
+	order.Status = req.Status
+	order.UpdatedAt = util.TimeNow()
+	if err := s.repo.Update(ctx, order); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	return order, nil
+}
+
+func (s *orderService) getOrder(ctx context.Context, id int) (*model.Order, error) {
+	order, err := s.repo.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(util.ErrOrderNotFound)
+		}
+		log.Error(err)
+		return nil, err
+	}
+	return order, nil
+}
+
 func (s *orderService) Delete(ctx context.Context, id int) error {
 	return s.repo.Delete(ctx, id)
 }
@@ -0,0 +1,478 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"simcomm-monolith/internal/model"
+	"simcomm-monolith/internal/repository/mocks"
+	"simcomm-monolith/util"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type orderServiceMocks struct {
+	repo        *mocks.OrderRepository
+	userRepo    *mocks.UserRepository
+	shopRepo    *mocks.ShopRepository
+	productRepo *mocks.ProductRepository
+	redisRepo   *mocks.RedisRepository
+}
+
+func newTestOrderService(t *testing.T) (*orderService, orderServiceMocks) {
+	t.Helper()
+	m := orderServiceMocks{
+		repo:        &mocks.OrderRepository{},
+		userRepo:    &mocks.UserRepository{},
+		shopRepo:    &mocks.ShopRepository{},
+		productRepo: &mocks.ProductRepository{},
+		redisRepo:   &mocks.RedisRepository{},
+	}
+	t.Cleanup(func() {
+		m.repo.AssertExpectations(t)
+		m.userRepo.AssertExpectations(t)
+		m.shopRepo.AssertExpectations(t)
+		m.productRepo.AssertExpectations(t)
+	})
+	return NewOrderService(m.repo, m.userRepo, m.shopRepo, m.productRepo, m.redisRepo, testConfig()), m
+}
+
+func customerContext(userID int) context.Context {
+	return util.SetAuthUser(context.Background(), util.AuthUser{ID: userID, Roles: []string{util.RoleCustomer}})
+}
+
+// expectVerifiedBuyer expects the buyer of the order to have a verified email
+func expectVerifiedBuyer(m orderServiceMocks, userID int) {
+	m.userRepo.On("Get", mock.Anything, userID).Return(&model.User{ID: userID, UserDetail: model.UserDetail{EmailVerified: true}}, nil)
+}
+
+// expectOrderable expects the shop and the product of the order lines to be active
+func expectOrderable(m orderServiceMocks, shopID int) {
+	m.shopRepo.On("Get", mock.Anything, shopID).Return(&model.Shop{ID: shopID, Status: model.StatusActive}, nil)
+	m.productRepo.On("Get", mock.Anything, 10).Return(&model.Product{ID: 10, Status: model.StatusActive}, nil)
+}
+
+// expectPricedItem expects an order line of an active listing priced at 15000 IDR
+func expectPricedItem(m orderServiceMocks, shopProductID int, shopID int) {
+	expectOrderable(m, shopID)
+	m.shopRepo.On("ShopProductRepositoryGet", mock.Anything, shopProductID).Return(&model.ShopProduct{
+		ID:        shopProductID,
+		ProductID: 10,
+		ShopID:    shopID,
+		Status:    model.StatusActive,
+	}, nil)
+	m.shopRepo.On("ShopProductRepositoryGetCurrentPrice", mock.Anything, shopProductID, mock.Anything).Return(&model.ShopProductPrice{
+		ShopProductID: shopProductID,
+		Price:         15000,
+		Currency:      "IDR",
+	}, nil)
+}
+
+func testShippingAddress() *model.UserAddress {
+	return &model.UserAddress{
+		Recipient:  "Jane Doe",
+		Phone:      "081234567890",
+		Street:     "Jl. Sudirman 1",
+		City:       "Jakarta",
+		Country:    "ID",
+		PostalCode: "10220",
+	}
+}
+
+func TestOrderServiceCreateShippingAddress(t *testing.T) {
+	tests := []struct {
+		name        string
+		address     *model.UserAddress
+		setup       func(m orderServiceMocks)
+		wantErr     string
+		wantStreet  string
+		wantPhone   string
+		wantInvalid bool
+	}{
+		{
+			name:    "client address is validated and normalized",
+			address: testShippingAddress(),
+			setup: func(m orderServiceMocks) {
+				expectPricedItem(m, 1, 7)
+				m.repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+			},
+			wantStreet: "Jl. Sudirman 1",
+			wantPhone:  "+6281234567890",
+		},
+		{
+			name:        "invalid client address",
+			address:     &model.UserAddress{Recipient: "Jane Doe", City: "Jakarta"},
+			setup:       func(m orderServiceMocks) {},
+			wantInvalid: true,
+		},
+		{
+			name: "default address of the user",
+			setup: func(m orderServiceMocks) {
+				m.userRepo.On("Get", mock.Anything, 1).Return(&model.User{ID: 1, UserDetail: model.UserDetail{
+					EmailVerified: true,
+					Addresses: []model.UserAddress{
+						{ID: 1, Street: "Old Street"},
+						{ID: 2, Street: "Default Street", IsDefault: true},
+					},
+				}}, nil)
+				expectPricedItem(m, 1, 7)
+				m.repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+			},
+			wantStreet: "Default Street",
+		},
+		{
+			name: "no address at all",
+			setup: func(m orderServiceMocks) {
+				m.userRepo.On("Get", mock.Anything, 1).Return(&model.User{ID: 1, UserDetail: model.UserDetail{PhoneVerified: true}}, nil)
+			},
+			wantErr: util.ErrShippingAddressRequired,
+		},
+		{
+			name:    "buyer without a verified email or phone",
+			address: testShippingAddress(),
+			setup: func(m orderServiceMocks) {
+				m.userRepo.On("Get", mock.Anything, 1).Return(&model.User{ID: 1}, nil)
+			},
+			wantErr: util.ErrContactNotVerified,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newTestOrderService(t)
+			tt.setup(m)
+			expectVerifiedBuyer(m, 1)
+
+			order := &model.Order{
+				ShopID: 7,
+				Detail: model.OrderDetail{
+					ShippingAddress: tt.address,
+					Items:           []model.OrderItem{{ShopProductID: 1, Quantity: 2}},
+				},
+			}
+			err := svc.Create(customerContext(1), order)
+			switch {
+			case tt.wantInvalid:
+				var errs model.ValidationErrors
+				assert.ErrorAs(t, err, &errs)
+			case tt.wantErr != "":
+				assert.EqualError(t, err, tt.wantErr)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantStreet, order.Detail.ShippingAddress.Street)
+				if tt.wantPhone != "" {
+					assert.Equal(t, tt.wantPhone, order.Detail.ShippingAddress.Phone)
+				}
+			}
+		})
+	}
+}
+
+func TestOrderServiceCreateTotals(t *testing.T) {
+	tests := []struct {
+		name      string
+		items     []model.OrderItem
+		price     int64
+		wantErr   string
+		wantTotal int64
+	}{
+		{
+			name:      "subtotals add up to the total",
+			items:     []model.OrderItem{{ShopProductID: 1, Quantity: 2}, {ShopProductID: 1, Quantity: 3}},
+			price:     15000,
+			wantTotal: 75000,
+		},
+		{
+			name:    "quantity above the cap",
+			items:   []model.OrderItem{{ShopProductID: 1, Quantity: maxOrderItemQuantity + 1}},
+			price:   15000,
+			wantErr: util.ErrInvalidOrderItem,
+		},
+		{
+			name:    "subtotal overflow",
+			items:   []model.OrderItem{{ShopProductID: 1, Quantity: 2}},
+			price:   math.MaxInt64/2 + 1,
+			wantErr: util.ErrOrderTotalTooLarge,
+		},
+		{
+			name:    "total overflow",
+			items:   []model.OrderItem{{ShopProductID: 1, Quantity: 1}, {ShopProductID: 1, Quantity: 1}},
+			price:   math.MaxInt64/2 + 1,
+			wantErr: util.ErrOrderTotalTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newTestOrderService(t)
+			expectVerifiedBuyer(m, 1)
+			m.shopRepo.On("Get", mock.Anything, 7).Return(&model.Shop{ID: 7, Status: model.StatusActive}, nil)
+			m.productRepo.On("Get", mock.Anything, 10).Return(&model.Product{ID: 10, Status: model.StatusActive}, nil).Maybe()
+			m.shopRepo.On("ShopProductRepositoryGet", mock.Anything, 1).Return(&model.ShopProduct{
+				ID:        1,
+				ProductID: 10,
+				ShopID:    7,
+				Status:    model.StatusActive,
+			}, nil).Maybe()
+			m.shopRepo.On("ShopProductRepositoryGetCurrentPrice", mock.Anything, 1, mock.Anything).Return(&model.ShopProductPrice{
+				ShopProductID: 1,
+				Price:         tt.price,
+				Currency:      "IDR",
+			}, nil).Maybe()
+			if tt.wantErr == "" {
+				m.repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+			}
+
+			order := &model.Order{
+				ShopID: 7,
+				Detail: model.OrderDetail{
+					ShippingAddress: testShippingAddress(),
+					Items:           tt.items,
+				},
+			}
+			err := svc.Create(customerContext(1), order)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTotal, order.Detail.Total)
+			assert.Equal(t, "IDR", order.Detail.Currency)
+		})
+	}
+}
+
+func TestOrderServiceCreateAvailability(t *testing.T) {
+	tests := []struct {
+		name          string
+		shopStatus    model.Status
+		listingStatus model.Status
+		productStatus model.Status
+		wantErr       string
+	}{
+		{name: "everything active", shopStatus: model.StatusActive, listingStatus: model.StatusActive, productStatus: model.StatusActive},
+		{name: "suspended shop", shopStatus: model.StatusSuspended, wantErr: util.ErrShopProductNotAvailable},
+		{name: "draft listing", shopStatus: model.StatusActive, listingStatus: model.StatusDraft, wantErr: util.ErrShopProductNotAvailable},
+		{name: "archived listing", shopStatus: model.StatusActive, listingStatus: model.StatusArchived, wantErr: util.ErrShopProductNotAvailable},
+		{name: "suspended product", shopStatus: model.StatusActive, listingStatus: model.StatusActive, productStatus: model.StatusSuspended, wantErr: util.ErrShopProductNotAvailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newTestOrderService(t)
+			expectVerifiedBuyer(m, 1)
+			m.shopRepo.On("Get", mock.Anything, 7).Return(&model.Shop{ID: 7, Status: tt.shopStatus}, nil)
+			if tt.listingStatus != "" {
+				m.shopRepo.On("ShopProductRepositoryGet", mock.Anything, 1).Return(&model.ShopProduct{
+					ID: 1, ProductID: 10, ShopID: 7, Status: tt.listingStatus,
+				}, nil)
+			}
+			if tt.productStatus != "" {
+				m.productRepo.On("Get", mock.Anything, 10).Return(&model.Product{ID: 10, Status: tt.productStatus}, nil)
+			}
+			if tt.wantErr == "" {
+				m.shopRepo.On("ShopProductRepositoryGetCurrentPrice", mock.Anything, 1, mock.Anything).Return(&model.ShopProductPrice{
+					ShopProductID: 1, Price: 15000, Currency: "IDR",
+				}, nil)
+				m.repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+			}
+
+			order := &model.Order{
+				ShopID: 7,
+				Detail: model.OrderDetail{
+					ShippingAddress: testShippingAddress(),
+					Items:           []model.OrderItem{{ShopProductID: 1, Quantity: 1}},
+				},
+			}
+			err := svc.Create(customerContext(1), order)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestOrderServiceCreateIgnoresClientStatus(t *testing.T) {
+	svc, m := newTestOrderService(t)
+	expectVerifiedBuyer(m, 1)
+	expectPricedItem(m, 1, 7)
+	m.repo.On("Create", mock.Anything, mock.MatchedBy(func(order *model.Order) bool {
+		return order.Status == model.OrderStatusPending
+	})).Return(nil)
+
+	order := &model.Order{
+		ShopID: 7,
+		Status: model.OrderStatusCompleted,
+		Detail: model.OrderDetail{
+			ShippingAddress: testShippingAddress(),
+			Items:           []model.OrderItem{{ShopProductID: 1, Quantity: 1}},
+		},
+	}
+	require.NoError(t, svc.Create(customerContext(1), order))
+	assert.Equal(t, model.OrderStatusPending, order.Status)
+}
+
+func TestOrderServiceUpdateKeepsStatus(t *testing.T) {
+	svc, m := newTestOrderService(t)
+	m.repo.On("Get", mock.Anything, 1).Return(&model.Order{ID: 1, ShopID: 7, Status: model.OrderStatusPending}, nil)
+	m.repo.On("Update", mock.Anything, mock.MatchedBy(func(order *model.Order) bool {
+		return order.Status == model.OrderStatusPending
+	})).Return(nil)
+
+	order := &model.Order{ID: 1, ShopID: 7, Status: model.OrderStatusCompleted}
+	adminCtx := util.SetAuthUser(context.Background(), util.AuthUser{ID: 99, Roles: []string{util.RoleAdmin}})
+	require.NoError(t, svc.Update(adminCtx, order))
+	assert.Equal(t, model.OrderStatusPending, order.Status)
+}
+
+func TestOrderServiceUpdateStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		stored  string
+		status  string
+		setup   func(m orderServiceMocks)
+		wantErr string
+	}{
+		{
+			name:   "owner of the shop ships the order",
+			ctx:    sellerContext(5),
+			stored: model.OrderStatusPending,
+			status: model.OrderStatusShipped,
+		},
+		{
+			name:   "staff of the shop completes a shipped order",
+			ctx:    util.SetAuthUser(context.Background(), util.AuthUser{ID: 6, Roles: []string{util.RoleWarehouseStaff}}),
+			stored: model.OrderStatusShipped,
+			status: model.OrderStatusCompleted,
+			setup: func(m orderServiceMocks) {
+				m.shopRepo.On("ShopMemberRepositoryGet", mock.Anything, 7, 6).Return(&model.ShopMember{ShopID: 7, UserID: 6, Role: model.ShopRoleStaff}, nil)
+			},
+		},
+		{
+			name:   "admin completes a shipped order",
+			ctx:    util.SetAuthUser(context.Background(), util.AuthUser{ID: 99, Roles: []string{util.RoleAdmin}}),
+			stored: model.OrderStatusShipped,
+			status: model.OrderStatusCompleted,
+		},
+		{
+			name:    "pending order cannot be completed",
+			ctx:     sellerContext(5),
+			stored:  model.OrderStatusPending,
+			status:  model.OrderStatusCompleted,
+			wantErr: util.ErrInvalidStatusTransition,
+		},
+		{
+			name:   "buyer cannot complete the order",
+			ctx:    customerContext(1),
+			stored: model.OrderStatusShipped,
+			status: model.OrderStatusCompleted,
+			setup: func(m orderServiceMocks) {
+				m.shopRepo.On("ShopMemberRepositoryGet", mock.Anything, 7, 1).Return(nil, errors.New(util.ErrShopMemberNotFound))
+			},
+			wantErr: util.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newTestOrderService(t)
+			m.repo.On("Get", mock.Anything, 1).Return(&model.Order{ID: 1, UserID: 1, ShopID: 7, Status: tt.stored}, nil)
+			m.shopRepo.On("Get", mock.Anything, 7).Return(&model.Shop{ID: 7, UserID: 5, Status: model.StatusActive}, nil)
+			if tt.setup != nil {
+				tt.setup(m)
+			}
+			if tt.wantErr == "" {
+				m.repo.On("Update", mock.Anything, mock.Anything).Return(nil)
+			}
+
+			order, err := svc.UpdateStatus(tt.ctx, 1, model.OrderStatusRequest{Status: tt.status})
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.status, order.Status)
+		})
+	}
+
+	svc, _ := newTestOrderService(t)
+	_, err := svc.UpdateStatus(sellerContext(5), 1, model.OrderStatusRequest{Status: "delivered"})
+	var errs model.ValidationErrors
+	assert.ErrorAs(t, err, &errs)
+}
+
+func TestOrderServiceGet(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		setup   func(m orderServiceMocks)
+		wantErr string
+	}{
+		{
+			name: "buyer reads the order",
+			ctx:  customerContext(1),
+		},
+		{
+			name: "staff of the shop reads the order",
+			ctx:  util.SetAuthUser(context.Background(), util.AuthUser{ID: 6, Roles: []string{util.RoleWarehouseStaff}}),
+			setup: func(m orderServiceMocks) {
+				m.shopRepo.On("Get", mock.Anything, 7).Return(&model.Shop{ID: 7, UserID: 5}, nil)
+				m.shopRepo.On("ShopMemberRepositoryGet", mock.Anything, 7, 6).Return(&model.ShopMember{ShopID: 7, UserID: 6, Role: model.ShopRoleStaff}, nil)
+			},
+		},
+		{
+			name: "admin reads any order",
+			ctx:  util.SetAuthUser(context.Background(), util.AuthUser{ID: 99, Roles: []string{util.RoleAdmin}}),
+			setup: func(m orderServiceMocks) {
+				m.shopRepo.On("Get", mock.Anything, 7).Return(&model.Shop{ID: 7, UserID: 5}, nil)
+			},
+		},
+		{
+			name: "another customer cannot read the order",
+			ctx:  customerContext(2),
+			setup: func(m orderServiceMocks) {
+				m.shopRepo.On("Get", mock.Anything, 7).Return(&model.Shop{ID: 7, UserID: 5}, nil)
+				m.shopRepo.On("ShopMemberRepositoryGet", mock.Anything, 7, 2).Return(nil, errors.New(util.ErrShopMemberNotFound))
+			},
+			wantErr: util.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newTestOrderService(t)
+			m.repo.On("Get", mock.Anything, 1).Return(&model.Order{ID: 1, UserID: 1, ShopID: 7}, nil)
+			if tt.setup != nil {
+				tt.setup(m)
+			}
+
+			order, err := svc.Get(tt.ctx, 1)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 1, order.ID)
+		})
+	}
+}
+
+func TestOrderServiceGetAll(t *testing.T) {
+	svc, m := newTestOrderService(t)
+	m.repo.On("GetVisibleToUser", mock.Anything, 1).Return([]model.Order{{ID: 1, UserID: 1}}, nil)
+	orders, err := svc.GetAll(customerContext(1))
+	require.NoError(t, err)
+	assert.Len(t, orders, 1)
+
+	svc, m = newTestOrderService(t)
+	m.repo.On("GetAll", mock.Anything).Return([]model.Order{{ID: 1}, {ID: 2}}, nil)
+	orders, err = svc.GetAll(util.SetAuthUser(context.Background(), util.AuthUser{ID: 99, Roles: []string{util.RoleAdmin}}))
+	require.NoError(t, err)
+	assert.Len(t, orders, 2)
+}
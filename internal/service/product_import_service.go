@@ -0,0 +1,457 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"simcomm-monolith/internal/model"
+	"simcomm-monolith/util"
+	"strconv"
+	"strings"
+
+	log "github.com/labstack/gommon/log"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// productCSVColumns are the columns of an exported CSV, an import needs at least
+// code and name. Attributes are a JSON object
+var productCSVColumns = []string{"code", "name", "category_id", "status", "weight", "image_url", "attributes"}
+
+const exportBatchSize = 500
+
+// ProductImportService defines the methods for the product Import service
+type ProductImportService interface {
+	ImportServiceCreate(ctx context.Context, format string, file io.Reader) (*model.ProductImportJob, error)
+	ImportServiceGet(ctx context.Context, id int) (*model.ProductImportJob, error)
+	ImportServiceExport(ctx context.Context, format string, w io.Writer) error
+	ProcessImportQueue(ctx context.Context, msg amqp.Delivery) error
+}
+
+// ImportServiceCreate parses the file and upserts its products right away, or
+// hands them to the import worker when the file has more rows than the sync limit.
+// The status of a row only applies to a new product
+func (s *productService) ImportServiceCreate(ctx context.Context, format string, file io.Reader) (*model.ProductImportJob, error) {
+	authUser, ok := util.GetAuthUser(ctx)
+	if !ok {
+		return nil, errors.New(util.ErrForbidden)
+	}
+
+	cfg := s.cfg.ProductImportConfig
+	data, err := io.ReadAll(io.LimitReader(file, cfg.MaxFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, errors.New(util.ErrImportFileRequired)
+	}
+	if int64(len(data)) > cfg.MaxFileSize {
+		return nil, errors.New(util.ErrImportTooLarge)
+	}
+
+	var rows []model.ProductImportRow
+	switch format {
+	case model.ImportFormatCSV:
+		rows, err = parseProductCSV(data)
+	case model.ImportFormatJSONL:
+		rows, err = parseProductJSONL(data)
+	default:
+		return nil, errors.New(util.ErrInvalidImportFormat)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) > cfg.MaxRows {
+		return nil, errors.New(util.ErrImportTooLarge)
+	}
+
+	timeNow := util.TimeNow()
+	job := &model.ProductImportJob{
+		UserID:    authUser.ID,
+		Format:    format,
+		Status:    model.ImportStatusPending,
+		Detail:    model.ProductImportJobDetail{Total: len(rows), Errors: []model.ImportRowError{}},
+		CreatedAt: timeNow,
+		UpdatedAt: timeNow,
+	}
+	if err := s.repo.ImportJobRepositoryCreate(ctx, job); err != nil {
+		return nil, err
+	}
+
+	if len(rows) <= cfg.SyncRowLimit {
+		if err := s.importRows(ctx, job, rows); err != nil {
+			return nil, err
+		}
+		return job, nil
+	}
+
+	message := model.ProductImportMessage{JobID: job.ID, Roles: authUser.Roles, Rows: rows}
+	if err := s.importQueue.Publish(ctx, message); err != nil {
+		log.Error(err)
+		job.Status = model.ImportStatusFailed
+		job.Detail.Message = "could not be queued"
+		job.UpdatedAt = util.TimeNow()
+		s.repo.ImportJobRepositoryUpdate(ctx, job)
+		return nil, err
+	}
+	return job, nil
+}
+
+// ImportServiceGet returns the report of an import job to the user who started it
+func (s *productService) ImportServiceGet(ctx context.Context, id int) (*model.ProductImportJob, error) {
+	authUser, ok := util.GetAuthUser(ctx)
+	if !ok {
+		return nil, errors.New(util.ErrForbidden)
+	}
+
+	job, err := s.repo.ImportJobRepositoryGet(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !authUser.HasRole(util.RoleAdmin) && job.UserID != authUser.ID {
+		return nil, errors.New(util.ErrImportJobNotFound)
+	}
+	return job, nil
+}
+
+// ImportServiceExport writes every product in the format accepted by the import
+func (s *productService) ImportServiceExport(ctx context.Context, format string, w io.Writer) error {
+	switch format {
+	case model.ImportFormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(productCSVColumns); err != nil {
+			return err
+		}
+		err := s.repo.FindInBatches(ctx, exportBatchSize, func(products []model.Product) error {
+			for _, product := range products {
+				record, err := productCSVRecord(product)
+				if err != nil {
+					return err
+				}
+				if err := writer.Write(record); err != nil {
+					return err
+				}
+			}
+			writer.Flush()
+			return writer.Error()
+		})
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+		writer.Flush()
+		return writer.Error()
+	case model.ImportFormatJSONL:
+		encoder := json.NewEncoder(w)
+		err := s.repo.FindInBatches(ctx, exportBatchSize, func(products []model.Product) error {
+			for _, product := range products {
+				if err := encoder.Encode(product); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			log.Error(err)
+		}
+		return err
+	}
+	return errors.New(util.ErrInvalidImportFormat)
+}
+
+// ProcessImportQueue imports the rows of a large file, a job that is already
+// finished is skipped so a redelivered message is not imported twice
+func (s *productService) ProcessImportQueue(ctx context.Context, msg amqp.Delivery) error {
+	var message model.ProductImportMessage
+	if err := json.Unmarshal(msg.Body, &message); err != nil {
+		log.Error(err)
+		return nil
+	}
+
+	job, err := s.repo.ImportJobRepositoryGet(ctx, message.JobID)
+	if err != nil {
+		log.Error(err)
+		if err.Error() == util.ErrImportJobNotFound {
+			return nil
+		}
+		return err
+	}
+	if job.Status == model.ImportStatusCompleted || job.Status == model.ImportStatusFailed {
+		return nil
+	}
+
+	// the rows are imported as the user who started the import
+	ctx = util.SetAuthUser(ctx, util.AuthUser{ID: job.UserID, Roles: message.Roles})
+	return s.importRows(ctx, job, message.Rows)
+}
+
+// importRows validates and upserts every row, a row that fails is reported in the
+// job without stopping the import. A row only updates a product the importer may change
+func (s *productService) importRows(ctx context.Context, job *model.ProductImportJob, rows []model.ProductImportRow) error {
+	job.Status = model.ImportStatusProcessing
+	job.Detail = model.ProductImportJobDetail{Total: len(rows), Errors: []model.ImportRowError{}}
+	job.UpdatedAt = util.TimeNow()
+	if err := s.repo.ImportJobRepositoryUpdate(ctx, job); err != nil {
+		return err
+	}
+
+	schemas := make(map[int]*model.CategorySchema)
+	codeRows := make(map[string]int, len(rows))
+	var updatedIDs []int
+	for _, row := range rows {
+		product := row.Product
+		errs := s.validateImportRow(ctx, row, schemas)
+		if firstRow, ok := codeRows[product.Code]; ok && product.Code != "" {
+			errs.Add("code", fmt.Sprintf("is duplicated, first used in row %v", firstRow))
+		} else {
+			codeRows[product.Code] = row.Row
+		}
+
+		if len(errs) == 0 {
+			if err := applyInitialStatus(ctx, &product, product.Status); err != nil {
+				errs.Add("status", err.Error())
+			}
+		}
+
+		if len(errs) == 0 {
+			timeNow := util.TimeNow()
+			product.UserID = &job.UserID
+			product.ShopID = nil
+			product.CreatedAt = timeNow
+			product.UpdatedAt = timeNow
+			created, err := s.repo.UpsertByCode(ctx, &product, func(existing *model.Product) error {
+				return s.authorizeProduct(ctx, existing)
+			})
+			switch {
+			case err != nil && err.Error() == util.ErrForbidden:
+				errs.Add("code", "belongs to a product you cannot change")
+			case err != nil:
+				errs.Add("product", "could not be saved")
+			case created:
+				job.Detail.Created++
+			default:
+				job.Detail.Updated++
+				updatedIDs = append(updatedIDs, product.ID)
+			}
+		}
+
+		if len(errs) > 0 {
+			job.Detail.Failed++
+			job.Detail.Errors = append(job.Detail.Errors, model.ImportRowError{Row: row.Row, Code: product.Code, Errors: errs})
+		}
+	}
+
+	// created products are not listed by any shop yet
+	if len(updatedIDs) > 0 {
+		invalidateProductStorefronts(ctx, s.redisRepo, s.repo, updatedIDs...)
+	}
+
+	timeNow := util.TimeNow()
+	job.Status = model.ImportStatusCompleted
+	job.UpdatedAt = timeNow
+	job.FinishedAt = &timeNow
+	return s.repo.ImportJobRepositoryUpdate(ctx, job)
+}
+
+// validateImportRow checks a row like a product create, the category schemas are
+// cached for the whole import
+func (s *productService) validateImportRow(ctx context.Context, row model.ProductImportRow, schemas map[int]*model.CategorySchema) model.ValidationErrors {
+	errs := append(model.ValidationErrors{}, row.Errors...)
+	product := row.Product
+	if product.Code == "" {
+		errs.Add("code", "is required")
+	}
+	if product.Name == "" {
+		errs.Add("name", "is required")
+	}
+
+	if product.CategoryID == nil {
+		if len(product.Detail.Attributes) > 0 {
+			errs.Add("category_id", "is required when attributes are set")
+		}
+		return errs
+	}
+
+	schema, ok := schemas[*product.CategoryID]
+	if !ok {
+		var err error
+		if schema, err = s.CategoryServiceGet(ctx, *product.CategoryID); err != nil {
+			log.Error(err)
+			schema = nil
+		}
+		schemas[*product.CategoryID] = schema
+	}
+	if schema == nil {
+		errs.Add("category_id", "does not exist")
+		return errs
+	}
+
+	if err := schema.ValidateAttributes(product.Detail.Attributes); err != nil {
+		if fieldErrs, ok := err.(model.ValidationErrors); ok {
+			return append(errs, fieldErrs...)
+		}
+		errs.Add("detail.attributes", err.Error())
+	}
+	return errs
+}
+
+// parseProductCSV reads a CSV with a header row, a row that cannot be parsed is
+// kept with its errors so it is reported with the other invalid rows
+func parseProductCSV(data []byte) ([]model.ProductImportRow, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, model.ValidationErrors{{Field: "header", Message: "is missing or invalid"}}
+	}
+
+	var errs model.ValidationErrors
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !isProductCSVColumn(name) {
+			errs.Add("header", fmt.Sprintf("has unknown column %v", name))
+		}
+		columns[name] = i
+	}
+	for _, required := range []string{"code", "name"} {
+		if _, ok := columns[required]; !ok {
+			errs.Add("header", fmt.Sprintf("is missing column %v", required))
+		}
+	}
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+
+	var rows []model.ProductImportRow
+	for i := 1; ; i++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, err
+			}
+			rows = append(rows, model.ProductImportRow{Row: i, Errors: model.ValidationErrors{{Field: "row", Message: parseErr.Err.Error()}}})
+			continue
+		}
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+		rows = append(rows, parseProductCSVRecord(i, record, columns))
+	}
+	return rows, nil
+}
+
+func isProductCSVColumn(name string) bool {
+	for _, column := range productCSVColumns {
+		if column == name {
+			return true
+		}
+	}
+	return false
+}
+
+func parseProductCSVRecord(i int, record []string, columns map[string]int) model.ProductImportRow {
+	row := model.ProductImportRow{Row: i}
+	value := func(column string) string {
+		index, ok := columns[column]
+		if !ok || index >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[index])
+	}
+
+	if len(record) != len(columns) {
+		row.Errors.Add("row", fmt.Sprintf("has %v columns, expected %v", len(record), len(columns)))
+	}
+
+	row.Product.Code = value("code")
+	row.Product.Name = value("name")
+	row.Product.Status = model.Status(value("status"))
+	row.Product.Detail.ImageURL = value("image_url")
+
+	if categoryID := value("category_id"); categoryID != "" {
+		id, err := strconv.Atoi(categoryID)
+		if err != nil {
+			row.Errors.Add("category_id", "must be a number")
+		} else {
+			row.Product.CategoryID = &id
+		}
+	}
+	if weight := value("weight"); weight != "" {
+		var err error
+		if row.Product.Detail.Weight, err = strconv.Atoi(weight); err != nil {
+			row.Errors.Add("weight", "must be a number")
+		}
+	}
+	if attributes := value("attributes"); attributes != "" {
+		if err := json.Unmarshal([]byte(attributes), &row.Product.Detail.Attributes); err != nil {
+			row.Errors.Add("attributes", "must be a JSON object")
+		}
+	}
+	return row
+}
+
+// parseProductJSONL reads one product per line in the same JSON as the product API
+func parseProductJSONL(data []byte) ([]model.ProductImportRow, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var rows []model.ProductImportRow
+	for i := 1; scanner.Scan(); i++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		row := model.ProductImportRow{Row: i}
+		if err := json.Unmarshal(line, &row.Product); err != nil {
+			row.Errors.Add("row", "must be a JSON object of a product")
+		}
+		row.Product = model.Product{
+			Code:       strings.TrimSpace(row.Product.Code),
+			Name:       strings.TrimSpace(row.Product.Name),
+			CategoryID: row.Product.CategoryID,
+			Status:     row.Product.Status,
+			Detail:     model.ProductDetail{Weight: row.Product.Detail.Weight, ImageURL: row.Product.Detail.ImageURL, Attributes: row.Product.Detail.Attributes},
+		}
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, model.ValidationErrors{{Field: "file", Message: err.Error()}}
+	}
+	return rows, nil
+}
+
+func productCSVRecord(product model.Product) ([]string, error) {
+	categoryID := ""
+	if product.CategoryID != nil {
+		categoryID = strconv.Itoa(*product.CategoryID)
+	}
+	attributes := ""
+	if len(product.Detail.Attributes) > 0 {
+		encoded, err := json.Marshal(product.Detail.Attributes)
+		if err != nil {
+			return nil, err
+		}
+		attributes = string(encoded)
+	}
+	return []string{
+		product.Code,
+		product.Name,
+		categoryID,
+		string(product.Status),
+		strconv.Itoa(product.Detail.Weight),
+		product.Detail.ImageURL,
+		attributes,
+	}, nil
+}
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"simcomm-monolith/internal/model"
+	"simcomm-monolith/util"
+	"strings"
+	"testing"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func sellerContext(userID int) context.Context {
+	return util.SetAuthUser(context.Background(), util.AuthUser{ID: userID, Roles: []string{util.RoleSeller}})
+}
+
+func rowFields(row model.ProductImportRow) []string {
+	var fields []string
+	for _, fe := range row.Errors {
+		fields = append(fields, fe.Field)
+	}
+	return fields
+}
+
+func TestParseProductCSV(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       string
+		wantCodes  []string
+		wantFields [][]string
+		wantErr    bool
+	}{
+		{
+			name:       "rows with a byte order mark",
+			data:       "\xef\xbb\xbfcode,name,category_id,weight,attributes\nP-1,Runner,3,500,\"{\"\"size\"\": 42}\"\nP-2,Walker,,,\n",
+			wantCodes:  []string{"P-1", "P-2"},
+			wantFields: [][]string{nil, nil},
+		},
+		{
+			name:       "invalid values are kept as row errors",
+			data:       "code,name,category_id,weight,attributes\nP-1,Runner,three,heavy,[1]\nP-2,Walker\n",
+			wantCodes:  []string{"P-1", "P-2"},
+			wantFields: [][]string{{"category_id", "weight", "attributes"}, {"row"}},
+		},
+		{
+			name:       "blank lines are skipped",
+			data:       "code,name\n\nP-1,Runner\n\n",
+			wantCodes:  []string{"P-1"},
+			wantFields: [][]string{nil},
+		},
+		{name: "missing required column", data: "code,weight\nP-1,500\n", wantErr: true},
+		{name: "unknown column", data: "code,name,colour\nP-1,Runner,black\n", wantErr: true},
+		{name: "empty file", data: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := parseProductCSV([]byte(tt.data))
+			if tt.wantErr {
+				var errs model.ValidationErrors
+				assert.ErrorAs(t, err, &errs)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, rows, len(tt.wantCodes))
+			for i, row := range rows {
+				assert.Equal(t, tt.wantCodes[i], row.Product.Code)
+				assert.Equal(t, tt.wantFields[i], rowFields(row))
+			}
+		})
+	}
+
+	rows, err := parseProductCSV([]byte("code,name,category_id,weight,attributes\nP-1,Runner,3,500,\"{\"\"size\"\": 42}\"\n"))
+	require.NoError(t, err)
+	assert.Equal(t, 3, *rows[0].Product.CategoryID)
+	assert.Equal(t, 500, rows[0].Product.Detail.Weight)
+	assert.Equal(t, map[string]interface{}{"size": 42.0}, rows[0].Product.Detail.Attributes)
+}
+
+func TestParseProductJSONL(t *testing.T) {
+	data := strings.Join([]string{
+		`{"code": " P-1 ", "name": "Runner", "category_id": 3, "detail": {"weight": 500, "attributes": {"size": 42}}}`,
+		``,
+		`{"code": "P-2", "id": 99, "created_at": "2020-01-01T00:00:00Z"}`,
+		`not json`,
+	}, "\n")
+
+	rows, err := parseProductJSONL([]byte(data))
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+
+	assert.Equal(t, 1, rows[0].Row)
+	assert.Equal(t, "P-1", rows[0].Product.Code)
+	assert.Equal(t, 3, *rows[0].Product.CategoryID)
+	assert.Equal(t, 500, rows[0].Product.Detail.Weight)
+
+	assert.Equal(t, 3, rows[1].Row, "row numbers count the blank lines")
+	assert.Zero(t, rows[1].Product.ID, "only importable fields are kept")
+	assert.True(t, rows[1].Product.CreatedAt.IsZero())
+
+	assert.Equal(t, 4, rows[2].Row)
+	assert.Equal(t, []string{"row"}, rowFields(rows[2]))
+}
+
+// upsertExisting answers UpsertByCode as if the product with the code already exists
+func upsertExisting(existing *model.Product) func(context.Context, *model.Product, func(*model.Product) error) error {
+	return func(ctx context.Context, product *model.Product, authorize func(*model.Product) error) error {
+		return authorize(existing)
+	}
+}
+
+func TestProductServiceImportServiceCreate(t *testing.T) {
+	tests := []struct {
+		name        string
+		format      string
+		data        string
+		setup       func(m productServiceMocks)
+		wantErr     string
+		wantStatus  string
+		wantCreated int
+		wantUpdated int
+		wantFailed  int
+	}{
+		{
+			name:   "small file is imported right away",
+			format: model.ImportFormatCSV,
+			data:   "code,name\nP-1,Runner\nP-2,Walker\nP-1,Runner again\n,Nameless\nP-3,Stranger\n",
+			setup: func(m productServiceMocks) {
+				m.repo.On("UpsertByCode", mock.Anything, mock.MatchedBy(func(product *model.Product) bool {
+					return product.Code == "P-1" && *product.UserID == 1
+				}), mock.Anything).Return(true, nil).Once()
+				m.repo.On("UpsertByCode", mock.Anything, mock.MatchedBy(func(product *model.Product) bool {
+					return product.Code == "P-2"
+				}), mock.Anything).Run(func(args mock.Arguments) {
+					args.Get(1).(*model.Product).ID = 2
+				}).Return(false, upsertExisting(&model.Product{ID: 2, UserID: intPtr(1)})).Once()
+				m.repo.On("UpsertByCode", mock.Anything, mock.MatchedBy(func(product *model.Product) bool {
+					return product.Code == "P-3"
+				}), mock.Anything).Return(false, upsertExisting(&model.Product{ID: 3, UserID: intPtr(9)})).Once()
+				m.repo.On("GetShopIDs", mock.Anything, 2).Return([]int{4}, nil)
+				m.redisRepo.On("DeleteStorefronts", mock.Anything, 4).Return(nil)
+			},
+			wantStatus:  model.ImportStatusCompleted,
+			wantCreated: 1,
+			wantUpdated: 1,
+			wantFailed:  3,
+		},
+		{
+			name:   "large file is queued",
+			format: model.ImportFormatJSONL,
+			data:   strings.Repeat(`{"code": "P-1", "name": "Runner"}`+"\n", 6),
+			setup: func(m productServiceMocks) {
+				m.queue.On("Publish", mock.Anything, mock.MatchedBy(func(message model.ProductImportMessage) bool {
+					return message.JobID == 1 && len(message.Rows) == 6 && message.Roles[0] == util.RoleSeller
+				})).Return(nil)
+			},
+			wantStatus: model.ImportStatusPending,
+		},
+		{name: "unknown format", format: "xml", data: "<products/>", setup: func(m productServiceMocks) {}, wantErr: util.ErrInvalidImportFormat},
+		{name: "empty file", format: model.ImportFormatCSV, setup: func(m productServiceMocks) {}, wantErr: util.ErrImportFileRequired},
+		{name: "file too large", format: model.ImportFormatCSV, data: strings.Repeat("x", 1025), setup: func(m productServiceMocks) {}, wantErr: util.ErrImportTooLarge},
+		{name: "too many rows", format: model.ImportFormatCSV, data: "code,name\n" + strings.Repeat("P-1,Runner\n", 11), setup: func(m productServiceMocks) {}, wantErr: util.ErrImportTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newTestProductService(t)
+			svc.cfg.ProductImportConfig.MaxFileSize = 1024
+			svc.cfg.ProductImportConfig.MaxRows = 10
+			svc.cfg.ProductImportConfig.SyncRowLimit = 5
+			if tt.wantErr == "" {
+				m.repo.On("ImportJobRepositoryCreate", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					args.Get(1).(*model.ProductImportJob).ID = 1
+				}).Return(nil)
+				m.repo.On("ImportJobRepositoryUpdate", mock.Anything, mock.Anything).Return(nil).Maybe()
+			}
+			tt.setup(m)
+
+			job, err := svc.ImportServiceCreate(sellerContext(1), tt.format, strings.NewReader(tt.data))
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, job.Status)
+			assert.Equal(t, 1, job.UserID)
+			assert.Equal(t, tt.wantCreated, job.Detail.Created)
+			assert.Equal(t, tt.wantUpdated, job.Detail.Updated)
+			assert.Equal(t, tt.wantFailed, job.Detail.Failed)
+			m.queue.AssertExpectations(t)
+		})
+	}
+}
+
+func TestProductServiceProcessImportQueue(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      string
+		wantUpserts int
+	}{
+		{"pending job is imported", model.ImportStatusPending, 1},
+		{"redelivered message of a finished job is skipped", model.ImportStatusCompleted, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newTestProductService(t)
+			m.repo.On("ImportJobRepositoryGet", mock.Anything, 1).Return(&model.ProductImportJob{ID: 1, UserID: 7, Status: tt.status}, nil)
+			if tt.wantUpserts > 0 {
+				m.repo.On("ImportJobRepositoryUpdate", mock.Anything, mock.Anything).Return(nil).Twice()
+				m.repo.On("UpsertByCode", mock.Anything, mock.MatchedBy(func(product *model.Product) bool {
+					return product.Status == model.StatusDraft
+				}), mock.Anything).Return(true, nil).Times(tt.wantUpserts)
+			}
+
+			body, err := json.Marshal(model.ProductImportMessage{JobID: 1, Rows: []model.ProductImportRow{
+				{Row: 1, Product: model.Product{Code: "P-1", Name: "Runner"}},
+			}})
+			require.NoError(t, err)
+			assert.NoError(t, svc.ProcessImportQueue(context.Background(), amqp.Delivery{Body: body}))
+		})
+	}
+}
@@ -2,12 +2,18 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
 	"simcomm-monolith/config"
 	"simcomm-monolith/internal/model"
 	"simcomm-monolith/internal/repository"
 	"simcomm-monolith/util"
+	"strings"
 
 	log "github.com/labstack/gommon/log"
+	"gorm.io/gorm"
 )
 
 // ProductService defines the methods for the Product service
@@ -17,23 +23,56 @@ type ProductService interface {
 	GetAll(ctx context.Context) ([]model.Product, error)
 	Update(ctx context.Context, product *model.Product) error
 	Delete(ctx context.Context, id int) error
+	GetStatusHistory(ctx context.Context, id int) ([]model.StatusChange, error)
+	Search(ctx context.Context, req model.ProductSearchRequest) (*model.ProductSearchResult, error)
+	UploadImage(ctx context.Context, id int, file io.Reader) (*model.ImageData, error)
+
+	CategoryService
+	VariantService
+	ProductImportService
 }
 
 type productService struct {
-	repo      repository.ProductRepository
-	redisRepo repository.RedisRepository
-	cfg       *config.Config
+	repo        repository.ProductRepository
+	shopRepo    repository.ShopRepository
+	redisRepo   repository.RedisRepository
+	storage     repository.Storage
+	importQueue repository.Queue
+	cfg         *config.Config
 }
 
-func NewProductService(repo repository.ProductRepository, redisRepo repository.RedisRepository, cfg *config.Config) *productService {
+func NewProductService(repo repository.ProductRepository, shopRepo repository.ShopRepository, redisRepo repository.RedisRepository, storage repository.Storage, importQueue repository.Queue, cfg *config.Config) *productService {
 	return &productService{
-		repo:      repo,
-		redisRepo: redisRepo,
-		cfg:       cfg,
+		repo:        repo,
+		shopRepo:    shopRepo,
+		redisRepo:   redisRepo,
+		storage:     storage,
+		importQueue: importQueue,
+		cfg:         cfg,
 	}
 }
 
+// Create records the authenticated user as the owner of the product, a product
+// can only be given to a shop the user manages
 func (s *productService) Create(ctx context.Context, product *model.Product) error {
+	authUser, ok := util.GetAuthUser(ctx)
+	if !ok {
+		return errors.New(util.ErrForbidden)
+	}
+	product.UserID = &authUser.ID
+	if product.ShopID != nil {
+		if _, _, err := authorizeShopMember(ctx, s.shopRepo, *product.ShopID, shopManagerRoles...); err != nil {
+			return err
+		}
+	}
+
+	if err := s.validateAttributes(ctx, product); err != nil {
+		return err
+	}
+	if err := applyInitialStatus(ctx, product, product.Status); err != nil {
+		return err
+	}
+
 	timeNow := util.TimeNow()
 	product.CreatedAt = timeNow
 	product.UpdatedAt = timeNow
@@ -52,12 +91,448 @@ func (s *productService) GetAll(ctx context.Context) ([]model.Product, error) {
 	return s.repo.GetAll(ctx)
 }
 
+// Update rejects a status the stored status cannot move to, the owners of the
+// product are kept
 func (s *productService) Update(ctx context.Context, product *model.Product) error {
-	timeNow := util.TimeNow()
-	product.UpdatedAt = timeNow
-	return s.repo.Update(ctx, product)
+	existing, err := s.getOwnedProduct(ctx, product.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.validateAttributes(ctx, product); err != nil {
+		return err
+	}
+	if err := applyStatus(ctx, product, existing.Status, product.Status); err != nil {
+		return err
+	}
+
+	product.UserID = existing.UserID
+	product.ShopID = existing.ShopID
+	product.CreatedAt = existing.CreatedAt
+	product.UpdatedAt = util.TimeNow()
+	if err := s.repo.Update(ctx, product); err != nil {
+		return err
+	}
+	invalidateProductStorefronts(ctx, s.redisRepo, s.repo, product.ID)
+	return nil
 }
 
+// Delete removes the product, the shops listing it are looked up first so their
+// storefronts are invalidated
 func (s *productService) Delete(ctx context.Context, id int) error {
-	return s.repo.Delete(ctx, id)
+	if _, err := s.getOwnedProduct(ctx, id); err != nil {
+		return err
+	}
+
+	shopIDs, err := s.repo.GetShopIDs(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	invalidateStorefronts(ctx, s.redisRepo, shopIDs...)
+	return nil
+}
+
+func (s *productService) GetStatusHistory(ctx context.Context, id int) ([]model.StatusChange, error) {
+	if _, err := s.getProduct(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.repo.GetStatusHistory(ctx, id)
+}
+
+// Search matches the shop listings against the request, a category filter also matches
+// its sub categories and a price range defaults to the default currency
+func (s *productService) Search(ctx context.Context, req model.ProductSearchRequest) (*model.ProductSearchResult, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	if req.Currency == "" && (req.MinPrice != nil || req.MaxPrice != nil) {
+		req.Currency = s.cfg.PricingConfig.DefaultCurrency
+	}
+
+	if req.CategoryID != nil {
+		categories, err := s.getCategoryMap(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := categories[*req.CategoryID]; !ok {
+			return nil, errors.New(util.ErrCategoryNotFound)
+		}
+		req.CategoryIDs = descendantsOf(categories, *req.CategoryID)
+	}
+
+	result, err := s.repo.Search(ctx, req)
+	if err != nil {
+		log.Error(err)
+	}
+	return result, err
+}
+
+// UploadImage replaces the product image and its thumbnail
+func (s *productService) UploadImage(ctx context.Context, id int, file io.Reader) (*model.ImageData, error) {
+	product, err := s.getOwnedProduct(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	image, err := uploadImage(ctx, s.storage, s.cfg.StorageConfig, fmt.Sprintf("products/%v", id), file)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := model.ImageData{ImageURL: product.Detail.ImageURL, ThumbnailURL: product.Detail.ThumbnailURL}
+	product.Detail.ImageURL = image.ImageURL
+	product.Detail.ThumbnailURL = image.ThumbnailURL
+	product.UpdatedAt = util.TimeNow()
+	if err := s.repo.Update(ctx, product); err != nil {
+		log.Error(err)
+		deleteImage(ctx, s.storage, *image)
+		return nil, err
+	}
+
+	deleteImage(ctx, s.storage, previous)
+	invalidateProductStorefronts(ctx, s.redisRepo, s.repo, id)
+	return image, nil
+}
+
+// validateAttributes checks the product attributes against the schema of its category
+func (s *productService) validateAttributes(ctx context.Context, product *model.Product) error {
+	if product.CategoryID == nil {
+		if len(product.Detail.Attributes) > 0 {
+			return model.ValidationErrors{{Field: "category_id", Message: "is required when attributes are set"}}
+		}
+		return nil
+	}
+
+	schema, err := s.CategoryServiceGet(ctx, *product.CategoryID)
+	if err != nil {
+		if err.Error() == util.ErrCategoryNotFound {
+			return model.ValidationErrors{{Field: "category_id", Message: "does not exist"}}
+		}
+		return err
+	}
+	return schema.ValidateAttributes(product.Detail.Attributes)
+}
+
+// CategoryService defines the methods for the Category service
+type CategoryService interface {
+	CategoryServiceCreate(ctx context.Context, category *model.Category) error
+	CategoryServiceGet(ctx context.Context, id int) (*model.CategorySchema, error)
+	CategoryServiceGetTree(ctx context.Context) ([]*model.CategoryNode, error)
+	CategoryServiceUpdate(ctx context.Context, category *model.Category) error
+	CategoryServiceDelete(ctx context.Context, id int) error
+}
+
+func (s *productService) CategoryServiceCreate(ctx context.Context, category *model.Category) error {
+	if err := category.Validate(); err != nil {
+		return err
+	}
+	if category.ParentID != nil {
+		if _, err := s.repo.CategoryRepositoryGet(ctx, *category.ParentID); err != nil {
+			return err
+		}
+	}
+
+	timeNow := util.TimeNow()
+	category.CreatedAt = timeNow
+	category.UpdatedAt = timeNow
+	return s.repo.CategoryRepositoryCreate(ctx, category)
+}
+
+// CategoryServiceGet returns the category with its path from the root and every
+// attribute it defines or inherits, a definition overrides the one of an ancestor
+func (s *productService) CategoryServiceGet(ctx context.Context, id int) (*model.CategorySchema, error) {
+	categories, err := s.getCategoryMap(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	category, ok := categories[id]
+	if !ok {
+		return nil, errors.New(util.ErrCategoryNotFound)
+	}
+
+	schema := &model.CategorySchema{Category: category}
+	for current, ok := category, true; ok; current, ok = parentOf(categories, current) {
+		schema.Path = append([]model.Category{current}, schema.Path...)
+		if len(schema.Path) > len(categories) {
+			return nil, errors.New(util.ErrInvalidCategoryParent)
+		}
+	}
+
+	attributes := make(map[string]int)
+	for _, pathCategory := range schema.Path {
+		for _, definition := range pathCategory.Detail.Attributes {
+			if i, ok := attributes[definition.Code]; ok {
+				schema.Attributes[i] = definition
+				continue
+			}
+			attributes[definition.Code] = len(schema.Attributes)
+			schema.Attributes = append(schema.Attributes, definition)
+		}
+	}
+	return schema, nil
+}
+
+// CategoryServiceGetTree returns the root categories with their sub categories
+func (s *productService) CategoryServiceGetTree(ctx context.Context) ([]*model.CategoryNode, error) {
+	categories, err := s.repo.CategoryRepositoryGetAll(ctx)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	nodes := make(map[int]*model.CategoryNode, len(categories))
+	for _, category := range categories {
+		nodes[category.ID] = &model.CategoryNode{Category: category, Children: []*model.CategoryNode{}}
+	}
+
+	roots := []*model.CategoryNode{}
+	for _, category := range categories {
+		node := nodes[category.ID]
+		if category.ParentID == nil {
+			roots = append(roots, node)
+			continue
+		}
+		if parent, ok := nodes[*category.ParentID]; ok {
+			parent.Children = append(parent.Children, node)
+		}
+	}
+	return roots, nil
+}
+
+func (s *productService) CategoryServiceUpdate(ctx context.Context, category *model.Category) error {
+	if err := category.Validate(); err != nil {
+		return err
+	}
+
+	categories, err := s.getCategoryMap(ctx)
+	if err != nil {
+		return err
+	}
+	existing, ok := categories[category.ID]
+	if !ok {
+		return errors.New(util.ErrCategoryNotFound)
+	}
+
+	// the new parent must not be the category itself or one of its descendants
+	if category.ParentID != nil {
+		parent, ok := categories[*category.ParentID]
+		if !ok {
+			return errors.New(util.ErrCategoryNotFound)
+		}
+		for ; ok; parent, ok = parentOf(categories, parent) {
+			if parent.ID == category.ID {
+				return errors.New(util.ErrInvalidCategoryParent)
+			}
+		}
+	}
+
+	category.CreatedAt = existing.CreatedAt
+	category.UpdatedAt = util.TimeNow()
+	return s.repo.CategoryRepositoryUpdate(ctx, category)
+}
+
+func (s *productService) CategoryServiceDelete(ctx context.Context, id int) error {
+	return s.repo.CategoryRepositoryDelete(ctx, id)
+}
+
+func (s *productService) getCategoryMap(ctx context.Context) (map[int]model.Category, error) {
+	categories, err := s.repo.CategoryRepositoryGetAll(ctx)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	categoryMap := make(map[int]model.Category, len(categories))
+	for _, category := range categories {
+		categoryMap[category.ID] = category
+	}
+	return categoryMap, nil
+}
+
+func parentOf(categories map[int]model.Category, category model.Category) (model.Category, bool) {
+	if category.ParentID == nil {
+		return model.Category{}, false
+	}
+	parent, ok := categories[*category.ParentID]
+	return parent, ok
+}
+
+// descendantsOf returns the category with every category below it
+func descendantsOf(categories map[int]model.Category, id int) []int {
+	children := make(map[int][]int, len(categories))
+	for _, category := range categories {
+		if category.ParentID != nil {
+			children[*category.ParentID] = append(children[*category.ParentID], category.ID)
+		}
+	}
+
+	ids := []int{id}
+	visited := map[int]bool{id: true}
+	for i := 0; i < len(ids); i++ {
+		for _, child := range children[ids[i]] {
+			if !visited[child] {
+				visited[child] = true
+				ids = append(ids, child)
+			}
+		}
+	}
+	return ids
+}
+
+// VariantService defines the methods for the product Variant service
+type VariantService interface {
+	VariantServiceCreate(ctx context.Context, variant *model.ProductVariant) error
+	VariantServiceGetByProductID(ctx context.Context, productID int) ([]model.ProductVariant, error)
+	VariantServiceUpdate(ctx context.Context, variant *model.ProductVariant) error
+	VariantServiceDelete(ctx context.Context, productID int, id int) error
+}
+
+func (s *productService) VariantServiceCreate(ctx context.Context, variant *model.ProductVariant) error {
+	product, err := s.getOwnedProduct(ctx, variant.ProductID)
+	if err != nil {
+		return err
+	}
+	if err := s.validateVariant(ctx, product, variant); err != nil {
+		return err
+	}
+
+	timeNow := util.TimeNow()
+	variant.CreatedAt = timeNow
+	variant.UpdatedAt = timeNow
+	return s.repo.VariantRepositoryCreate(ctx, variant)
+}
+
+func (s *productService) VariantServiceGetByProductID(ctx context.Context, productID int) ([]model.ProductVariant, error) {
+	if _, err := s.getProduct(ctx, productID); err != nil {
+		return nil, err
+	}
+	return s.repo.VariantRepositoryGetByProductID(ctx, productID)
+}
+
+func (s *productService) VariantServiceUpdate(ctx context.Context, variant *model.ProductVariant) error {
+	product, err := s.getOwnedProduct(ctx, variant.ProductID)
+	if err != nil {
+		return err
+	}
+	existing, err := s.repo.VariantRepositoryGet(ctx, variant.ID)
+	if err != nil {
+		return err
+	}
+	if existing.ProductID != variant.ProductID {
+		return errors.New(util.ErrVariantNotFound)
+	}
+
+	if err := s.validateVariant(ctx, product, variant); err != nil {
+		return err
+	}
+
+	variant.CreatedAt = existing.CreatedAt
+	variant.UpdatedAt = util.TimeNow()
+	if err := s.repo.VariantRepositoryUpdate(ctx, variant); err != nil {
+		return err
+	}
+	invalidateProductStorefronts(ctx, s.redisRepo, s.repo, variant.ProductID)
+	return nil
+}
+
+func (s *productService) VariantServiceDelete(ctx context.Context, productID int, id int) error {
+	if _, err := s.getOwnedProduct(ctx, productID); err != nil {
+		return err
+	}
+	existing, err := s.repo.VariantRepositoryGet(ctx, id)
+	if err != nil {
+		return err
+	}
+	if existing.ProductID != productID {
+		return errors.New(util.ErrVariantNotFound)
+	}
+	if err := s.repo.VariantRepositoryDelete(ctx, id); err != nil {
+		return err
+	}
+	invalidateProductStorefronts(ctx, s.redisRepo, s.repo, productID)
+	return nil
+}
+
+// validateVariant checks the SKU and the options against the category of the
+// parent product, no two variants of a product may have the same options
+func (s *productService) validateVariant(ctx context.Context, product *model.Product, variant *model.ProductVariant) error {
+	var errs model.ValidationErrors
+	variant.SKU = strings.TrimSpace(variant.SKU)
+	if variant.SKU == "" {
+		errs.Add("sku", "is required")
+	}
+	if len(variant.Detail.Options) == 0 {
+		errs.Add("detail.options", "is required")
+	}
+	if err := errs.Err(); err != nil {
+		return err
+	}
+
+	if product.CategoryID != nil {
+		schema, err := s.CategoryServiceGet(ctx, *product.CategoryID)
+		if err != nil {
+			return err
+		}
+		if err := schema.ValidateVariantOptions(variant.Detail.Options); err != nil {
+			return err
+		}
+	}
+
+	siblings, err := s.repo.VariantRepositoryGetByProductID(ctx, variant.ProductID)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	for _, sibling := range siblings {
+		if sibling.ID != variant.ID && reflect.DeepEqual(sibling.Detail.Options, variant.Detail.Options) {
+			return errors.New(util.ErrDuplicateVariantOptions)
+		}
+	}
+	return nil
+}
+
+func (s *productService) getProduct(ctx context.Context, id int) (*model.Product, error) {
+	product, err := s.repo.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(util.ErrProductNotFound)
+		}
+		log.Error(err)
+		return nil, err
+	}
+	return product, nil
+}
+
+// getOwnedProduct returns the product when the authenticated user may change it
+func (s *productService) getOwnedProduct(ctx context.Context, id int) (*model.Product, error) {
+	product, err := s.getProduct(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeProduct(ctx, product); err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+// authorizeProduct ensures the authenticated user may change the product, its creator,
+// the managers of its shop and admins can
+func (s *productService) authorizeProduct(ctx context.Context, product *model.Product) error {
+	authUser, ok := util.GetAuthUser(ctx)
+	if !ok {
+		return errors.New(util.ErrForbidden)
+	}
+	if authUser.HasRole(util.RoleAdmin) || (product.UserID != nil && *product.UserID == authUser.ID) {
+		return nil
+	}
+	if product.ShopID == nil {
+		return errors.New(util.ErrForbidden)
+	}
+	_, _, err := authorizeShopMember(ctx, s.shopRepo, *product.ShopID, shopManagerRoles...)
+	return err
 }
@@ -0,0 +1,317 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"simcomm-monolith/internal/model"
+	"simcomm-monolith/internal/repository/mocks"
+	"simcomm-monolith/util"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type productServiceMocks struct {
+	repo      *mocks.ProductRepository
+	shopRepo  *mocks.ShopRepository
+	redisRepo *mocks.RedisRepository
+	storage   *mocks.Storage
+	queue     *mocks.Queue
+}
+
+func newTestProductService(t *testing.T) (*productService, productServiceMocks) {
+	t.Helper()
+	m := productServiceMocks{
+		repo:      &mocks.ProductRepository{},
+		shopRepo:  &mocks.ShopRepository{},
+		redisRepo: &mocks.RedisRepository{},
+		storage:   &mocks.Storage{},
+		queue:     &mocks.Queue{},
+	}
+	t.Cleanup(func() {
+		m.repo.AssertExpectations(t)
+		m.shopRepo.AssertExpectations(t)
+		m.storage.AssertExpectations(t)
+	})
+	return NewProductService(m.repo, m.shopRepo, m.redisRepo, m.storage, m.queue, testConfig()), m
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+// testCategories is a tree of Fashion > Shoes > Running shoes
+func testCategories() []model.Category {
+	return []model.Category{
+		{ID: 1, Name: "Fashion", Slug: "fashion", Detail: model.CategoryDetail{Attributes: []model.AttributeDefinition{
+			{Code: "brand", Name: "Brand", Type: model.AttributeTypeString},
+			{Code: "colour", Name: "Colour", Type: model.AttributeTypeString},
+		}}},
+		{ID: 2, ParentID: intPtr(1), Name: "Shoes", Slug: "shoes", Detail: model.CategoryDetail{Attributes: []model.AttributeDefinition{
+			{Code: "size", Name: "Size", Type: model.AttributeTypeNumber, Required: true},
+		}}},
+		{ID: 3, ParentID: intPtr(2), Name: "Running shoes", Slug: "running-shoes", Detail: model.CategoryDetail{Attributes: []model.AttributeDefinition{
+			{Code: "colour", Name: "Colour", Type: model.AttributeTypeEnum, Options: []string{"black", "white"}},
+		}}},
+	}
+}
+
+func TestProductServiceCategoryServiceGet(t *testing.T) {
+	svc, m := newTestProductService(t)
+	m.repo.On("CategoryRepositoryGetAll", mock.Anything).Return(testCategories(), nil)
+
+	schema, err := svc.CategoryServiceGet(context.Background(), 3)
+	require.NoError(t, err)
+
+	var path []string
+	for _, category := range schema.Path {
+		path = append(path, category.Slug)
+	}
+	assert.Equal(t, []string{"fashion", "shoes", "running-shoes"}, path)
+
+	var codes []string
+	for _, definition := range schema.Attributes {
+		codes = append(codes, definition.Code)
+	}
+	assert.Equal(t, []string{"brand", "colour", "size"}, codes)
+	assert.Equal(t, model.AttributeTypeEnum, schema.Attributes[1].Type, "a sub category overrides an inherited attribute")
+
+	_, err = svc.CategoryServiceGet(context.Background(), 99)
+	assert.EqualError(t, err, util.ErrCategoryNotFound)
+}
+
+func TestProductServiceCategoryServiceUpdate(t *testing.T) {
+	tests := []struct {
+		name     string
+		category model.Category
+		wantErr  string
+	}{
+		{"move under another category", model.Category{ID: 3, ParentID: intPtr(1), Name: "Running shoes", Slug: "running-shoes"}, ""},
+		{"category as its own parent", model.Category{ID: 2, ParentID: intPtr(2), Name: "Shoes", Slug: "shoes"}, util.ErrInvalidCategoryParent},
+		{"category under its descendant", model.Category{ID: 1, ParentID: intPtr(3), Name: "Fashion", Slug: "fashion"}, util.ErrInvalidCategoryParent},
+		{"unknown parent", model.Category{ID: 2, ParentID: intPtr(99), Name: "Shoes", Slug: "shoes"}, util.ErrCategoryNotFound},
+		{"unknown category", model.Category{ID: 99, Name: "Bags", Slug: "bags"}, util.ErrCategoryNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newTestProductService(t)
+			m.repo.On("CategoryRepositoryGetAll", mock.Anything).Return(testCategories(), nil)
+			if tt.wantErr == "" {
+				m.repo.On("CategoryRepositoryUpdate", mock.Anything, mock.Anything).Return(nil)
+			}
+
+			category := tt.category
+			err := svc.CategoryServiceUpdate(context.Background(), &category)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestProductServiceCreateAttributes(t *testing.T) {
+	tests := []struct {
+		name       string
+		product    model.Product
+		wantFields []string
+	}{
+		{"product without category or attributes", model.Product{Code: "P-1", Name: "Plain"}, nil},
+		{"attributes need a category", model.Product{Code: "P-1", Name: "Plain", Detail: model.ProductDetail{
+			Attributes: map[string]interface{}{"size": 42.0},
+		}}, []string{"category_id"}},
+		{"unknown category", model.Product{Code: "P-1", Name: "Runner", CategoryID: intPtr(99)}, []string{"category_id"}},
+		{"inherited required attribute", model.Product{Code: "P-1", Name: "Runner", CategoryID: intPtr(3), Detail: model.ProductDetail{
+			Attributes: map[string]interface{}{"colour": "black"},
+		}}, []string{"detail.attributes.size"}},
+		{"valid attributes", model.Product{Code: "P-1", Name: "Runner", CategoryID: intPtr(3), Detail: model.ProductDetail{
+			Attributes: map[string]interface{}{"size": 42.0, "colour": "black", "brand": "Acme"},
+		}}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newTestProductService(t)
+			m.repo.On("CategoryRepositoryGetAll", mock.Anything).Return(testCategories(), nil).Maybe()
+			if tt.wantFields == nil {
+				m.repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+			}
+
+			product := tt.product
+			err := svc.Create(sellerContext(2), &product)
+			if tt.wantFields == nil {
+				require.NoError(t, err)
+				assert.Equal(t, model.StatusDraft, product.Status)
+				assert.Equal(t, intPtr(2), product.UserID)
+				return
+			}
+			var errs model.ValidationErrors
+			require.ErrorAs(t, err, &errs)
+			var fields []string
+			for _, fe := range errs {
+				fields = append(fields, fe.Field)
+			}
+			assert.Equal(t, tt.wantFields, fields)
+		})
+	}
+}
+
+func TestProductServiceVariantServiceCreate(t *testing.T) {
+	runner := &model.Product{ID: 5, Code: "P-5", Name: "Runner", CategoryID: intPtr(3), UserID: intPtr(2)}
+
+	tests := []struct {
+		name       string
+		variant    model.ProductVariant
+		setup      func(m productServiceMocks)
+		wantErr    string
+		wantFields []string
+	}{
+		{
+			name:    "valid variant",
+			variant: model.ProductVariant{ProductID: 5, SKU: " RUN-42-BLK ", Detail: model.VariantDetail{Options: map[string]interface{}{"size": 42.0, "colour": "black"}}},
+			setup: func(m productServiceMocks) {
+				m.repo.On("VariantRepositoryGetByProductID", mock.Anything, 5).Return([]model.ProductVariant{
+					{ID: 1, ProductID: 5, SKU: "RUN-42-WHT", Detail: model.VariantDetail{Options: map[string]interface{}{"size": 42.0, "colour": "white"}}},
+				}, nil)
+				m.repo.On("VariantRepositoryCreate", mock.Anything, mock.MatchedBy(func(variant *model.ProductVariant) bool {
+					return variant.SKU == "RUN-42-BLK"
+				})).Return(nil)
+			},
+		},
+		{
+			name:       "sku and options are required",
+			variant:    model.ProductVariant{ProductID: 5},
+			setup:      func(m productServiceMocks) {},
+			wantFields: []string{"sku", "detail.options"},
+		},
+		{
+			name:       "option outside the category schema",
+			variant:    model.ProductVariant{ProductID: 5, SKU: "RUN-42-RED", Detail: model.VariantDetail{Options: map[string]interface{}{"colour": "red"}}},
+			setup:      func(m productServiceMocks) {},
+			wantFields: []string{"detail.options.colour"},
+		},
+		{
+			name:    "same options as a sibling",
+			variant: model.ProductVariant{ProductID: 5, SKU: "RUN-42-WHT-2", Detail: model.VariantDetail{Options: map[string]interface{}{"size": 42.0, "colour": "white"}}},
+			setup: func(m productServiceMocks) {
+				m.repo.On("VariantRepositoryGetByProductID", mock.Anything, 5).Return([]model.ProductVariant{
+					{ID: 1, ProductID: 5, SKU: "RUN-42-WHT", Detail: model.VariantDetail{Options: map[string]interface{}{"size": 42.0, "colour": "white"}}},
+				}, nil)
+			},
+			wantErr: util.ErrDuplicateVariantOptions,
+		},
+		{
+			name:    "product still listed without a variant",
+			variant: model.ProductVariant{ProductID: 5, SKU: "RUN-42-BLK", Detail: model.VariantDetail{Options: map[string]interface{}{"size": 42.0}}},
+			setup: func(m productServiceMocks) {
+				m.repo.On("VariantRepositoryGetByProductID", mock.Anything, 5).Return([]model.ProductVariant{}, nil)
+				m.repo.On("VariantRepositoryCreate", mock.Anything, mock.Anything).Return(errors.New(util.ErrProductListedWithoutVariant))
+			},
+			wantErr: util.ErrProductListedWithoutVariant,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newTestProductService(t)
+			m.repo.On("Get", mock.Anything, 5).Return(runner, nil).Maybe()
+			m.repo.On("CategoryRepositoryGetAll", mock.Anything).Return(testCategories(), nil).Maybe()
+			tt.setup(m)
+
+			variant := tt.variant
+			err := svc.VariantServiceCreate(sellerContext(2), &variant)
+			switch {
+			case tt.wantFields != nil:
+				var errs model.ValidationErrors
+				require.ErrorAs(t, err, &errs)
+				var fields []string
+				for _, fe := range errs {
+					fields = append(fields, fe.Field)
+				}
+				assert.Equal(t, tt.wantFields, fields)
+			case tt.wantErr != "":
+				assert.EqualError(t, err, tt.wantErr)
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestProductServiceDeleteOwnership(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		product *model.Product
+		setup   func(m productServiceMocks)
+		wantErr string
+	}{
+		{
+			name:    "creator deletes the product",
+			ctx:     sellerContext(2),
+			product: &model.Product{ID: 5, UserID: intPtr(2)},
+		},
+		{
+			name:    "manager of the owning shop deletes the product",
+			ctx:     sellerContext(3),
+			product: &model.Product{ID: 5, UserID: intPtr(2), ShopID: intPtr(7)},
+			setup: func(m productServiceMocks) {
+				m.shopRepo.On("Get", mock.Anything, 7).Return(&model.Shop{ID: 7, UserID: 2}, nil)
+				m.shopRepo.On("ShopMemberRepositoryGet", mock.Anything, 7, 3).Return(&model.ShopMember{ShopID: 7, UserID: 3, Role: model.ShopRoleManager}, nil)
+			},
+		},
+		{
+			name:    "admin deletes a product without owner",
+			ctx:     util.SetAuthUser(context.Background(), util.AuthUser{ID: 99, Roles: []string{util.RoleAdmin}}),
+			product: &model.Product{ID: 5},
+		},
+		{
+			name:    "another seller cannot delete the product",
+			ctx:     sellerContext(3),
+			product: &model.Product{ID: 5, UserID: intPtr(2)},
+			wantErr: util.ErrForbidden,
+		},
+		{
+			name:    "staff of the owning shop cannot delete the product",
+			ctx:     sellerContext(3),
+			product: &model.Product{ID: 5, UserID: intPtr(2), ShopID: intPtr(7)},
+			setup: func(m productServiceMocks) {
+				m.shopRepo.On("Get", mock.Anything, 7).Return(&model.Shop{ID: 7, UserID: 2}, nil)
+				m.shopRepo.On("ShopMemberRepositoryGet", mock.Anything, 7, 3).Return(&model.ShopMember{ShopID: 7, UserID: 3, Role: model.ShopRoleStaff}, nil)
+			},
+			wantErr: util.ErrForbidden,
+		},
+		{
+			name:    "seller cannot delete a product without owner",
+			ctx:     sellerContext(2),
+			product: &model.Product{ID: 5},
+			wantErr: util.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newTestProductService(t)
+			m.repo.On("Get", mock.Anything, 5).Return(tt.product, nil)
+			if tt.setup != nil {
+				tt.setup(m)
+			}
+			if tt.wantErr == "" {
+				m.repo.On("GetShopIDs", mock.Anything, 5).Return([]int{7}, nil)
+				m.repo.On("Delete", mock.Anything, 5).Return(nil)
+				m.redisRepo.On("DeleteStorefronts", mock.Anything, 7).Return(nil)
+			}
+
+			err := svc.Delete(tt.ctx, 5)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"simcomm-monolith/config"
+	"simcomm-monolith/internal/model"
+	"simcomm-monolith/internal/repository"
+	"simcomm-monolith/util"
+
+	log "github.com/labstack/gommon/log"
+	"gorm.io/gorm"
+)
+
+// ReviewService defines the methods for the Review service
+type ReviewService interface {
+	Create(ctx context.Context, req model.ReviewRequest) (*model.Review, error)
+	GetByProductID(ctx context.Context, req model.ReviewListRequest) (*model.ReviewList, error)
+	GetFlagged(ctx context.Context, req model.ReviewListRequest) (*model.ReviewList, error)
+	GetProductRating(ctx context.Context, productID int) (*model.RatingSummary, error)
+	GetShopRating(ctx context.Context, shopID int) (*model.RatingSummary, error)
+	Reply(ctx context.Context, id int, req model.ReviewReplyRequest) (*model.Review, error)
+	Flag(ctx context.Context, id int, req model.ReviewFlagRequest) (*model.Review, error)
+	Moderate(ctx context.Context, id int, req model.ReviewModerationRequest) (*model.Review, error)
+}
+
+type reviewService struct {
+	repo        repository.ReviewRepository
+	orderRepo   repository.OrderRepository
+	shopRepo    repository.ShopRepository
+	productRepo repository.ProductRepository
+	cfg         *config.Config
+}
+
+func NewReviewService(repo repository.ReviewRepository, orderRepo repository.OrderRepository, shopRepo repository.ShopRepository, productRepo repository.ProductRepository, cfg *config.Config) *reviewService {
+	return &reviewService{
+		repo:        repo,
+		orderRepo:   orderRepo,
+		shopRepo:    shopRepo,
+		productRepo: productRepo,
+		cfg:         cfg,
+	}
+}
+
+// Create reviews a line of a completed order, only the buyer of the order can
+// review it and each line is reviewed once
+func (s *reviewService) Create(ctx context.Context, req model.ReviewRequest) (*model.Review, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	authUser, ok := util.GetAuthUser(ctx)
+	if !ok {
+		return nil, errors.New(util.ErrForbidden)
+	}
+
+	order, err := s.orderRepo.Get(ctx, req.OrderID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(util.ErrOrderNotFound)
+		}
+		log.Error(err)
+		return nil, err
+	}
+	if order.UserID != authUser.ID {
+		return nil, errors.New(util.ErrForbidden)
+	}
+	if order.Status != model.OrderStatusCompleted {
+		return nil, errors.New(util.ErrOrderNotCompleted)
+	}
+
+	var line *model.OrderItem
+	for i := range order.Detail.Items {
+		item := order.Detail.Items[i]
+		if item.ShopProductID == req.ShopProductID && sameVariant(item.VariantID, req.VariantID) {
+			line = &order.Detail.Items[i]
+			break
+		}
+	}
+	if line == nil {
+		return nil, errors.New(util.ErrInvalidOrderItem)
+	}
+
+	timeNow := util.TimeNow()
+	review := &model.Review{
+		OrderID:          order.ID,
+		ShopProductID:    line.ShopProductID,
+		ProductID:        line.ProductID,
+		VariantID:        line.VariantID,
+		ShopID:           order.ShopID,
+		UserID:           authUser.ID,
+		Rating:           req.Rating,
+		Comment:          req.Comment,
+		ModerationStatus: model.ReviewStatusPublished,
+		CreatedAt:        timeNow,
+		UpdatedAt:        timeNow,
+	}
+	if err := s.repo.Create(ctx, review); err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+// sameVariant reports whether two variant IDs point to the same variant, two lines
+// without a variant are the same
+func sameVariant(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// GetByProductID lists the reviews of a product with the rating of the product
+func (s *reviewService) GetByProductID(ctx context.Context, req model.ReviewListRequest) (*model.ReviewList, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := s.productRepo.Get(ctx, req.ProductID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(util.ErrProductNotFound)
+		}
+		log.Error(err)
+		return nil, err
+	}
+
+	reviews, total, err := s.repo.GetByProductID(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	rating, err := s.repo.GetProductRating(ctx, req.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.ReviewList{
+		Reviews: reviews,
+		Rating:  *rating,
+		Total:   total,
+		Page:    req.Page,
+		Limit:   req.Limit,
+	}, nil
+}
+
+// GetFlagged lists the reviews waiting for moderation, the filters besides the
+// page are ignored
+func (s *reviewService) GetFlagged(ctx context.Context, req model.ReviewListRequest) (*model.ReviewList, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	reviews, total, err := s.repo.GetFlagged(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.ReviewList{
+		Reviews: reviews,
+		Total:   total,
+		Page:    req.Page,
+		Limit:   req.Limit,
+	}, nil
+}
+
+func (s *reviewService) GetProductRating(ctx context.Context, productID int) (*model.RatingSummary, error) {
+	if _, err := s.productRepo.Get(ctx, productID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(util.ErrProductNotFound)
+		}
+		log.Error(err)
+		return nil, err
+	}
+	return s.repo.GetProductRating(ctx, productID)
+}
+
+func (s *reviewService) GetShopRating(ctx context.Context, shopID int) (*model.RatingSummary, error) {
+	if _, err := s.shopRepo.Get(ctx, shopID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(util.ErrShopNotFound)
+		}
+		log.Error(err)
+		return nil, err
+	}
+	return s.repo.GetShopRating(ctx, shopID)
+}
+
+// Reply sets the reply of the shop to a review, the owner and managers of the shop
+// can reply and a new reply replaces the previous one
+func (s *reviewService) Reply(ctx context.Context, id int, req model.ReviewReplyRequest) (*model.Review, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	review, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	_, member, err := authorizeShopMember(ctx, s.shopRepo, review.ShopID, shopManagerRoles...)
+	if err != nil {
+		return nil, err
+	}
+
+	timeNow := util.TimeNow()
+	review.Reply = req.Reply
+	review.RepliedBy = &member.UserID
+	review.RepliedAt = &timeNow
+	review.UpdatedAt = timeNow
+	if err := s.repo.Update(ctx, review); err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+// Flag reports a review for moderation, it is hidden once it reaches the
+// configured number of flags
+func (s *reviewService) Flag(ctx context.Context, id int, req model.ReviewFlagRequest) (*model.Review, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	authUser, ok := util.GetAuthUser(ctx)
+	if !ok {
+		return nil, errors.New(util.ErrForbidden)
+	}
+
+	flag := &model.ReviewFlag{
+		ReviewID:  id,
+		UserID:    authUser.ID,
+		Reason:    req.Reason,
+		CreatedAt: util.TimeNow(),
+	}
+	return s.repo.AddFlag(ctx, flag, s.cfg.ReviewConfig.HideFlagThreshold)
+}
+
+// Moderate publishes or hides a review, the route is admin only
+func (s *reviewService) Moderate(ctx context.Context, id int, req model.ReviewModerationRequest) (*model.Review, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	review, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	review.Moderate(req.Status, util.TimeNow())
+	if err := s.repo.Update(ctx, review); err != nil {
+		return nil, err
+	}
+	return review, nil
+}
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"simcomm-monolith/internal/model"
+	"simcomm-monolith/internal/repository/mocks"
+	"simcomm-monolith/util"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type reviewServiceMocks struct {
+	repo        *mocks.ReviewRepository
+	orderRepo   *mocks.OrderRepository
+	shopRepo    *mocks.ShopRepository
+	productRepo *mocks.ProductRepository
+}
+
+func newTestReviewService(t *testing.T) (*reviewService, reviewServiceMocks) {
+	t.Helper()
+	m := reviewServiceMocks{
+		repo:        &mocks.ReviewRepository{},
+		orderRepo:   &mocks.OrderRepository{},
+		shopRepo:    &mocks.ShopRepository{},
+		productRepo: &mocks.ProductRepository{},
+	}
+	t.Cleanup(func() {
+		m.repo.AssertExpectations(t)
+		m.orderRepo.AssertExpectations(t)
+		m.shopRepo.AssertExpectations(t)
+		m.productRepo.AssertExpectations(t)
+	})
+	cfg := testConfig()
+	cfg.ReviewConfig.HideFlagThreshold = 2
+	return NewReviewService(m.repo, m.orderRepo, m.shopRepo, m.productRepo, cfg), m
+}
+
+func TestReviewServiceCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		status  string
+		req     model.ReviewRequest
+		setup   func(m reviewServiceMocks)
+		wantErr string
+	}{
+		{
+			name:   "buyer reviews a line of a completed order",
+			ctx:    customerContext(1),
+			status: model.OrderStatusCompleted,
+			req:    model.ReviewRequest{OrderID: 1, ShopProductID: 3, VariantID: intPtr(5), Rating: 4},
+			setup: func(m reviewServiceMocks) {
+				m.repo.On("Create", mock.Anything, mock.MatchedBy(func(review *model.Review) bool {
+					return review.ShopProductID == 3 && *review.VariantID == 5 && review.ProductID == 10
+				})).Return(nil)
+			},
+		},
+		{
+			name:    "another user cannot review the order",
+			ctx:     customerContext(2),
+			status:  model.OrderStatusCompleted,
+			req:     model.ReviewRequest{OrderID: 1, ShopProductID: 3, VariantID: intPtr(5), Rating: 4},
+			wantErr: util.ErrForbidden,
+		},
+		{
+			name:    "order that is not completed cannot be reviewed",
+			ctx:     customerContext(1),
+			status:  model.OrderStatusShipped,
+			req:     model.ReviewRequest{OrderID: 1, ShopProductID: 3, VariantID: intPtr(5), Rating: 4},
+			wantErr: util.ErrOrderNotCompleted,
+		},
+		{
+			name:    "line with another variant is not in the order",
+			ctx:     customerContext(1),
+			status:  model.OrderStatusCompleted,
+			req:     model.ReviewRequest{OrderID: 1, ShopProductID: 3, VariantID: intPtr(6), Rating: 4},
+			wantErr: util.ErrInvalidOrderItem,
+		},
+		{
+			name:    "line without the variant is not in the order",
+			ctx:     customerContext(1),
+			status:  model.OrderStatusCompleted,
+			req:     model.ReviewRequest{OrderID: 1, ShopProductID: 3, Rating: 4},
+			wantErr: util.ErrInvalidOrderItem,
+		},
+		{
+			name:   "line is reviewed only once",
+			ctx:    customerContext(1),
+			status: model.OrderStatusCompleted,
+			req:    model.ReviewRequest{OrderID: 1, ShopProductID: 3, VariantID: intPtr(5), Rating: 4},
+			setup: func(m reviewServiceMocks) {
+				m.repo.On("Create", mock.Anything, mock.Anything).Return(errors.New(util.ErrReviewAlreadyExists))
+			},
+			wantErr: util.ErrReviewAlreadyExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newTestReviewService(t)
+			m.orderRepo.On("Get", mock.Anything, 1).Return(&model.Order{
+				ID:     1,
+				UserID: 1,
+				ShopID: 7,
+				Status: tt.status,
+				Detail: model.OrderDetail{Items: []model.OrderItem{
+					{ShopProductID: 3, ProductID: 10, VariantID: intPtr(5), Quantity: 1},
+				}},
+			}, nil)
+			if tt.setup != nil {
+				tt.setup(m)
+			}
+
+			review, err := svc.Create(tt.ctx, tt.req)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 7, review.ShopID)
+			assert.Equal(t, model.ReviewStatusPublished, review.ModerationStatus)
+		})
+	}
+}
+
+func TestReviewServiceReply(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		setup   func(m reviewServiceMocks)
+		wantErr string
+	}{
+		{
+			name: "owner of the shop replies",
+			ctx:  sellerContext(5),
+		},
+		{
+			name: "manager of the shop replies",
+			ctx:  sellerContext(6),
+			setup: func(m reviewServiceMocks) {
+				m.shopRepo.On("ShopMemberRepositoryGet", mock.Anything, 7, 6).Return(&model.ShopMember{ShopID: 7, UserID: 6, Role: model.ShopRoleManager}, nil)
+			},
+		},
+		{
+			name: "staff of the shop cannot reply",
+			ctx:  sellerContext(6),
+			setup: func(m reviewServiceMocks) {
+				m.shopRepo.On("ShopMemberRepositoryGet", mock.Anything, 7, 6).Return(&model.ShopMember{ShopID: 7, UserID: 6, Role: model.ShopRoleStaff}, nil)
+			},
+			wantErr: util.ErrForbidden,
+		},
+		{
+			name: "buyer cannot reply",
+			ctx:  customerContext(1),
+			setup: func(m reviewServiceMocks) {
+				m.shopRepo.On("ShopMemberRepositoryGet", mock.Anything, 7, 1).Return(nil, errors.New(util.ErrShopMemberNotFound))
+			},
+			wantErr: util.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newTestReviewService(t)
+			m.repo.On("Get", mock.Anything, 1).Return(&model.Review{ID: 1, ShopID: 7, UserID: 1}, nil)
+			m.shopRepo.On("Get", mock.Anything, 7).Return(&model.Shop{ID: 7, UserID: 5}, nil)
+			if tt.setup != nil {
+				tt.setup(m)
+			}
+			if tt.wantErr == "" {
+				m.repo.On("Update", mock.Anything, mock.Anything).Return(nil)
+			}
+
+			review, err := svc.Reply(tt.ctx, 1, model.ReviewReplyRequest{Reply: "Thank you"})
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "Thank you", review.Reply)
+			require.NotNil(t, review.RepliedBy)
+			assert.NotNil(t, review.RepliedAt)
+		})
+	}
+}
+
+func TestReviewServiceFlag(t *testing.T) {
+	svc, m := newTestReviewService(t)
+	stored := &model.Review{ID: 1, ModerationStatus: model.ReviewStatusPublished}
+	m.repo.On("AddFlag", mock.Anything, mock.MatchedBy(func(flag *model.ReviewFlag) bool {
+		return flag.ReviewID == 1
+	}), 2).Return(func(ctx context.Context, flag *model.ReviewFlag, hideThreshold int) *model.Review {
+		stored.Flag(hideThreshold, flag.CreatedAt)
+		review := *stored
+		return &review
+	}, nil)
+
+	review, err := svc.Flag(customerContext(1), 1, model.ReviewFlagRequest{Reason: "spam"})
+	require.NoError(t, err)
+	assert.Equal(t, model.ReviewStatusFlagged, review.ModerationStatus)
+
+	review, err = svc.Flag(customerContext(2), 1, model.ReviewFlagRequest{Reason: "spam"})
+	require.NoError(t, err)
+	assert.Equal(t, model.ReviewStatusHidden, review.ModerationStatus)
+	assert.Equal(t, 2, review.FlagCount)
+}
@@ -0,0 +1,270 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"simcomm-monolith/internal/model"
+	"simcomm-monolith/internal/repository"
+	"simcomm-monolith/util"
+	"time"
+
+	log "github.com/labstack/gommon/log"
+	"gorm.io/gorm"
+)
+
+// shopManagerRoles can change the shop, its products and its warehouses
+var shopManagerRoles = []string{model.ShopRoleOwner, model.ShopRoleManager}
+
+// shopMemberRoles can work with the stock of the shop, e.g. transfers
+var shopMemberRoles = []string{model.ShopRoleOwner, model.ShopRoleManager, model.ShopRoleStaff}
+
+// authorizeShopMember ensures the authenticated user is a member of the shop with one
+// of the roles, admin acts as the owner of any shop. Shop.UserID is the owner even
+// without a membership, shops created before memberships have none
+func authorizeShopMember(ctx context.Context, repo repository.ShopRepository, shopID int, roles ...string) (*model.Shop, *model.ShopMember, error) {
+	authUser, ok := util.GetAuthUser(ctx)
+	if !ok {
+		return nil, nil, errors.New(util.ErrForbidden)
+	}
+
+	shop, err := repo.Get(ctx, shopID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, errors.New(util.ErrShopNotFound)
+		}
+		log.Error(err)
+		return nil, nil, err
+	}
+
+	if authUser.HasRole(util.RoleAdmin) || shop.UserID == authUser.ID {
+		owner := &model.ShopMember{ShopID: shop.ID, UserID: authUser.ID, Role: model.ShopRoleOwner}
+		return shop, owner, nil
+	}
+
+	member, err := repo.ShopMemberRepositoryGet(ctx, shopID, authUser.ID)
+	if err != nil {
+		if err.Error() == util.ErrShopMemberNotFound {
+			return nil, nil, errors.New(util.ErrForbidden)
+		}
+		return nil, nil, err
+	}
+	if !member.HasRole(roles...) {
+		return nil, nil, errors.New(util.ErrForbidden)
+	}
+	return shop, member, nil
+}
+
+// ShopMemberService defines the methods for the ShopMember service
+type ShopMemberService interface {
+	ShopMemberServiceGetAll(ctx context.Context, shopID int) ([]model.ShopMember, error)
+	ShopMemberServiceUpdateRole(ctx context.Context, shopID int, userID int, req model.ShopMemberRoleRequest) (*model.ShopMember, error)
+	ShopMemberServiceDelete(ctx context.Context, shopID int, userID int) error
+
+	ShopMemberServiceInvite(ctx context.Context, shopID int, req model.ShopInvitationRequest) (*model.ShopInvitation, error)
+	ShopMemberServiceGetInvitations(ctx context.Context, shopID int) ([]model.ShopInvitation, error)
+	ShopMemberServiceRevokeInvitation(ctx context.Context, shopID int, invitationID int) error
+	ShopMemberServiceAcceptInvitation(ctx context.Context, req model.AcceptShopInvitationRequest) (*model.ShopMember, error)
+}
+
+// ShopMemberServiceGetAll lists the members of a shop to any of its members, a shop
+// created before memberships lists no owner
+func (s *shopService) ShopMemberServiceGetAll(ctx context.Context, shopID int) ([]model.ShopMember, error) {
+	if _, _, err := authorizeShopMember(ctx, s.repo, shopID, shopMemberRoles...); err != nil {
+		return nil, err
+	}
+	return s.repo.ShopMemberRepositoryGetByShopID(ctx, shopID)
+}
+
+// ShopMemberServiceUpdateRole changes the role of a member, both the current and the
+// new role must rank below the role of the authenticated member
+func (s *shopService) ShopMemberServiceUpdateRole(ctx context.Context, shopID int, userID int, req model.ShopMemberRoleRequest) (*model.ShopMember, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	_, actor, err := authorizeShopMember(ctx, s.repo, shopID, shopManagerRoles...)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := s.repo.ShopMemberRepositoryGet(ctx, shopID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if member.Role == model.ShopRoleOwner {
+		return nil, errors.New(util.ErrShopOwnerMembership)
+	}
+	if !actor.CanManage(member.Role) || !actor.CanManage(req.Role) {
+		return nil, errors.New(util.ErrForbidden)
+	}
+
+	member.Role = req.Role
+	member.UpdatedAt = util.TimeNow()
+	if err := s.repo.ShopMemberRepositoryUpdate(ctx, member); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// ShopMemberServiceDelete removes a member ranked below the authenticated member, any
+// member but the owner can also leave the shop
+func (s *shopService) ShopMemberServiceDelete(ctx context.Context, shopID int, userID int) error {
+	_, actor, err := authorizeShopMember(ctx, s.repo, shopID, shopMemberRoles...)
+	if err != nil {
+		return err
+	}
+
+	shop, err := s.repo.Get(ctx, shopID)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	if shop.UserID == userID {
+		return errors.New(util.ErrShopOwnerMembership)
+	}
+
+	member, err := s.repo.ShopMemberRepositoryGet(ctx, shopID, userID)
+	if err != nil {
+		return err
+	}
+	if member.Role == model.ShopRoleOwner {
+		return errors.New(util.ErrShopOwnerMembership)
+	}
+	if member.UserID != actor.UserID && !actor.CanManage(member.Role) {
+		return errors.New(util.ErrForbidden)
+	}
+	return s.repo.ShopMemberRepositoryDelete(ctx, shopID, userID)
+}
+
+// ShopMemberServiceInvite sends a single-use token to the email or phone, the role
+// must rank below the role of the authenticated member
+func (s *shopService) ShopMemberServiceInvite(ctx context.Context, shopID int, req model.ShopInvitationRequest) (*model.ShopInvitation, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	shop, actor, err := authorizeShopMember(ctx, s.repo, shopID, shopManagerRoles...)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.CanManage(req.Role) {
+		return nil, errors.New(util.ErrForbidden)
+	}
+
+	token, err := util.GenerateRandomString(32)
+	if err != nil {
+		log.Error(err)
+		return nil, errors.New(util.ErrInternalServerError)
+	}
+
+	timeNow := util.TimeNow()
+	invitation := &model.ShopInvitation{
+		ShopID:    shopID,
+		Email:     req.Email,
+		Phone:     req.Phone,
+		Role:      req.Role,
+		TokenHash: util.HashToken(token),
+		Status:    model.ShopInvitationPending,
+		InvitedBy: actor.UserID,
+		ExpiresAt: timeNow.Add(s.cfg.ShopMemberConfig.InvitationDuration * time.Second),
+		CreatedAt: timeNow,
+		UpdatedAt: timeNow,
+	}
+	if err := s.repo.ShopMemberRepositoryCreateInvitation(ctx, invitation); err != nil {
+		return nil, err
+	}
+
+	channel, destination := util.ChannelEmail, req.Email
+	if req.Phone != "" {
+		channel, destination = util.ChannelPhone, req.Phone
+	}
+	message := fmt.Sprintf("You are invited to join the shop %v on simcomm as %v, your invitation token is %v", shop.Name, req.Role, token)
+	if err := s.notifier.Send(ctx, channel, destination, message); err != nil {
+		log.Error(err)
+		return nil, errors.New(util.ErrInternalServerError)
+	}
+	return invitation, nil
+}
+
+// ShopMemberServiceGetInvitations lists the invitations of a shop that are not
+// accepted or revoked yet
+func (s *shopService) ShopMemberServiceGetInvitations(ctx context.Context, shopID int) ([]model.ShopInvitation, error) {
+	if _, _, err := authorizeShopMember(ctx, s.repo, shopID, shopManagerRoles...); err != nil {
+		return nil, err
+	}
+	return s.repo.ShopMemberRepositoryGetPendingInvitations(ctx, shopID)
+}
+
+func (s *shopService) ShopMemberServiceRevokeInvitation(ctx context.Context, shopID int, invitationID int) error {
+	_, actor, err := authorizeShopMember(ctx, s.repo, shopID, shopManagerRoles...)
+	if err != nil {
+		return err
+	}
+
+	invitation, err := s.repo.ShopMemberRepositoryGetInvitation(ctx, shopID, invitationID)
+	if err != nil {
+		return err
+	}
+	if invitation.Status != model.ShopInvitationPending {
+		return errors.New(util.ErrShopInvitationNotFound)
+	}
+	if !actor.CanManage(invitation.Role) {
+		return errors.New(util.ErrForbidden)
+	}
+
+	invitation.Status = model.ShopInvitationRevoked
+	invitation.UpdatedAt = util.TimeNow()
+	return s.repo.ShopMemberRepositoryUpdateInvitation(ctx, invitation)
+}
+
+// ShopMemberServiceAcceptInvitation adds the authenticated user to the shop, the user
+// must have verified the email or phone the invitation was sent to. The membership
+// only gives access to the shop, the roles of the user are left as they are
+func (s *shopService) ShopMemberServiceAcceptInvitation(ctx context.Context, req model.AcceptShopInvitationRequest) (*model.ShopMember, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	authUser, ok := util.GetAuthUser(ctx)
+	if !ok {
+		return nil, errors.New(util.ErrForbidden)
+	}
+
+	invitation, err := s.repo.ShopMemberRepositoryGetInvitationByTokenHash(ctx, util.HashToken(req.Token))
+	if err != nil {
+		return nil, err
+	}
+	timeNow := util.TimeNow()
+	if !invitation.IsOpen(timeNow) {
+		return nil, errors.New(util.ErrInvalidShopInvitation)
+	}
+
+	user, err := s.userRepo.Get(ctx, authUser.ID)
+	if err != nil {
+		log.Error(err)
+		return nil, errors.New(util.ErrUserNotFound)
+	}
+	emailMatches := invitation.Email != "" && invitation.Email == user.Email && user.UserDetail.EmailVerified
+	phoneMatches := invitation.Phone != "" && invitation.Phone == user.Phone && user.UserDetail.PhoneVerified
+	if !emailMatches && !phoneMatches {
+		return nil, errors.New(util.ErrShopInvitationMismatch)
+	}
+
+	invitation.Status = model.ShopInvitationAccepted
+	invitation.AcceptedBy = &user.ID
+	invitation.AcceptedAt = &timeNow
+	invitation.UpdatedAt = timeNow
+	member := &model.ShopMember{
+		ShopID:    invitation.ShopID,
+		UserID:    user.ID,
+		Role:      invitation.Role,
+		InvitedBy: &invitation.InvitedBy,
+		CreatedAt: timeNow,
+		UpdatedAt: timeNow,
+	}
+	if err := s.repo.ShopMemberRepositoryAcceptInvitation(ctx, invitation, member); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"simcomm-monolith/internal/model"
+	"simcomm-monolith/internal/repository/mocks"
+	servicemocks "simcomm-monolith/internal/service/mocks"
+	"simcomm-monolith/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type shopServiceMocks struct {
+	wspSvc      *servicemocks.WarehouseService
+	repo        *mocks.ShopRepository
+	productRepo *mocks.ProductRepository
+	userRepo    *mocks.UserRepository
+	redisRepo   *mocks.RedisRepository
+	storage     *mocks.Storage
+	notifier    *mocks.Notifier
+	queue       *mocks.Queue
+}
+
+func newTestShopService(t *testing.T) (*shopService, shopServiceMocks) {
+	t.Helper()
+	m := shopServiceMocks{
+		wspSvc:      &servicemocks.WarehouseService{},
+		repo:        &mocks.ShopRepository{},
+		productRepo: &mocks.ProductRepository{},
+		userRepo:    &mocks.UserRepository{},
+		redisRepo:   &mocks.RedisRepository{},
+		storage:     &mocks.Storage{},
+		notifier:    &mocks.Notifier{},
+		queue:       &mocks.Queue{},
+	}
+	t.Cleanup(func() {
+		m.repo.AssertExpectations(t)
+		m.userRepo.AssertExpectations(t)
+		m.redisRepo.AssertExpectations(t)
+	})
+	return NewShopService(m.wspSvc, m.repo, m.productRepo, m.userRepo, m.redisRepo, m.storage, m.notifier, m.queue, testConfig()), m
+}
+
+func TestShopMemberServiceAcceptInvitation(t *testing.T) {
+	tests := []struct {
+		name       string
+		userRoles  []string
+		shopRole   string
+		invitation func(invitation *model.ShopInvitation)
+		wantErr    string
+	}{
+		{
+			name:      "customer invited as manager stays a customer",
+			userRoles: []string{util.RoleCustomer},
+			shopRole:  model.ShopRoleManager,
+		},
+		{
+			name:      "customer invited as staff stays a customer",
+			userRoles: []string{util.RoleCustomer},
+			shopRole:  model.ShopRoleStaff,
+		},
+		{
+			name:      "seller invited as manager keeps the roles",
+			userRoles: []string{util.RoleSeller},
+			shopRole:  model.ShopRoleManager,
+		},
+		{
+			name:      "expired invitation",
+			userRoles: []string{util.RoleCustomer},
+			shopRole:  model.ShopRoleStaff,
+			invitation: func(invitation *model.ShopInvitation) {
+				invitation.ExpiresAt = time.Now().Add(-time.Hour)
+			},
+			wantErr: util.ErrInvalidShopInvitation,
+		},
+		{
+			name:      "invitation sent to another email",
+			userRoles: []string{util.RoleCustomer},
+			shopRole:  model.ShopRoleStaff,
+			invitation: func(invitation *model.ShopInvitation) {
+				invitation.Email = "someone@example.com"
+			},
+			wantErr: util.ErrShopInvitationMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newTestShopService(t)
+			invitation := &model.ShopInvitation{
+				ID:        3,
+				ShopID:    7,
+				Email:     "jane@example.com",
+				Role:      tt.shopRole,
+				Status:    model.ShopInvitationPending,
+				InvitedBy: 5,
+				ExpiresAt: time.Now().Add(time.Hour),
+			}
+			if tt.invitation != nil {
+				tt.invitation(invitation)
+			}
+			m.repo.On("ShopMemberRepositoryGetInvitationByTokenHash", mock.Anything, util.HashToken("token")).Return(invitation, nil)
+
+			user := &model.User{ID: 1, Email: "jane@example.com", UserDetail: model.UserDetail{
+				Roles:         tt.userRoles,
+				EmailVerified: true,
+			}}
+			if tt.wantErr != util.ErrInvalidShopInvitation {
+				m.userRepo.On("Get", mock.Anything, 1).Return(user, nil)
+			}
+			if tt.wantErr == "" {
+				m.repo.On("ShopMemberRepositoryAcceptInvitation", mock.Anything, invitation, mock.MatchedBy(func(member *model.ShopMember) bool {
+					return member.ShopID == 7 && member.UserID == 1
+				})).Return(nil)
+			}
+
+			ctx := util.SetAuthUser(context.Background(), util.AuthUser{ID: 1, Roles: tt.userRoles})
+			member, err := svc.ShopMemberServiceAcceptInvitation(ctx, model.AcceptShopInvitationRequest{Token: "token"})
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.shopRole, member.Role)
+			assert.Equal(t, model.ShopInvitationAccepted, invitation.Status)
+			assert.Equal(t, tt.userRoles, user.UserDetail.Roles)
+		})
+	}
+}
+
+func TestShopMemberServiceAcceptInvitationAlreadyMember(t *testing.T) {
+	svc, m := newTestShopService(t)
+	invitation := &model.ShopInvitation{ID: 3, ShopID: 7, Email: "jane@example.com", Role: model.ShopRoleStaff,
+		Status: model.ShopInvitationPending, ExpiresAt: time.Now().Add(time.Hour)}
+	m.repo.On("ShopMemberRepositoryGetInvitationByTokenHash", mock.Anything, util.HashToken("token")).Return(invitation, nil)
+	m.userRepo.On("Get", mock.Anything, 1).Return(&model.User{ID: 1, Email: "jane@example.com", UserDetail: model.UserDetail{
+		Roles: []string{util.RoleCustomer}, EmailVerified: true,
+	}}, nil)
+	m.repo.On("ShopMemberRepositoryAcceptInvitation", mock.Anything, invitation, mock.Anything).
+		Return(errors.New(util.ErrShopMemberAlreadyExists))
+
+	ctx := util.SetAuthUser(context.Background(), util.AuthUser{ID: 1, Roles: []string{util.RoleCustomer}})
+	_, err := svc.ShopMemberServiceAcceptInvitation(ctx, model.AcceptShopInvitationRequest{Token: "token"})
+	assert.EqualError(t, err, util.ErrShopMemberAlreadyExists)
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"simcomm-monolith/config"
 	"simcomm-monolith/internal/model"
 	"simcomm-monolith/internal/repository"
 	"simcomm-monolith/util"
+	"time"
 
 	log "github.com/labstack/gommon/log"
 	amqp "github.com/rabbitmq/amqp091-go"
@@ -22,32 +25,55 @@ type ShopService interface {
 	GetAll(ctx context.Context) ([]model.Shop, error)
 	Update(ctx context.Context, shop *model.Shop) error
 	Delete(ctx context.Context, id int) error
+	GetStatusHistory(ctx context.Context, id int) ([]model.StatusChange, error)
+	UploadImage(ctx context.Context, id int, file io.Reader) (*model.ImageData, error)
+	GetStorefront(ctx context.Context, req model.StorefrontRequest) (*model.Storefront, error)
 
 	ShopProductService
+	ShopMemberService
 
 	CreateTransferProduct(ctx context.Context, tp *model.TransferProduct) error
 	ProcessRTPQueue(ctx context.Context, msg amqp.Delivery) error
 }
 
 type shopService struct {
-	wspSvc    WarehouseService
-	repo      repository.ShopRepository
-	redisRepo repository.RedisRepository
-	queue     repository.Queue
-	cfg       *config.Config
+	wspSvc      WarehouseService
+	repo        repository.ShopRepository
+	productRepo repository.ProductRepository
+	userRepo    repository.UserRepository
+	redisRepo   repository.RedisRepository
+	storage     repository.Storage
+	notifier    repository.Notifier
+	queue       repository.Queue
+	cfg         *config.Config
 }
 
-func NewShopService(wspSvc WarehouseService, repo repository.ShopRepository, redisRepo repository.RedisRepository, q repository.Queue, cfg *config.Config) *shopService {
+func NewShopService(wspSvc WarehouseService, repo repository.ShopRepository, productRepo repository.ProductRepository, userRepo repository.UserRepository, redisRepo repository.RedisRepository, storage repository.Storage, notifier repository.Notifier, q repository.Queue, cfg *config.Config) *shopService {
 	return &shopService{
-		wspSvc:    wspSvc,
-		repo:      repo,
-		redisRepo: redisRepo,
-		queue:     q,
-		cfg:       cfg,
+		wspSvc:      wspSvc,
+		repo:        repo,
+		productRepo: productRepo,
+		userRepo:    userRepo,
+		redisRepo:   redisRepo,
+		storage:     storage,
+		notifier:    notifier,
+		queue:       q,
+		cfg:         cfg,
 	}
 }
 
 func (s *shopService) Create(ctx context.Context, shop *model.Shop) error {
+	authUser, ok := util.GetAuthUser(ctx)
+	if !ok {
+		return errors.New(util.ErrForbidden)
+	}
+	if !authUser.HasRole(util.RoleAdmin) {
+		shop.UserID = authUser.ID
+	}
+	if err := applyInitialStatus(ctx, shop, shop.Status); err != nil {
+		return err
+	}
+
 	timeNow := util.TimeNow()
 	shop.CreatedAt = timeNow
 	shop.UpdatedAt = timeNow
@@ -66,12 +92,92 @@ func (s *shopService) GetAll(ctx context.Context) ([]model.Shop, error) {
 	return s.repo.GetAll(ctx)
 }
 
+// Update rejects a status the stored status cannot move to
 func (s *shopService) Update(ctx context.Context, shop *model.Shop) error {
-	return s.repo.Update(ctx, shop)
+	existing, err := s.authorizeShop(ctx, shop.ID, shopManagerRoles...)
+	if err != nil {
+		return err
+	}
+	if err := applyStatus(ctx, shop, existing.Status, shop.Status); err != nil {
+		return err
+	}
+	shop.UserID = existing.UserID
+	if err := s.repo.Update(ctx, shop); err != nil {
+		return err
+	}
+	invalidateStorefronts(ctx, s.redisRepo, shop.ID)
+	return nil
 }
 
+// Delete is left to the owner of the shop
 func (s *shopService) Delete(ctx context.Context, id int) error {
-	return s.repo.Delete(ctx, id)
+	if _, err := s.authorizeShop(ctx, id, model.ShopRoleOwner); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	invalidateStorefronts(ctx, s.redisRepo, id)
+	return nil
+}
+
+func (s *shopService) GetStatusHistory(ctx context.Context, id int) ([]model.StatusChange, error) {
+	if _, err := s.authorizeShop(ctx, id, shopMemberRoles...); err != nil {
+		return nil, err
+	}
+	return s.repo.GetStatusHistory(ctx, id)
+}
+
+// UploadImage replaces the shop image and its thumbnail
+func (s *shopService) UploadImage(ctx context.Context, id int, file io.Reader) (*model.ImageData, error) {
+	shop, err := s.authorizeShop(ctx, id, shopManagerRoles...)
+	if err != nil {
+		return nil, err
+	}
+
+	image, err := uploadImage(ctx, s.storage, s.cfg.StorageConfig, fmt.Sprintf("shops/%v", id), file)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := model.ImageData{ImageURL: shop.Detail.ImageURL, ThumbnailURL: shop.Detail.ThumbnailURL}
+	shop.Detail.ImageURL = image.ImageURL
+	shop.Detail.ThumbnailURL = image.ThumbnailURL
+	shop.UpdatedAt = util.TimeNow()
+	if err := s.repo.Update(ctx, shop); err != nil {
+		log.Error(err)
+		deleteImage(ctx, s.storage, *image)
+		return nil, err
+	}
+
+	deleteImage(ctx, s.storage, previous)
+	invalidateStorefronts(ctx, s.redisRepo, id)
+	return image, nil
+}
+
+// authorizeShop ensures the authenticated user is a member of the shop with one of
+// the roles, admin can access any shop
+func (s *shopService) authorizeShop(ctx context.Context, shopID int, roles ...string) (*model.Shop, error) {
+	shop, _, err := authorizeShopMember(ctx, s.repo, shopID, roles...)
+	return shop, err
+}
+
+// authorizeShopProduct ensures the authenticated user is a member of the shop of the
+// shop product with one of the roles
+func (s *shopService) authorizeShopProduct(ctx context.Context, shopProductID int, roles ...string) (*model.ShopProduct, error) {
+	shopProduct, err := s.repo.ShopProductRepositoryGet(ctx, shopProductID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(util.ErrShopProductNotFound)
+		}
+		log.Error(err)
+		return nil, err
+	}
+
+	if _, err := s.authorizeShop(ctx, shopProduct.ShopID, roles...); err != nil {
+		return nil, err
+	}
+	return shopProduct, nil
 }
 
 // ShopProductService defines the methods for the ShopProduct service
@@ -79,43 +185,319 @@ type ShopProductService interface {
 	ShopProductServiceCreate(ctx context.Context, shopproduct *model.ShopProduct) error
 	ShopProductServiceGet(ctx context.Context, id int) (*model.ShopProduct, error)
 	ShopProductServiceGetAll(ctx context.Context) ([]model.ShopProduct, error)
+	ShopProductServiceGetByShopID(ctx context.Context, req model.ShopProductListRequest) (*model.ShopProductList, error)
 	ShopProductServiceUpdate(ctx context.Context, shopproduct *model.ShopProduct) error
 	ShopProductServiceDelete(ctx context.Context, id int) error
+	ShopProductServiceGetStatusHistory(ctx context.Context, id int) ([]model.StatusChange, error)
+
+	ShopProductServiceGetPrices(ctx context.Context, id int) (*model.ShopProductPrices, error)
+	ShopProductServiceSetPrice(ctx context.Context, id int, req model.ShopProductPriceRequest) (*model.ShopProductPrice, error)
+	ShopProductServiceCancelPrice(ctx context.Context, id int, priceID int) error
+	RunPriceScheduler(ctx context.Context)
 }
 
+// ShopProductServiceCreate creates a shop product, its price is recorded as the first price history entry
 func (s *shopService) ShopProductServiceCreate(ctx context.Context, shopproduct *model.ShopProduct) error {
+	if _, err := s.authorizeShop(ctx, shopproduct.ShopID, shopManagerRoles...); err != nil {
+		return err
+	}
+
+	if err := s.validateVariant(ctx, shopproduct); err != nil {
+		return err
+	}
+	if err := applyInitialStatus(ctx, shopproduct, shopproduct.Status); err != nil {
+		return err
+	}
+
+	if shopproduct.Price < 0 {
+		return errors.New(util.ErrInvalidPrice)
+	}
+	if shopproduct.Currency == "" {
+		shopproduct.Currency = s.cfg.PricingConfig.DefaultCurrency
+	}
+	currency, ok := util.NormalizeCurrency(shopproduct.Currency)
+	if !ok {
+		return errors.New(util.ErrUnsupportedCurrency)
+	}
+	shopproduct.Currency = currency
+
+	authUser, _ := util.GetAuthUser(ctx)
 	timeNow := util.TimeNow()
 	shopproduct.CreatedAt = timeNow
 	shopproduct.UpdatedAt = timeNow
-	err := s.repo.ShopProductRepositoryCreate(ctx, shopproduct)
+	price := &model.ShopProductPrice{
+		Price:       shopproduct.Price,
+		Currency:    shopproduct.Currency,
+		EffectiveAt: timeNow,
+		AppliedAt:   &timeNow,
+		CreatedBy:   authUser.ID,
+		CreatedAt:   timeNow,
+	}
+	err := s.repo.ShopProductRepositoryCreate(ctx, shopproduct, price)
 	if err != nil {
 		log.Error(err)
+		return err
 	}
-	return err
+	invalidateStorefronts(ctx, s.redisRepo, shopproduct.ShopID)
+	return nil
 }
 
+// ShopProductServiceGet retrieves a shop product, users who are not members of the
+// shop only see it while it is active
 func (s *shopService) ShopProductServiceGet(ctx context.Context, id int) (*model.ShopProduct, error) {
-	return s.repo.ShopProductRepositoryGet(ctx, id)
+	shopProduct, err := s.repo.ShopProductRepositoryGet(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(util.ErrShopProductNotFound)
+		}
+		log.Error(err)
+		return nil, err
+	}
+	if shopProduct.Status == model.StatusActive {
+		return shopProduct, nil
+	}
+
+	if _, err := s.authorizeShop(ctx, shopProduct.ShopID, shopMemberRoles...); err != nil {
+		if err.Error() == util.ErrForbidden {
+			return nil, errors.New(util.ErrShopProductNotFound)
+		}
+		return nil, err
+	}
+	return shopProduct, nil
 }
 
 func (s *shopService) ShopProductServiceGetAll(ctx context.Context) ([]model.ShopProduct, error) {
 	return s.repo.ShopProductRepositoryGetAll(ctx)
 }
 
+// ShopProductServiceGetByShopID lists the shop products of a shop, users who are not
+// members of the shop only see the active ones
+func (s *shopService) ShopProductServiceGetByShopID(ctx context.Context, req model.ShopProductListRequest) (*model.ShopProductList, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	if _, _, err := authorizeShopMember(ctx, s.repo, req.ShopID, shopMemberRoles...); err != nil {
+		if err.Error() != util.ErrForbidden {
+			return nil, err
+		}
+		if req.Status != "" && req.Status != model.StatusActive {
+			return nil, err
+		}
+		req.Status = model.StatusActive
+	}
+
+	shopProducts, total, err := s.repo.ShopProductRepositoryGetByShopID(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &model.ShopProductList{
+		ShopProducts: shopProducts,
+		Total:        total,
+		Page:         req.Page,
+		Limit:        req.Limit,
+	}, nil
+}
+
 func (s *shopService) ShopProductServiceUpdate(ctx context.Context, shopproduct *model.ShopProduct) error {
-	return s.repo.ShopProductRepositoryUpdate(ctx, shopproduct)
+	existing, err := s.authorizeShopProduct(ctx, shopproduct.ID, shopManagerRoles...)
+	if err != nil {
+		return err
+	}
+	if err := applyStatus(ctx, shopproduct, existing.Status, shopproduct.Status); err != nil {
+		return err
+	}
+	shopproduct.ShopID = existing.ShopID
+	shopproduct.ProductID = existing.ProductID
+	shopproduct.VariantID = existing.VariantID
+	// the price only changes through ShopProductServiceSetPrice so it is always in the history
+	shopproduct.Price = existing.Price
+	shopproduct.Currency = existing.Currency
+	if err := s.repo.ShopProductRepositoryUpdate(ctx, shopproduct); err != nil {
+		return err
+	}
+	invalidateStorefronts(ctx, s.redisRepo, shopproduct.ShopID)
+	return nil
 }
 
 func (s *shopService) ShopProductServiceDelete(ctx context.Context, id int) error {
-	return s.repo.ShopProductRepositoryDelete(ctx, id)
+	existing, err := s.authorizeShopProduct(ctx, id, shopManagerRoles...)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.ShopProductRepositoryDelete(ctx, id); err != nil {
+		return err
+	}
+	invalidateStorefronts(ctx, s.redisRepo, existing.ShopID)
+	return nil
+}
+
+func (s *shopService) ShopProductServiceGetStatusHistory(ctx context.Context, id int) ([]model.StatusChange, error) {
+	if _, err := s.authorizeShopProduct(ctx, id, shopMemberRoles...); err != nil {
+		return nil, err
+	}
+	return s.repo.ShopProductRepositoryGetStatusHistory(ctx, id)
+}
+
+// validateVariant ensures a shop product of a product with variants lists exactly one of its variants
+func (s *shopService) validateVariant(ctx context.Context, shopproduct *model.ShopProduct) error {
+	if shopproduct.VariantID != nil {
+		variant, err := s.productRepo.VariantRepositoryGet(ctx, *shopproduct.VariantID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New(util.ErrVariantNotFound)
+			}
+			log.Error(err)
+			return err
+		}
+		if variant.ProductID != shopproduct.ProductID {
+			return errors.New(util.ErrVariantNotFound)
+		}
+		return nil
+	}
+
+	variants, err := s.productRepo.VariantRepositoryGetByProductID(ctx, shopproduct.ProductID)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	if len(variants) > 0 {
+		return errors.New(util.ErrVariantRequired)
+	}
+	return nil
+}
+
+// ShopProductServiceGetPrices returns the current price with the scheduled and past price changes
+func (s *shopService) ShopProductServiceGetPrices(ctx context.Context, id int) (*model.ShopProductPrices, error) {
+	if _, err := s.repo.ShopProductRepositoryGet(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(util.ErrShopProductNotFound)
+		}
+		log.Error(err)
+		return nil, err
+	}
+
+	prices, err := s.repo.ShopProductRepositoryGetPrices(ctx, id)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	timeNow := util.TimeNow()
+	result := &model.ShopProductPrices{
+		Scheduled: []model.ShopProductPrice{},
+		History:   []model.ShopProductPrice{},
+	}
+	for i := range prices {
+		if prices[i].EffectiveAt.After(timeNow) {
+			// prices are newest first, scheduled changes are listed in the order they apply
+			result.Scheduled = append([]model.ShopProductPrice{prices[i]}, result.Scheduled...)
+			continue
+		}
+		if result.Current == nil {
+			result.Current = &prices[i]
+		}
+		result.History = append(result.History, prices[i])
+	}
+	return result, nil
+}
+
+// ShopProductServiceSetPrice changes the price now, or schedules the change when EffectiveAt is in the future
+func (s *shopService) ShopProductServiceSetPrice(ctx context.Context, id int, req model.ShopProductPriceRequest) (*model.ShopProductPrice, error) {
+	shopProduct, err := s.authorizeShopProduct(ctx, id, shopManagerRoles...)
+	if err != nil {
+		return nil, err
+	}
+
+	authUser, _ := util.GetAuthUser(ctx)
+	timeNow := util.TimeNow()
+	price := &model.ShopProductPrice{
+		ShopProductID: id,
+		Price:         req.Price,
+		Currency:      req.Currency,
+		EffectiveAt:   timeNow,
+		CreatedBy:     authUser.ID,
+		CreatedAt:     timeNow,
+	}
+	if req.EffectiveAt != nil && req.EffectiveAt.After(timeNow) {
+		price.EffectiveAt = *req.EffectiveAt
+	} else {
+		price.AppliedAt = &timeNow
+	}
+
+	err = s.repo.ShopProductRepositoryCreatePrice(ctx, price)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	if price.AppliedAt != nil {
+		invalidateStorefronts(ctx, s.redisRepo, shopProduct.ShopID)
+	}
+	return price, nil
 }
 
+// ShopProductServiceCancelPrice removes a scheduled price change, applied changes stay in the history
+func (s *shopService) ShopProductServiceCancelPrice(ctx context.Context, id int, priceID int) error {
+	if _, err := s.authorizeShopProduct(ctx, id, shopManagerRoles...); err != nil {
+		return err
+	}
+	return s.repo.ShopProductRepositoryDeleteScheduledPrice(ctx, id, priceID)
+}
+
+// RunPriceScheduler applies scheduled price changes once they become effective, until ctx is done
+func (s *shopService) RunPriceScheduler(ctx context.Context) {
+	if s.cfg.PricingConfig.SchedulerInterval <= 0 {
+		log.Warn("price scheduler is disabled, scheduled price changes are not applied")
+		return
+	}
+
+	ticker := time.NewTicker(s.cfg.PricingConfig.SchedulerInterval * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			shopIDs, err := s.repo.ShopProductRepositoryApplyDuePrices(ctx, util.TimeNow())
+			if err != nil {
+				log.Error(err)
+				continue
+			}
+			if len(shopIDs) > 0 {
+				log.Infof("applied scheduled price changes of %v shops", len(shopIDs))
+				invalidateStorefronts(ctx, s.redisRepo, shopIDs...)
+			}
+		}
+	}
+}
+
+// CreateTransferProduct moves stock between two warehouses of the shop of the shop
+// product, any member of the shop can transfer
 func (s *shopService) CreateTransferProduct(ctx context.Context, tp *model.TransferProduct) error {
+	authorized, err := s.authorizeShopProduct(ctx, tp.ShopProductID, shopMemberRoles...)
+	if err != nil {
+		return err
+	}
+	for _, warehouseID := range []int{tp.WarehouseIDSource, tp.WarehouseIDDestination} {
+		// a warehouse of a shop the user is not a member of is reported as missing
+		warehouse, err := s.wspSvc.Get(ctx, warehouseID)
+		if err != nil {
+			if err.Error() == util.ErrForbidden || err.Error() == util.ErrWarehouseNotFound {
+				return errors.New(util.ErrWarehouseNotFound)
+			}
+			return err
+		}
+		if warehouse.ShopID != authorized.ShopID {
+			return errors.New(util.ErrWarehouseNotFound)
+		}
+	}
+
 	timeNow := util.TimeNow()
 	chShopProduct := make(chan model.ShopProduct, 1)
 	eg, egCtx := errgroup.WithContext(ctx)
 	eg.Go(func() error {
-		shopProduct, err := s.ShopProductServiceGet(egCtx, tp.ShopProductID)
+		shopProduct, err := s.repo.ShopProductRepositoryGet(egCtx, tp.ShopProductID)
 		if err != nil && err != gorm.ErrRecordNotFound {
 			return err
 		}
@@ -161,11 +543,12 @@ func (s *shopService) CreateTransferProduct(ctx context.Context, tp *model.Trans
 		},
 	}
 
-	err := s.repo.ShopProductRepositoryCreateTransferProduct(ctx, tp, &shopProduct, s.queue)
+	err = s.repo.ShopProductRepositoryCreateTransferProduct(ctx, tp, &shopProduct, s.queue)
 	if err != nil {
 		log.Error(err)
 		return err
 	}
+	invalidateStorefronts(ctx, s.redisRepo, shopProduct.ShopID)
 
 	return nil
 }
@@ -182,7 +565,7 @@ func (s *shopService) ProcessRTPQueue(ctx context.Context, msg amqp.Delivery) er
 
 	chShopProduct := make(chan model.ShopProduct, 1)
 	eg.Go(func() error {
-		shopProduct, err := s.ShopProductServiceGet(egCtx, rtp.ShopProductID)
+		shopProduct, err := s.repo.ShopProductRepositoryGet(egCtx, rtp.ShopProductID)
 		if err != nil && err != gorm.ErrRecordNotFound {
 			return err
 		}
@@ -235,6 +618,7 @@ func (s *shopService) ProcessRTPQueue(ctx context.Context, msg amqp.Delivery) er
 		log.Error(err)
 		return err
 	}
+	invalidateStorefronts(ctx, s.redisRepo, shopProduct.ShopID)
 
 	return nil
 }
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"simcomm-monolith/internal/model"
+	"simcomm-monolith/util"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestShopProductServiceGetByShopID(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		status     model.Status
+		member     bool
+		wantErr    string
+		wantStatus model.Status
+	}{
+		{name: "owner sees every status", ctx: sellerContext(5), wantStatus: ""},
+		{name: "member filters drafts", ctx: sellerContext(6), member: true, status: model.StatusDraft, wantStatus: model.StatusDraft},
+		{name: "customer sees only active listings", ctx: customerContext(1), wantStatus: model.StatusActive},
+		{name: "customer cannot list drafts", ctx: customerContext(1), status: model.StatusDraft, wantErr: util.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newTestShopService(t)
+			m.repo.On("Get", mock.Anything, 7).Return(&model.Shop{ID: 7, UserID: 5, Status: model.StatusActive}, nil)
+			authUser, _ := util.GetAuthUser(tt.ctx)
+			if authUser.ID != 5 {
+				if tt.member {
+					m.repo.On("ShopMemberRepositoryGet", mock.Anything, 7, authUser.ID).Return(&model.ShopMember{ShopID: 7, UserID: authUser.ID, Role: model.ShopRoleStaff}, nil)
+				} else {
+					m.repo.On("ShopMemberRepositoryGet", mock.Anything, 7, authUser.ID).Return(nil, errors.New(util.ErrShopMemberNotFound))
+				}
+			}
+			if tt.wantErr == "" {
+				m.repo.On("ShopProductRepositoryGetByShopID", mock.Anything, mock.MatchedBy(func(req model.ShopProductListRequest) bool {
+					return req.ShopID == 7 && req.Status == tt.wantStatus && req.Page == 1 && req.Limit == 20
+				})).Return([]model.ShopProduct{{ID: 1, ShopID: 7}}, int64(1), nil)
+			}
+
+			list, err := svc.ShopProductServiceGetByShopID(tt.ctx, model.ShopProductListRequest{ShopID: 7, Status: tt.status})
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), list.Total)
+			assert.Len(t, list.ShopProducts, 1)
+		})
+	}
+}
+
+func TestShopProductServiceGet(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		status  model.Status
+		member  bool
+		wantErr string
+	}{
+		{name: "customer sees an active listing", ctx: customerContext(1), status: model.StatusActive},
+		{name: "member sees a draft", ctx: sellerContext(6), status: model.StatusDraft, member: true},
+		{name: "customer does not see a draft", ctx: customerContext(1), status: model.StatusDraft, wantErr: util.ErrShopProductNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newTestShopService(t)
+			m.repo.On("ShopProductRepositoryGet", mock.Anything, 3).Return(&model.ShopProduct{ID: 3, ShopID: 7, Status: tt.status}, nil)
+			if tt.status != model.StatusActive {
+				authUser, _ := util.GetAuthUser(tt.ctx)
+				m.repo.On("Get", mock.Anything, 7).Return(&model.Shop{ID: 7, UserID: 5, Status: model.StatusActive}, nil)
+				if tt.member {
+					m.repo.On("ShopMemberRepositoryGet", mock.Anything, 7, authUser.ID).Return(&model.ShopMember{ShopID: 7, UserID: authUser.ID, Role: model.ShopRoleStaff}, nil)
+				} else {
+					m.repo.On("ShopMemberRepositoryGet", mock.Anything, 7, authUser.ID).Return(nil, errors.New(util.ErrShopMemberNotFound))
+				}
+			}
+
+			shopProduct, err := svc.ShopProductServiceGet(tt.ctx, 3)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 3, shopProduct.ID)
+		})
+	}
+}
+
+func TestShopServiceValidateVariant(t *testing.T) {
+	tests := []struct {
+		name    string
+		variant *model.ProductVariant
+		err     error
+		wantErr string
+	}{
+		{name: "variant of the product", variant: &model.ProductVariant{ID: 4, ProductID: 10}},
+		{name: "variant of another product", variant: &model.ProductVariant{ID: 4, ProductID: 11}, wantErr: util.ErrVariantNotFound},
+		{name: "unknown variant", err: gorm.ErrRecordNotFound, wantErr: util.ErrVariantNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newTestShopService(t)
+			m.productRepo.On("VariantRepositoryGet", mock.Anything, 4).Return(tt.variant, tt.err)
+
+			err := svc.validateVariant(context.Background(), &model.ShopProduct{ProductID: 10, VariantID: intPtr(4)})
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"simcomm-monolith/internal/model"
+	"simcomm-monolith/util"
+	"time"
+)
+
+// statusEntity is a product, shop, shop product or warehouse with a lifecycle status
+type statusEntity interface {
+	RecordStatusChange(from model.Status, to model.Status, by int, at time.Time)
+	KeepStatus(status model.Status)
+}
+
+// applyInitialStatus sets the status a new entity starts with, draft unless active is requested
+func applyInitialStatus(ctx context.Context, entity statusEntity, requested model.Status) error {
+	if requested == "" {
+		requested = model.StatusDraft
+	}
+	if !requested.IsInitial() {
+		return errors.New(util.ErrInvalidInitialStatus)
+	}
+
+	authUser, _ := util.GetAuthUser(ctx)
+	entity.RecordStatusChange("", requested, authUser.ID, util.TimeNow())
+	return nil
+}
+
+// applyStatus moves an entity from its stored status to the requested status,
+// an empty requested status keeps the stored status. Only admins lift or close a
+// suspension
+func applyStatus(ctx context.Context, entity statusEntity, stored model.Status, requested model.Status) error {
+	if requested == "" || requested == stored {
+		entity.KeepStatus(stored)
+		return nil
+	}
+	if !requested.IsValid() {
+		return errors.New(util.ErrInvalidStatus)
+	}
+	if !stored.CanTransitionTo(requested) {
+		return errors.New(util.ErrInvalidStatusTransition)
+	}
+
+	authUser, _ := util.GetAuthUser(ctx)
+	if stored == model.StatusSuspended && !authUser.HasRole(util.RoleAdmin) {
+		return errors.New(util.ErrForbidden)
+	}
+	entity.RecordStatusChange(stored, requested, authUser.ID, util.TimeNow())
+	return nil
+}
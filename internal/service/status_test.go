@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"simcomm-monolith/internal/model"
+	"simcomm-monolith/util"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		role       string
+		stored     model.Status
+		requested  model.Status
+		wantErr    string
+		wantStatus model.Status
+	}{
+		{name: "empty keeps the stored status", role: util.RoleSeller, stored: model.StatusActive, wantStatus: model.StatusActive},
+		{name: "seller activates a draft", role: util.RoleSeller, stored: model.StatusDraft, requested: model.StatusActive, wantStatus: model.StatusActive},
+		{name: "seller suspends", role: util.RoleSeller, stored: model.StatusActive, requested: model.StatusSuspended, wantStatus: model.StatusSuspended},
+		{name: "unknown status", role: util.RoleSeller, stored: model.StatusActive, requested: "sold", wantErr: util.ErrInvalidStatus},
+		{name: "archived is final", role: util.RoleAdmin, stored: model.StatusArchived, requested: model.StatusActive, wantErr: util.ErrInvalidStatusTransition},
+		{name: "seller cannot lift a suspension", role: util.RoleSeller, stored: model.StatusSuspended, requested: model.StatusActive, wantErr: util.ErrForbidden},
+		{name: "seller cannot archive a suspended entity", role: util.RoleSeller, stored: model.StatusSuspended, requested: model.StatusArchived, wantErr: util.ErrForbidden},
+		{name: "admin lifts a suspension", role: util.RoleAdmin, stored: model.StatusSuspended, requested: model.StatusActive, wantStatus: model.StatusActive},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := util.SetAuthUser(context.Background(), util.AuthUser{ID: 1, Roles: []string{tt.role}})
+			shop := &model.Shop{ID: 1, Status: tt.requested}
+
+			err := applyStatus(ctx, shop, tt.stored, tt.requested)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, shop.Status)
+		})
+	}
+}
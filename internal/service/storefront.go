@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"simcomm-monolith/internal/model"
+	"simcomm-monolith/internal/repository"
+	"simcomm-monolith/util"
+	"time"
+
+	log "github.com/labstack/gommon/log"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// invalidateStorefronts deletes the cached storefronts of the shops, a failure is
+// only logged since the cache expires on its own
+func invalidateStorefronts(ctx context.Context, redisRepo repository.RedisRepository, shopIDs ...int) {
+	if err := redisRepo.DeleteStorefronts(ctx, shopIDs...); err != nil {
+		log.Error(err)
+	}
+}
+
+// invalidateProductStorefronts deletes the cached storefronts of the shops listing
+// any of the products
+func invalidateProductStorefronts(ctx context.Context, redisRepo repository.RedisRepository, productRepo repository.ProductRepository, productIDs ...int) {
+	shopIDs, err := productRepo.GetShopIDs(ctx, productIDs...)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	invalidateStorefronts(ctx, redisRepo, shopIDs...)
+}
+
+// GetStorefront returns the public view of an active shop, pages are served from
+// the cache until the shop, its listings or their stock change
+func (s *shopService) GetStorefront(ctx context.Context, req model.StorefrontRequest) (*model.Storefront, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	cacheDuration := s.cfg.StorefrontConfig.CacheDuration * time.Second
+	if cacheDuration > 0 {
+		storefront, err := s.redisRepo.GetStorefront(ctx, req.ShopID, req.Page, req.Limit)
+		if err == nil {
+			return storefront, nil
+		}
+		if err != redis.Nil {
+			log.Error(err)
+		}
+	}
+
+	shop, err := s.repo.Get(ctx, req.ShopID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(util.ErrShopNotFound)
+		}
+		log.Error(err)
+		return nil, err
+	}
+	if shop.Status != model.StatusActive {
+		return nil, errors.New(util.ErrShopNotFound)
+	}
+
+	listings, total, err := s.repo.ShopProductRepositoryGetStorefront(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	storefront := &model.Storefront{
+		Shop:     shop.ToStorefront(),
+		Listings: listings,
+		Total:    total,
+		Page:     req.Page,
+		Limit:    req.Limit,
+	}
+	if cacheDuration > 0 {
+		if err := s.redisRepo.StoreStorefront(ctx, *storefront, cacheDuration); err != nil {
+			log.Error(err)
+		}
+	}
+	return storefront, nil
+}
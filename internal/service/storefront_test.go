@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"simcomm-monolith/internal/model"
+	"simcomm-monolith/util"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestShopServiceGetStorefront(t *testing.T) {
+	listings := []model.StorefrontListing{{
+		ShopProduct:   model.ShopProduct{ID: 1, ShopID: 7, ProductID: 10, Status: model.StatusActive},
+		Product:       model.Product{ID: 10, Name: "Runner", Status: model.StatusActive},
+		SellableStock: 4,
+	}}
+
+	tests := []struct {
+		name          string
+		cacheDuration time.Duration
+		setup         func(m shopServiceMocks)
+		wantErr       string
+		wantListings  int
+	}{
+		{
+			name:          "cached page is served without the database",
+			cacheDuration: 60,
+			setup: func(m shopServiceMocks) {
+				m.redisRepo.On("GetStorefront", mock.Anything, 7, 1, 20).Return(&model.Storefront{
+					Shop: model.StorefrontShop{ID: 7}, Listings: listings, Total: 1, Page: 1, Limit: 20,
+				}, nil)
+			},
+			wantListings: 1,
+		},
+		{
+			name:          "missing page is loaded and cached",
+			cacheDuration: 60,
+			setup: func(m shopServiceMocks) {
+				m.redisRepo.On("GetStorefront", mock.Anything, 7, 1, 20).Return(nil, redis.Nil)
+				m.repo.On("Get", mock.Anything, 7).Return(&model.Shop{ID: 7, UserID: 5, Name: "Acme", Status: model.StatusActive}, nil)
+				m.repo.On("ShopProductRepositoryGetStorefront", mock.Anything, mock.Anything).Return(listings, int64(1), nil)
+				m.redisRepo.On("StoreStorefront", mock.Anything, mock.MatchedBy(func(storefront model.Storefront) bool {
+					return storefront.Shop == model.StorefrontShop{ID: 7, Name: "Acme"} && storefront.Page == 1 && storefront.Limit == 20 && storefront.Total == 1
+				}), 60*time.Second).Return(nil)
+			},
+			wantListings: 1,
+		},
+		{
+			name: "cache disabled",
+			setup: func(m shopServiceMocks) {
+				m.repo.On("Get", mock.Anything, 7).Return(&model.Shop{ID: 7, Status: model.StatusActive}, nil)
+				m.repo.On("ShopProductRepositoryGetStorefront", mock.Anything, mock.Anything).Return([]model.StorefrontListing{}, int64(0), nil)
+			},
+		},
+		{
+			name: "inactive shop is hidden",
+			setup: func(m shopServiceMocks) {
+				m.repo.On("Get", mock.Anything, 7).Return(&model.Shop{ID: 7, Status: model.StatusSuspended}, nil)
+			},
+			wantErr: util.ErrShopNotFound,
+		},
+		{
+			name: "unknown shop",
+			setup: func(m shopServiceMocks) {
+				m.repo.On("Get", mock.Anything, 7).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantErr: util.ErrShopNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newTestShopService(t)
+			svc.cfg.StorefrontConfig.CacheDuration = tt.cacheDuration
+			tt.setup(m)
+
+			storefront, err := svc.GetStorefront(context.Background(), model.StorefrontRequest{ShopID: 7})
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, storefront.Listings, tt.wantListings)
+			assert.Equal(t, 1, storefront.Page)
+			assert.Equal(t, 20, storefront.Limit)
+		})
+	}
+
+	svc, _ := newTestShopService(t)
+	_, err := svc.GetStorefront(context.Background(), model.StorefrontRequest{ShopID: 7, Limit: 101})
+	var errs model.ValidationErrors
+	assert.ErrorAs(t, err, &errs)
+}
+
+func TestInvalidateProductStorefronts(t *testing.T) {
+	_, m := newTestShopService(t)
+	m.productRepo.On("GetShopIDs", mock.Anything, 10, 11).Return([]int{7, 8}, nil)
+	m.redisRepo.On("DeleteStorefronts", mock.Anything, 7, 8).Return(nil)
+
+	invalidateProductStorefronts(context.Background(), m.redisRepo, m.productRepo, 10, 11)
+	m.productRepo.AssertExpectations(t)
+}
@@ -2,11 +2,15 @@ package service
 
 import (
 	"context"
+	"crypto/subtle"
 	"errors"
+	"fmt"
 	"simcomm-monolith/config"
 	"simcomm-monolith/internal/model"
 	"simcomm-monolith/internal/repository"
 	"simcomm-monolith/util"
+	"strings"
+	"time"
 
 	log "github.com/labstack/gommon/log"
 )
@@ -22,27 +26,92 @@ type UserService interface {
 	GetUserByIdentifier(ctx context.Context, identifier string) (*model.User, error)
 	SignUp(ctx context.Context, req model.SignUpRequest) error
 	Login(ctx context.Context, req model.LoginRequest) (model.LoginData, error)
+	Logout(ctx context.Context, token string) error
+	RefreshToken(ctx context.Context, req model.RefreshTokenRequest) (model.LoginData, error)
+
+	GrantRole(ctx context.Context, userID int, role string) error
+	RevokeRole(ctx context.Context, userID int, role string) error
+	Unlock(ctx context.Context, userID int) error
+
+	SendVerification(ctx context.Context, req model.SendVerificationRequest) error
+	VerifyContact(ctx context.Context, req model.VerifyContactRequest) error
+
+	ForgotPassword(ctx context.Context, req model.ForgotPasswordRequest) error
+	ResetPassword(ctx context.Context, req model.ResetPasswordRequest) error
+	ChangePassword(ctx context.Context, userID int, req model.ChangePasswordRequest) error
+
+	UpdateProfile(ctx context.Context, userID int, req model.UpdateProfileRequest) (*model.User, error)
+
+	GetAddresses(ctx context.Context, userID int) ([]model.UserAddress, error)
+	AddAddress(ctx context.Context, userID int, req model.AddressRequest) (*model.UserAddress, error)
+	UpdateAddress(ctx context.Context, userID int, addressID int, req model.AddressRequest) (*model.UserAddress, error)
+	DeleteAddress(ctx context.Context, userID int, addressID int) error
+
+	OIDCLogin(ctx context.Context, provider string) (model.OIDCLoginData, error)
+	OIDCCallback(ctx context.Context, provider string, code string, state string) (model.LoginData, error)
+
+	LoginTwoFactor(ctx context.Context, req model.TwoFactorLoginRequest) (model.LoginData, error)
+	EnrollTOTP(ctx context.Context, userID int) (model.TOTPEnrolmentData, error)
+	ConfirmTOTP(ctx context.Context, userID int, req model.TOTPCodeRequest) (model.RecoveryCodesData, error)
+	DisableTOTP(ctx context.Context, userID int, req model.DisableTOTPRequest) error
+	RegenerateRecoveryCodes(ctx context.Context, userID int, req model.TOTPCodeRequest) (model.RecoveryCodesData, error)
+
+	Deactivate(ctx context.Context, userID int) error
+	Reactivate(ctx context.Context, userID int) error
+	DeactivateSelf(ctx context.Context, userID int, req model.PasswordConfirmationRequest) error
+	Erase(ctx context.Context, userID int, req model.PasswordConfirmationRequest) error
+	Export(ctx context.Context, userID int) (model.UserExport, error)
 }
 
 type userService struct {
-	repo      repository.UserRepository
-	redisRepo repository.RedisRepository
-	cfg       *config.Config
+	repo          repository.UserRepository
+	shopRepo      repository.ShopRepository
+	orderRepo     repository.OrderRepository
+	redisRepo     repository.RedisRepository
+	notifier      repository.Notifier
+	oidcProviders map[string]repository.OIDCProvider
+	keySet        *util.KeySet
+	cfg           *config.Config
 }
 
-func NewUserService(repo repository.UserRepository, redisRepo repository.RedisRepository, cfg *config.Config) *userService {
+func NewUserService(
+	repo repository.UserRepository,
+	shopRepo repository.ShopRepository,
+	orderRepo repository.OrderRepository,
+	redisRepo repository.RedisRepository,
+	notifier repository.Notifier,
+	oidcProviders map[string]repository.OIDCProvider,
+	keySet *util.KeySet,
+	cfg *config.Config,
+) *userService {
 	return &userService{
-		repo:      repo,
-		redisRepo: redisRepo,
-		cfg:       cfg,
+		repo:          repo,
+		shopRepo:      shopRepo,
+		orderRepo:     orderRepo,
+		redisRepo:     redisRepo,
+		notifier:      notifier,
+		oidcProviders: oidcProviders,
+		keySet:        keySet,
+		cfg:           cfg,
 	}
 }
 
 func (s *userService) Create(ctx context.Context, user *model.User) error {
+	if user.Passsword == "" {
+		return errors.New(util.ErrInvalidPassword)
+	}
+
+	hashedPassword, err := util.HashPassword(user.Passsword)
+	if err != nil {
+		log.Error(err)
+		return errors.New(util.ErrInternalServerError)
+	}
+	user.Passsword = hashedPassword
+
 	timeNow := util.TimeNow()
 	user.CreatedAt = timeNow
 	user.UpdatedAt = timeNow
-	err := s.repo.Create(ctx, user)
+	err = s.repo.Create(ctx, user)
 	if err != nil {
 		log.Error(err)
 	}
@@ -58,15 +127,63 @@ func (s *userService) GetAll(ctx context.Context) ([]model.User, error) {
 }
 
 func (s *userService) Update(ctx context.Context, user *model.User) error {
-	return s.repo.Update(ctx, user)
+	existing, err := s.repo.Get(ctx, user.ID)
+	if err != nil {
+		log.Error(err)
+		return errors.New(util.ErrUserNotFound)
+	}
+
+	if user.Passsword == "" {
+		user.Passsword = existing.Passsword
+	} else {
+		hashedPassword, err := util.HashPassword(user.Passsword)
+		if err != nil {
+			log.Error(err)
+			return errors.New(util.ErrInternalServerError)
+		}
+		user.Passsword = hashedPassword
+	}
+	// admin can only reset two-factor authentication, enrolment is done by the user
+	if user.UserDetail.TwoFactor.Enabled && existing.UserDetail.TwoFactor.Enabled {
+		user.UserDetail.TwoFactor = existing.UserDetail.TwoFactor
+	} else {
+		user.UserDetail.TwoFactor = model.TwoFactor{}
+	}
+	user.CreatedAt = existing.CreatedAt
+	user.UpdatedAt = util.TimeNow()
+
+	err = s.repo.Update(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	if user.Passsword != existing.Passsword {
+		err = s.redisRepo.DeleteUserSessions(ctx, user.ID)
+		if err != nil {
+			log.Error(err)
+			return errors.New(util.ErrInternalServerError)
+		}
+	}
+	return nil
 }
 
+// Delete soft deletes the user and revokes its sessions, orders keep referencing the user
 func (s *userService) Delete(ctx context.Context, id int) error {
-	return s.repo.Delete(ctx, id)
+	err := s.repo.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = s.redisRepo.DeleteUserSessions(ctx, id)
+	if err != nil {
+		log.Error(err)
+		return errors.New(util.ErrInternalServerError)
+	}
+	return nil
 }
 
 func (s *userService) GetUserByIdentifier(ctx context.Context, identifier string) (*model.User, error) {
-	return s.repo.GetByIdentifier(ctx, identifier)
+	return s.repo.GetByIdentifier(ctx, util.NormalizeIdentifier(identifier))
 }
 
 func (s *userService) SignUp(ctx context.Context, req model.SignUpRequest) error {
@@ -84,7 +201,7 @@ func (s *userService) SignUp(ctx context.Context, req model.SignUpRequest) error
 		Email:     req.Email,
 		Passsword: hashedPassword,
 		UserDetail: model.UserDetail{
-			Roles: []string{req.Role},
+			Roles: []string{util.RoleCustomer},
 		},
 		CreatedAt: timeNow,
 		UpdatedAt: timeNow,
@@ -95,23 +212,1312 @@ func (s *userService) SignUp(ctx context.Context, req model.SignUpRequest) error
 		return err
 	}
 
+	for _, channel := range []string{util.ChannelEmail, util.ChannelPhone} {
+		if err := s.acquireVerificationCooldown(ctx, channel, contactDestination(user, channel)); err != nil {
+			continue
+		}
+		if err := s.sendVerificationCode(ctx, user, channel); err != nil {
+			log.Error(err)
+		}
+	}
+
 	return nil
 }
 
 func (s *userService) Login(ctx context.Context, req model.LoginRequest) (model.LoginData, error) {
 	loginData := model.LoginData{}
+	identifierKey := "identifier:" + strings.ToLower(req.Identifier)
+	ipKey := "ip:" + req.ClientIP
+
+	err := s.checkLoginBackoff(ctx, identifierKey, ipKey)
+	if err != nil {
+		return loginData, err
+	}
+
 	user, err := s.GetUserByIdentifier(ctx, req.Identifier)
 	if err != nil {
 		log.Error(err)
+		if err.Error() == util.ErrUserNotFound {
+			s.registerFailedLogin(ctx, nil, identifierKey, ipKey)
+			return loginData, errors.New(util.ErrInvalidCredential)
+		}
+		return loginData, err
+	}
+
+	err = s.checkAccountStatus(ctx, user)
+	if err != nil {
 		return loginData, err
 	}
 
+	err = util.ValidatePassword(req.Password, user.Passsword)
+	if err != nil {
+		if s.registerFailedLogin(ctx, user, identifierKey, ipKey) {
+			return loginData, errors.New(util.ErrAccountLocked)
+		}
+		return loginData, errors.New(util.ErrInvalidCredential)
+	}
+
+	// checked after the password so it does not reveal unverified accounts
+	if !user.UserDetail.EmailVerified && !user.UserDetail.PhoneVerified {
+		return loginData, errors.New(util.ErrContactNotVerified)
+	}
+
+	return s.completeLogin(ctx, user, identifierKey, ipKey)
+}
+
+// checkLoginBackoff rejects a login attempt while one of the attempt keys is backing off
+func (s *userService) checkLoginBackoff(ctx context.Context, keys ...string) error {
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		backoff, err := s.redisRepo.GetLoginBackoff(ctx, key)
+		if err != nil {
+			log.Error(err)
+			return errors.New(util.ErrInternalServerError)
+		}
+		if backoff > 0 {
+			return errors.New(util.ErrTooManyLoginAttempts)
+		}
+	}
+	return nil
+}
+
+// resetLoginAttempts clears the failed attempts once a login is complete
+func (s *userService) resetLoginAttempts(ctx context.Context, keys ...string) {
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		if err := s.redisRepo.ResetLoginAttempt(ctx, key); err != nil {
+			log.Error(err)
+		}
+	}
+}
+
+// checkAccountStatus rejects the login or refresh of a locked or deactivated account
+func (s *userService) checkAccountStatus(ctx context.Context, user *model.User) error {
+	locked, err := s.redisRepo.IsAccountLocked(ctx, user.ID)
+	if err != nil {
+		log.Error(err)
+		return errors.New(util.ErrInternalServerError)
+	}
+	if locked {
+		return errors.New(util.ErrAccountLocked)
+	}
+
+	if user.UserDetail.DeactivatedAt != nil {
+		return errors.New(util.ErrAccountDeactivated)
+	}
+	return nil
+}
+
+// completeLogin issues the tokens of an authenticated user, or a challenge
+// token when the user still has to pass two-factor authentication. The failed
+// attempts are only reset once the login is complete, so wrong second factor
+// codes keep counting toward the lock of the first step.
+func (s *userService) completeLogin(ctx context.Context, user *model.User, identifierKey string, ipKey string) (model.LoginData, error) {
+	loginData := model.LoginData{}
+	if user.UserDetail.TwoFactor.Enabled {
+		challengeToken, err := util.GenerateRandomString(32)
+		if err != nil {
+			log.Error(err)
+			return loginData, errors.New(util.ErrInternalServerError)
+		}
+
+		err = s.redisRepo.StoreLoginChallenge(ctx, model.LoginChallenge{
+			TokenHash:     util.HashToken(challengeToken),
+			UserID:        user.ID,
+			IdentifierKey: identifierKey,
+			ExpiredAt:     util.TimeNow().Add(s.cfg.TwoFactorConfig.ChallengeDuration * time.Second),
+		})
+		if err != nil {
+			log.Error(err)
+			return loginData, errors.New(util.ErrInternalServerError)
+		}
+
+		loginData.TwoFactorRequired = true
+		loginData.ChallengeToken = challengeToken
+		return loginData, nil
+	}
+	s.resetLoginAttempts(ctx, identifierKey, ipKey)
+
+	familyID, err := util.GenerateRandomString(16)
+	if err != nil {
+		log.Error(err)
+		return loginData, errors.New(util.ErrInternalServerError)
+	}
+
+	return s.issueTokens(ctx, user, familyID)
+}
+
+// registerFailedLogin counts the failed attempt per identifier and per client IP,
+// applies an exponential backoff and locks the account once the threshold is reached.
+// It reports whether the account has been locked.
+func (s *userService) registerFailedLogin(ctx context.Context, user *model.User, identifierKey string, ipKey string) bool {
+	cfg := s.cfg.LoginProtectionConfig
+	locked := false
+	for _, key := range []string{identifierKey, ipKey} {
+		if key == "" {
+			continue
+		}
+		count, err := s.redisRepo.IncrLoginAttempt(ctx, key, cfg.AttemptWindow*time.Second)
+		if err != nil {
+			log.Error(err)
+			continue
+		}
+
+		backoff := cfg.BackoffBase * time.Second << (count - 1)
+		if backoff <= 0 || backoff > cfg.BackoffMax*time.Second {
+			backoff = cfg.BackoffMax * time.Second
+		}
+		if err := s.redisRepo.SetLoginBackoff(ctx, key, backoff); err != nil {
+			log.Error(err)
+		}
+
+		if key == identifierKey && user != nil && cfg.MaxAttempts > 0 && count >= int64(cfg.MaxAttempts) {
+			if err := s.redisRepo.LockAccount(ctx, user.ID, cfg.LockDuration*time.Second); err != nil {
+				log.Error(err)
+				continue
+			}
+			log.Warnf("user %v locked after %v failed login attempts", user.ID, count)
+			locked = true
+		}
+	}
+	return locked
+}
+
+// issueTokens creates a new access token session and a new refresh token
+// within the given refresh token family
+func (s *userService) issueTokens(ctx context.Context, user *model.User, familyID string) (model.LoginData, error) {
+	loginData := model.LoginData{}
+	tokenID, err := util.GenerateRandomString(16)
+	if err != nil {
+		log.Error(err)
+		return loginData, errors.New(util.ErrInternalServerError)
+	}
+
+	refreshToken, err := util.GenerateRandomString(32)
+	if err != nil {
+		log.Error(err)
+		return loginData, errors.New(util.ErrInternalServerError)
+	}
+
 	cfg := s.cfg.AuthTokenConfig
-	token, err := util.GenerateToken(user.ID, cfg.Duration, cfg.SecretKey)
+	token, err := s.keySet.GenerateToken(user.ID, user.UserDetail.Roles, tokenID, cfg.Duration)
 	if err != nil {
 		return loginData, err
 	}
+
+	timeNow := util.TimeNow()
+	refreshExpiredAt := timeNow.Add(cfg.RefreshDuration * time.Second)
+	session := model.Session{
+		TokenID:   tokenID,
+		UserID:    user.ID,
+		Roles:     user.UserDetail.Roles,
+		FamilyID:  familyID,
+		CreatedAt: timeNow,
+		ExpiredAt: timeNow.Add(cfg.Duration * time.Second),
+	}
+	err = s.redisRepo.StoreSession(ctx, session)
+	if err != nil {
+		log.Error(err)
+		return loginData, errors.New(util.ErrInternalServerError)
+	}
+
+	err = s.redisRepo.StoreTokenFamily(ctx, model.TokenFamily{
+		ID:        familyID,
+		UserID:    user.ID,
+		TokenID:   tokenID,
+		ExpiredAt: refreshExpiredAt,
+	})
+	if err != nil {
+		log.Error(err)
+		return loginData, errors.New(util.ErrInternalServerError)
+	}
+
+	err = s.redisRepo.StoreRefreshToken(ctx, model.RefreshToken{
+		TokenHash: util.HashToken(refreshToken),
+		FamilyID:  familyID,
+		UserID:    user.ID,
+		ExpiredAt: refreshExpiredAt,
+	})
+	if err != nil {
+		log.Error(err)
+		return loginData, errors.New(util.ErrInternalServerError)
+	}
+
 	loginData.Token = token
+	loginData.RefreshToken = refreshToken
 
 	return loginData, nil
 }
+
+// RefreshToken rotates the given refresh token, presenting an already used
+// refresh token revokes the whole token family
+func (s *userService) RefreshToken(ctx context.Context, req model.RefreshTokenRequest) (model.LoginData, error) {
+	loginData := model.LoginData{}
+	refreshToken, err := s.redisRepo.GetRefreshToken(ctx, util.HashToken(req.RefreshToken))
+	if err != nil {
+		return loginData, errors.New(util.ErrInvalidToken)
+	}
+
+	firstUse, err := s.redisRepo.MarkRefreshTokenUsed(ctx, *refreshToken)
+	if err != nil {
+		log.Error(err)
+		return loginData, errors.New(util.ErrInternalServerError)
+	}
+	if !firstUse {
+		log.Warnf("refresh token reuse detected, revoking token family %v", refreshToken.FamilyID)
+		err = s.redisRepo.DeleteTokenFamily(ctx, refreshToken.FamilyID)
+		if err != nil {
+			log.Error(err)
+		}
+		return loginData, errors.New(util.ErrInvalidToken)
+	}
+
+	family, err := s.redisRepo.GetTokenFamily(ctx, refreshToken.FamilyID)
+	if err != nil {
+		return loginData, errors.New(util.ErrInvalidToken)
+	}
+
+	user, err := s.repo.Get(ctx, refreshToken.UserID)
+	if err != nil {
+		log.Error(err)
+		return loginData, errors.New(util.ErrInvalidToken)
+	}
+
+	// a locked or deactivated account cannot refresh, its family is revoked
+	err = s.checkAccountStatus(ctx, user)
+	if err != nil {
+		if err := s.redisRepo.DeleteTokenFamily(ctx, family.ID); err != nil {
+			log.Error(err)
+		}
+		return loginData, err
+	}
+
+	err = s.redisRepo.DeleteSession(ctx, family.TokenID)
+	if err != nil {
+		log.Error(err)
+		return loginData, errors.New(util.ErrInternalServerError)
+	}
+
+	return s.issueTokens(ctx, user, family.ID)
+}
+
+func (s *userService) Logout(ctx context.Context, token string) error {
+	claims, err := s.keySet.VerifyToken(token)
+	if err != nil {
+		return err
+	}
+
+	session, err := s.redisRepo.GetSession(ctx, claims.StandardClaims.Id)
+	if err != nil {
+		return errors.New(util.ErrInvalidToken)
+	}
+
+	err = s.redisRepo.DeleteSession(ctx, session.TokenID)
+	if err != nil {
+		log.Error(err)
+		return errors.New(util.ErrInternalServerError)
+	}
+
+	if session.FamilyID != "" {
+		err = s.redisRepo.DeleteTokenFamily(ctx, session.FamilyID)
+		if err != nil {
+			log.Error(err)
+			return errors.New(util.ErrInternalServerError)
+		}
+	}
+
+	return nil
+}
+
+func (s *userService) GrantRole(ctx context.Context, userID int, role string) error {
+	role = strings.ToLower(role)
+	if !util.IsValidRole(role) {
+		return errors.New(util.ErrInvalidRole)
+	}
+
+	user, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		log.Error(err)
+		return errors.New(util.ErrUserNotFound)
+	}
+
+	for _, userRole := range user.UserDetail.Roles {
+		if userRole == role {
+			return nil
+		}
+	}
+	user.UserDetail.Roles = append(user.UserDetail.Roles, role)
+
+	return s.updateRoles(ctx, user)
+}
+
+func (s *userService) RevokeRole(ctx context.Context, userID int, role string) error {
+	role = strings.ToLower(role)
+	user, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		log.Error(err)
+		return errors.New(util.ErrUserNotFound)
+	}
+
+	roles := make([]string, 0, len(user.UserDetail.Roles))
+	for _, userRole := range user.UserDetail.Roles {
+		if userRole != role {
+			roles = append(roles, userRole)
+		}
+	}
+	if len(roles) == len(user.UserDetail.Roles) {
+		return nil
+	}
+	user.UserDetail.Roles = roles
+
+	return s.updateRoles(ctx, user)
+}
+
+// updateRoles persists the user roles and revokes existing sessions so the
+// new roles take effect on the next login
+func (s *userService) updateRoles(ctx context.Context, user *model.User) error {
+	user.UpdatedAt = util.TimeNow()
+	err := s.repo.Update(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	err = s.redisRepo.DeleteUserSessions(ctx, user.ID)
+	if err != nil {
+		log.Error(err)
+		return errors.New(util.ErrInternalServerError)
+	}
+	return nil
+}
+
+func (s *userService) Unlock(ctx context.Context, userID int) error {
+	user, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		log.Error(err)
+		return errors.New(util.ErrUserNotFound)
+	}
+
+	err = s.redisRepo.UnlockAccount(ctx, user.ID)
+	if err != nil {
+		log.Error(err)
+		return errors.New(util.ErrInternalServerError)
+	}
+
+	for _, identifier := range []string{user.Email, user.Phone} {
+		err = s.redisRepo.ResetLoginAttempt(ctx, "identifier:"+strings.ToLower(identifier))
+		if err != nil {
+			log.Error(err)
+			return errors.New(util.ErrInternalServerError)
+		}
+	}
+
+	return nil
+}
+
+// SendVerification sends a new verification code throttled per destination, it does
+// not report whether the user exists and unknown identifiers are throttled the same way
+func (s *userService) SendVerification(ctx context.Context, req model.SendVerificationRequest) error {
+	user, err := s.GetUserByIdentifier(ctx, req.Identifier)
+	if err != nil && err.Error() != util.ErrUserNotFound {
+		return err
+	}
+
+	destination := util.NormalizeIdentifier(req.Identifier)
+	if user != nil {
+		destination = contactDestination(user, req.Channel)
+	}
+	err = s.acquireVerificationCooldown(ctx, req.Channel, destination)
+	if err != nil {
+		return err
+	}
+
+	if user == nil || isContactVerified(user, req.Channel) {
+		return nil
+	}
+
+	err = s.sendVerificationCode(ctx, user, req.Channel)
+	if err != nil {
+		log.Error(err)
+		return errors.New(util.ErrInternalServerError)
+	}
+	return nil
+}
+
+func (s *userService) VerifyContact(ctx context.Context, req model.VerifyContactRequest) error {
+	user, err := s.GetUserByIdentifier(ctx, req.Identifier)
+	if err != nil {
+		if err.Error() == util.ErrUserNotFound {
+			return errors.New(util.ErrInvalidVerificationCode)
+		}
+		return err
+	}
+
+	cfg := s.cfg.VerificationConfig
+	attempts, err := s.redisRepo.GetVerificationAttempts(ctx, req.Channel, user.ID)
+	if err != nil {
+		log.Error(err)
+		return errors.New(util.ErrInternalServerError)
+	}
+	if attempts >= int64(cfg.MaxAttempts) {
+		return errors.New(util.ErrInvalidVerificationCode)
+	}
+
+	code, err := s.redisRepo.GetVerificationCode(ctx, req.Channel, user.ID)
+	if err != nil {
+		return errors.New(util.ErrInvalidVerificationCode)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(code.CodeHash), []byte(util.HashToken(req.Code))) != 1 {
+		attempts, err = s.redisRepo.IncrVerificationAttempt(ctx, req.Channel, user.ID, cfg.CodeDuration*time.Second)
+		if err != nil {
+			log.Error(err)
+		} else if attempts >= int64(cfg.MaxAttempts) {
+			err = s.redisRepo.DeleteVerificationCode(ctx, req.Channel, user.ID)
+			if err != nil {
+				log.Error(err)
+			}
+		}
+		return errors.New(util.ErrInvalidVerificationCode)
+	}
+
+	switch req.Channel {
+	case util.ChannelEmail:
+		user.UserDetail.EmailVerified = true
+	case util.ChannelPhone:
+		user.UserDetail.PhoneVerified = true
+	}
+	user.UpdatedAt = util.TimeNow()
+	err = s.repo.Update(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	err = s.redisRepo.DeleteVerificationCode(ctx, req.Channel, user.ID)
+	if err != nil {
+		log.Error(err)
+	}
+	err = s.redisRepo.ResetVerificationAttempt(ctx, req.Channel, user.ID)
+	if err != nil {
+		log.Error(err)
+	}
+	return nil
+}
+
+func (s *userService) sendVerificationCode(ctx context.Context, user *model.User, channel string) error {
+	code, err := util.GenerateNumericCode(6)
+	if err != nil {
+		return err
+	}
+
+	err = s.redisRepo.StoreVerificationCode(ctx, model.VerificationCode{
+		UserID:    user.ID,
+		Channel:   channel,
+		CodeHash:  util.HashToken(code),
+		ExpiredAt: util.TimeNow().Add(s.cfg.VerificationConfig.CodeDuration * time.Second),
+	})
+	if err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("Your simcomm verification code is %v", code)
+	return s.notifier.Send(ctx, channel, contactDestination(user, channel), message)
+}
+
+// acquireVerificationCooldown rejects sending another code to the destination
+// before the resend cooldown is over
+func (s *userService) acquireVerificationCooldown(ctx context.Context, channel string, destination string) error {
+	cooldown := s.cfg.VerificationConfig.ResendCooldown * time.Second
+	if cooldown <= 0 {
+		return nil
+	}
+
+	acquired, err := s.redisRepo.AcquireVerificationCooldown(ctx, channel, destination, cooldown)
+	if err != nil {
+		log.Error(err)
+		return errors.New(util.ErrInternalServerError)
+	}
+	if !acquired {
+		return errors.New(util.ErrVerificationCooldown)
+	}
+	return nil
+}
+
+func contactDestination(user *model.User, channel string) string {
+	if channel == util.ChannelPhone {
+		return user.Phone
+	}
+	return user.Email
+}
+
+func isContactVerified(user *model.User, channel string) bool {
+	if channel == util.ChannelPhone {
+		return user.UserDetail.PhoneVerified
+	}
+	return user.UserDetail.EmailVerified
+}
+
+// ForgotPassword sends a single-use reset token, it does not report whether
+// the user exists so it cannot be used to enumerate users
+func (s *userService) ForgotPassword(ctx context.Context, req model.ForgotPasswordRequest) error {
+	user, err := s.GetUserByIdentifier(ctx, req.Identifier)
+	if err != nil {
+		if err.Error() == util.ErrUserNotFound {
+			return nil
+		}
+		return err
+	}
+
+	token, err := util.GenerateRandomString(32)
+	if err != nil {
+		log.Error(err)
+		return errors.New(util.ErrInternalServerError)
+	}
+
+	duration := s.cfg.VerificationConfig.ResetTokenDuration * time.Second
+	err = s.redisRepo.StorePasswordResetToken(ctx, util.HashToken(token), user.ID, duration)
+	if err != nil {
+		log.Error(err)
+		return errors.New(util.ErrInternalServerError)
+	}
+
+	channel, destination := util.ChannelEmail, user.Email
+	if req.Identifier == user.Phone {
+		channel, destination = util.ChannelPhone, user.Phone
+	}
+	message := fmt.Sprintf("Your simcomm password reset token is %v", token)
+	err = s.notifier.Send(ctx, channel, destination, message)
+	if err != nil {
+		log.Error(err)
+		return errors.New(util.ErrInternalServerError)
+	}
+	return nil
+}
+
+func (s *userService) ResetPassword(ctx context.Context, req model.ResetPasswordRequest) error {
+	userID, err := s.redisRepo.ConsumePasswordResetToken(ctx, util.HashToken(req.Token))
+	if err != nil {
+		return errors.New(util.ErrInvalidResetToken)
+	}
+
+	user, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		log.Error(err)
+		return errors.New(util.ErrInvalidResetToken)
+	}
+
+	return s.updatePassword(ctx, user, req.NewPassword)
+}
+
+func (s *userService) ChangePassword(ctx context.Context, userID int, req model.ChangePasswordRequest) error {
+	user, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		log.Error(err)
+		return errors.New(util.ErrUserNotFound)
+	}
+
+	err = util.ValidatePassword(req.OldPassword, user.Passsword)
+	if err != nil {
+		return errors.New(util.ErrInvalidPassword)
+	}
+
+	return s.updatePassword(ctx, user, req.NewPassword)
+}
+
+// updatePassword stores the new password hash and revokes every session of the user
+func (s *userService) updatePassword(ctx context.Context, user *model.User, password string) error {
+	hashedPassword, err := util.HashPassword(password)
+	if err != nil {
+		log.Error(err)
+		return errors.New(util.ErrInternalServerError)
+	}
+
+	user.Passsword = hashedPassword
+	user.UpdatedAt = util.TimeNow()
+	err = s.repo.Update(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	err = s.redisRepo.DeleteUserSessions(ctx, user.ID)
+	if err != nil {
+		log.Error(err)
+		return errors.New(util.ErrInternalServerError)
+	}
+	return nil
+}
+
+// UpdateProfile updates the user own profile, a changed email or phone has to be verified again
+func (s *userService) UpdateProfile(ctx context.Context, userID int, req model.UpdateProfileRequest) (*model.User, error) {
+	user, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		log.Error(err)
+		return nil, errors.New(util.ErrUserNotFound)
+	}
+
+	var channels []string
+	if req.Name != nil {
+		user.Name = *req.Name
+	}
+	if req.Email != nil && *req.Email != user.Email {
+		user.Email = *req.Email
+		user.UserDetail.EmailVerified = false
+		channels = append(channels, util.ChannelEmail)
+	}
+	if req.Phone != nil && *req.Phone != user.Phone {
+		user.Phone = *req.Phone
+		user.UserDetail.PhoneVerified = false
+		channels = append(channels, util.ChannelPhone)
+	}
+	user.UpdatedAt = util.TimeNow()
+
+	err = s.repo.Update(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, channel := range channels {
+		if err := s.sendVerificationCode(ctx, user, channel); err != nil {
+			log.Error(err)
+		}
+	}
+	return user, nil
+}
+
+func (s *userService) GetAddresses(ctx context.Context, userID int) ([]model.UserAddress, error) {
+	user, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		log.Error(err)
+		return nil, errors.New(util.ErrUserNotFound)
+	}
+
+	if user.UserDetail.Addresses == nil {
+		return []model.UserAddress{}, nil
+	}
+	return user.UserDetail.Addresses, nil
+}
+
+// AddAddress adds an address to the address book, the first address is always the default
+func (s *userService) AddAddress(ctx context.Context, userID int, req model.AddressRequest) (*model.UserAddress, error) {
+	user, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		log.Error(err)
+		return nil, errors.New(util.ErrUserNotFound)
+	}
+
+	address := req.ToUserAddress()
+	for _, existing := range user.UserDetail.Addresses {
+		if existing.ID > address.ID {
+			address.ID = existing.ID
+		}
+	}
+	address.ID++
+	if len(user.UserDetail.Addresses) == 0 {
+		address.IsDefault = true
+	}
+	user.UserDetail.Addresses = append(user.UserDetail.Addresses, address)
+
+	if err := s.saveAddresses(ctx, user, address); err != nil {
+		return nil, err
+	}
+	return &address, nil
+}
+
+func (s *userService) UpdateAddress(ctx context.Context, userID int, addressID int, req model.AddressRequest) (*model.UserAddress, error) {
+	user, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		log.Error(err)
+		return nil, errors.New(util.ErrUserNotFound)
+	}
+
+	for i, existing := range user.UserDetail.Addresses {
+		if existing.ID != addressID {
+			continue
+		}
+
+		address := req.ToUserAddress()
+		address.ID = addressID
+		// the default address can only be moved by marking another address as default
+		address.IsDefault = address.IsDefault || existing.IsDefault
+		user.UserDetail.Addresses[i] = address
+
+		if err := s.saveAddresses(ctx, user, address); err != nil {
+			return nil, err
+		}
+		return &address, nil
+	}
+
+	return nil, errors.New(util.ErrAddressNotFound)
+}
+
+func (s *userService) DeleteAddress(ctx context.Context, userID int, addressID int) error {
+	user, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		log.Error(err)
+		return errors.New(util.ErrUserNotFound)
+	}
+
+	addresses := make([]model.UserAddress, 0, len(user.UserDetail.Addresses))
+	var deleted *model.UserAddress
+	for i, existing := range user.UserDetail.Addresses {
+		if existing.ID == addressID {
+			deleted = &user.UserDetail.Addresses[i]
+			continue
+		}
+		addresses = append(addresses, existing)
+	}
+	if deleted == nil {
+		return errors.New(util.ErrAddressNotFound)
+	}
+
+	if deleted.IsDefault && len(addresses) > 0 {
+		addresses[0].IsDefault = true
+	}
+	user.UserDetail.Addresses = addresses
+	user.UpdatedAt = util.TimeNow()
+
+	return s.repo.Update(ctx, user)
+}
+
+// saveAddresses persists the address book, keeping a single default address
+func (s *userService) saveAddresses(ctx context.Context, user *model.User, changed model.UserAddress) error {
+	if changed.IsDefault {
+		for i := range user.UserDetail.Addresses {
+			user.UserDetail.Addresses[i].IsDefault = user.UserDetail.Addresses[i].ID == changed.ID
+		}
+	}
+	user.UpdatedAt = util.TimeNow()
+
+	return s.repo.Update(ctx, user)
+}
+
+// OIDCLogin starts an authorization code flow with PKCE against the identity provider
+func (s *userService) OIDCLogin(ctx context.Context, provider string) (model.OIDCLoginData, error) {
+	loginData := model.OIDCLoginData{}
+	oidcProvider, ok := s.oidcProviders[provider]
+	if !ok {
+		return loginData, errors.New(util.ErrUnknownOIDCProvider)
+	}
+
+	state, err := util.GenerateRandomString(16)
+	if err != nil {
+		log.Error(err)
+		return loginData, errors.New(util.ErrInternalServerError)
+	}
+	nonce, err := util.GenerateRandomString(16)
+	if err != nil {
+		log.Error(err)
+		return loginData, errors.New(util.ErrInternalServerError)
+	}
+	codeVerifier, codeChallenge, err := util.GeneratePKCE()
+	if err != nil {
+		log.Error(err)
+		return loginData, errors.New(util.ErrInternalServerError)
+	}
+
+	oidcState := model.OIDCState{
+		Provider:     provider,
+		CodeVerifier: codeVerifier,
+		Nonce:        nonce,
+	}
+	err = s.redisRepo.StoreOIDCState(ctx, state, oidcState, s.cfg.OIDCConfig.StateDuration*time.Second)
+	if err != nil {
+		log.Error(err)
+		return loginData, errors.New(util.ErrInternalServerError)
+	}
+
+	loginData.AuthorizationURL, err = oidcProvider.AuthorizationURL(ctx, state, nonce, codeChallenge)
+	if err != nil {
+		log.Error(err)
+		return loginData, errors.New(util.ErrInternalServerError)
+	}
+	return loginData, nil
+}
+
+// OIDCCallback completes the identity provider login, the external subject is
+// linked to an existing user with the same verified email or to a new customer
+func (s *userService) OIDCCallback(ctx context.Context, provider string, code string, state string) (model.LoginData, error) {
+	loginData := model.LoginData{}
+	oidcProvider, ok := s.oidcProviders[provider]
+	if !ok {
+		return loginData, errors.New(util.ErrUnknownOIDCProvider)
+	}
+
+	oidcState, err := s.redisRepo.ConsumeOIDCState(ctx, state)
+	if err != nil || oidcState.Provider != provider {
+		return loginData, errors.New(util.ErrInvalidOIDCLogin)
+	}
+
+	oidcIdentity, err := oidcProvider.Exchange(ctx, code, oidcState.CodeVerifier, oidcState.Nonce)
+	if err != nil {
+		log.Error(err)
+		return loginData, errors.New(util.ErrInvalidOIDCLogin)
+	}
+
+	user, err := s.getOrCreateOIDCUser(ctx, oidcIdentity)
+	if err != nil {
+		return loginData, err
+	}
+
+	err = s.checkAccountStatus(ctx, user)
+	if err != nil {
+		return loginData, err
+	}
+
+	if !user.UserDetail.EmailVerified && !user.UserDetail.PhoneVerified {
+		return loginData, errors.New(util.ErrContactNotVerified)
+	}
+
+	return s.completeLogin(ctx, user, "identifier:"+user.Email, "")
+}
+
+func (s *userService) getOrCreateOIDCUser(ctx context.Context, oidcIdentity *model.OIDCIdentity) (*model.User, error) {
+	identity := model.Identity{
+		Provider: oidcIdentity.Provider,
+		Subject:  oidcIdentity.Subject,
+	}
+
+	user, err := s.repo.GetByIdentity(ctx, identity)
+	if err == nil {
+		return user, nil
+	}
+	if err.Error() != util.ErrUserNotFound {
+		return nil, err
+	}
+
+	email, emailErr := util.NormalizeEmail(oidcIdentity.Email)
+	if emailErr != nil {
+		return nil, errors.New(util.ErrInvalidOIDCLogin)
+	}
+
+	// only a verified email can be trusted to link an existing account
+	if oidcIdentity.EmailVerified {
+		user, err = s.repo.GetByIdentifier(ctx, email)
+		if err == nil {
+			user.UserDetail.Identities = append(user.UserDetail.Identities, identity)
+			user.UserDetail.EmailVerified = true
+			user.UpdatedAt = util.TimeNow()
+			if err := s.repo.Update(ctx, user); err != nil {
+				return nil, err
+			}
+			return user, nil
+		}
+		if err.Error() != util.ErrUserNotFound {
+			return nil, err
+		}
+	}
+
+	// the user signs in through the identity provider, so the password is never used
+	password, err := util.GenerateRandomString(32)
+	if err != nil {
+		log.Error(err)
+		return nil, errors.New(util.ErrInternalServerError)
+	}
+	hashedPassword, err := util.HashPassword(password)
+	if err != nil {
+		log.Error(err)
+		return nil, errors.New(util.ErrInternalServerError)
+	}
+
+	name := oidcIdentity.Name
+	if name == "" {
+		name = email
+	}
+
+	timeNow := util.TimeNow()
+	user = &model.User{
+		Name:      name,
+		Email:     email,
+		Passsword: hashedPassword,
+		UserDetail: model.UserDetail{
+			Roles:         []string{util.RoleCustomer},
+			EmailVerified: oidcIdentity.EmailVerified,
+			Identities:    []model.Identity{identity},
+		},
+		CreatedAt: timeNow,
+		UpdatedAt: timeNow,
+	}
+	err = s.repo.Create(ctx, user)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	return user, nil
+}
+
+// LoginTwoFactor completes a login challenge with a TOTP code or a recovery code
+func (s *userService) LoginTwoFactor(ctx context.Context, req model.TwoFactorLoginRequest) (model.LoginData, error) {
+	loginData := model.LoginData{}
+	ipKey := "ip:" + req.ClientIP
+	challenge, err := s.redisRepo.GetLoginChallenge(ctx, util.HashToken(req.ChallengeToken))
+	if err != nil {
+		return loginData, errors.New(util.ErrInvalidLoginChallenge)
+	}
+
+	err = s.checkLoginBackoff(ctx, challenge.IdentifierKey, ipKey)
+	if err != nil {
+		return loginData, err
+	}
+
+	user, err := s.repo.Get(ctx, challenge.UserID)
+	if err != nil {
+		log.Error(err)
+		return loginData, errors.New(util.ErrInvalidLoginChallenge)
+	}
+
+	err = s.checkAccountStatus(ctx, user)
+	if err != nil {
+		return loginData, err
+	}
+
+	valid, err := s.verifySecondFactor(ctx, user, req.Code, true)
+	if err != nil {
+		return loginData, err
+	}
+	if !valid {
+		locked := s.registerFailedLogin(ctx, user, challenge.IdentifierKey, ipKey)
+		attempts, err := s.redisRepo.IncrLoginChallengeAttempt(ctx, challenge.TokenHash, challenge.ExpiredAt.Sub(util.TimeNow()))
+		if err != nil {
+			log.Error(err)
+		}
+		if locked || err != nil || attempts >= int64(s.cfg.TwoFactorConfig.MaxAttempts) {
+			if err := s.redisRepo.DeleteLoginChallenge(ctx, challenge.TokenHash); err != nil {
+				log.Error(err)
+			}
+		}
+		if locked {
+			return loginData, errors.New(util.ErrAccountLocked)
+		}
+		return loginData, errors.New(util.ErrInvalidTwoFactorCode)
+	}
+
+	err = s.redisRepo.DeleteLoginChallenge(ctx, challenge.TokenHash)
+	if err != nil {
+		log.Error(err)
+	}
+	s.resetLoginAttempts(ctx, challenge.IdentifierKey, ipKey)
+
+	familyID, err := util.GenerateRandomString(16)
+	if err != nil {
+		log.Error(err)
+		return loginData, errors.New(util.ErrInternalServerError)
+	}
+
+	return s.issueTokens(ctx, user, familyID)
+}
+
+// EnrollTOTP generates a pending TOTP secret, it is only enabled once confirmed with ConfirmTOTP
+func (s *userService) EnrollTOTP(ctx context.Context, userID int) (model.TOTPEnrolmentData, error) {
+	enrolment := model.TOTPEnrolmentData{}
+	user, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		log.Error(err)
+		return enrolment, errors.New(util.ErrUserNotFound)
+	}
+
+	if user.UserDetail.TwoFactor.Enabled {
+		return enrolment, errors.New(util.ErrTwoFactorAlreadyEnabled)
+	}
+
+	secret, err := util.GenerateTOTPSecret()
+	if err != nil {
+		log.Error(err)
+		return enrolment, errors.New(util.ErrInternalServerError)
+	}
+
+	user.UserDetail.TwoFactor.PendingSecret = secret
+	user.UpdatedAt = util.TimeNow()
+	err = s.repo.Update(ctx, user)
+	if err != nil {
+		return enrolment, err
+	}
+
+	account := user.Email
+	if account == "" {
+		account = user.Phone
+	}
+
+	enrolment.Secret = secret
+	enrolment.URI = util.TOTPURI(s.cfg.TwoFactorConfig.Issuer, account, secret)
+	return enrolment, nil
+}
+
+// ConfirmTOTP enables two-factor authentication and returns the recovery codes, they are only shown once
+func (s *userService) ConfirmTOTP(ctx context.Context, userID int, req model.TOTPCodeRequest) (model.RecoveryCodesData, error) {
+	recoveryCodes := model.RecoveryCodesData{}
+	user, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		log.Error(err)
+		return recoveryCodes, errors.New(util.ErrUserNotFound)
+	}
+
+	twoFactor := user.UserDetail.TwoFactor
+	if twoFactor.Enabled {
+		return recoveryCodes, errors.New(util.ErrTwoFactorAlreadyEnabled)
+	}
+	if twoFactor.PendingSecret == "" {
+		return recoveryCodes, errors.New(util.ErrTwoFactorNotEnabled)
+	}
+
+	step, ok := util.ValidateTOTP(twoFactor.PendingSecret, req.Code, util.TimeNow())
+	if !ok {
+		return recoveryCodes, errors.New(util.ErrInvalidTwoFactorCode)
+	}
+
+	user.UserDetail.TwoFactor = model.TwoFactor{
+		Enabled:      true,
+		Secret:       twoFactor.PendingSecret,
+		LastUsedStep: step,
+	}
+	return s.saveRecoveryCodes(ctx, user)
+}
+
+// DisableTOTP turns off two-factor authentication, it requires both the password and a second factor
+func (s *userService) DisableTOTP(ctx context.Context, userID int, req model.DisableTOTPRequest) error {
+	user, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		log.Error(err)
+		return errors.New(util.ErrUserNotFound)
+	}
+
+	if !user.UserDetail.TwoFactor.Enabled {
+		return errors.New(util.ErrTwoFactorNotEnabled)
+	}
+
+	err = util.ValidatePassword(req.Password, user.Passsword)
+	if err != nil {
+		return errors.New(util.ErrInvalidPassword)
+	}
+
+	valid, err := s.verifySecondFactor(ctx, user, req.Code, true)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return errors.New(util.ErrInvalidTwoFactorCode)
+	}
+
+	user.UserDetail.TwoFactor = model.TwoFactor{}
+	user.UpdatedAt = util.TimeNow()
+	return s.repo.Update(ctx, user)
+}
+
+// RegenerateRecoveryCodes replaces every recovery code, it requires a TOTP code
+func (s *userService) RegenerateRecoveryCodes(ctx context.Context, userID int, req model.TOTPCodeRequest) (model.RecoveryCodesData, error) {
+	recoveryCodes := model.RecoveryCodesData{}
+	user, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		log.Error(err)
+		return recoveryCodes, errors.New(util.ErrUserNotFound)
+	}
+
+	if !user.UserDetail.TwoFactor.Enabled {
+		return recoveryCodes, errors.New(util.ErrTwoFactorNotEnabled)
+	}
+
+	valid, err := s.verifySecondFactor(ctx, user, req.Code, false)
+	if err != nil {
+		return recoveryCodes, err
+	}
+	if !valid {
+		return recoveryCodes, errors.New(util.ErrInvalidTwoFactorCode)
+	}
+
+	return s.saveRecoveryCodes(ctx, user)
+}
+
+// verifySecondFactor checks a TOTP code, or a recovery code when allowed, and
+// stores what was used so the same TOTP step or recovery code is not accepted twice
+func (s *userService) verifySecondFactor(ctx context.Context, user *model.User, code string, allowRecoveryCode bool) (bool, error) {
+	twoFactor := &user.UserDetail.TwoFactor
+	step, ok := util.ValidateTOTP(twoFactor.Secret, code, util.TimeNow())
+	if ok {
+		if step <= twoFactor.LastUsedStep {
+			return false, nil
+		}
+		twoFactor.LastUsedStep = step
+	} else {
+		if !allowRecoveryCode {
+			return false, nil
+		}
+
+		codeHash := util.HashToken(normalizeRecoveryCode(code))
+		index := -1
+		for i, recoveryCodeHash := range twoFactor.RecoveryCodeHashes {
+			if subtle.ConstantTimeCompare([]byte(recoveryCodeHash), []byte(codeHash)) == 1 {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return false, nil
+		}
+		twoFactor.RecoveryCodeHashes = append(twoFactor.RecoveryCodeHashes[:index], twoFactor.RecoveryCodeHashes[index+1:]...)
+	}
+
+	user.UpdatedAt = util.TimeNow()
+	err := s.repo.Update(ctx, user)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// saveRecoveryCodes generates new recovery codes, only their hashes are stored
+func (s *userService) saveRecoveryCodes(ctx context.Context, user *model.User) (model.RecoveryCodesData, error) {
+	recoveryCodes := model.RecoveryCodesData{}
+	codeHashes := make([]string, 0, s.cfg.TwoFactorConfig.RecoveryCodeCount)
+	for i := 0; i < s.cfg.TwoFactorConfig.RecoveryCodeCount; i++ {
+		code, err := util.GenerateRandomString(5)
+		if err != nil {
+			log.Error(err)
+			return recoveryCodes, errors.New(util.ErrInternalServerError)
+		}
+		recoveryCodes.RecoveryCodes = append(recoveryCodes.RecoveryCodes, code[:5]+"-"+code[5:])
+		codeHashes = append(codeHashes, util.HashToken(code))
+	}
+
+	user.UserDetail.TwoFactor.RecoveryCodeHashes = codeHashes
+	user.UpdatedAt = util.TimeNow()
+	err := s.repo.Update(ctx, user)
+	if err != nil {
+		return model.RecoveryCodesData{}, err
+	}
+	return recoveryCodes, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.ReplaceAll(code, "-", ""))
+}
+
+// Deactivate blocks the user from logging in and revokes its sessions, the data is kept
+func (s *userService) Deactivate(ctx context.Context, userID int) error {
+	user, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		log.Error(err)
+		return errors.New(util.ErrUserNotFound)
+	}
+
+	if user.UserDetail.DeactivatedAt != nil {
+		return nil
+	}
+
+	timeNow := util.TimeNow()
+	user.UserDetail.DeactivatedAt = &timeNow
+	user.UpdatedAt = timeNow
+	err = s.repo.Update(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	err = s.redisRepo.DeleteUserSessions(ctx, user.ID)
+	if err != nil {
+		log.Error(err)
+		return errors.New(util.ErrInternalServerError)
+	}
+	return nil
+}
+
+func (s *userService) Reactivate(ctx context.Context, userID int) error {
+	user, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		log.Error(err)
+		return errors.New(util.ErrUserNotFound)
+	}
+
+	if user.UserDetail.DeactivatedAt == nil {
+		return nil
+	}
+
+	user.UserDetail.DeactivatedAt = nil
+	user.UpdatedAt = util.TimeNow()
+	return s.repo.Update(ctx, user)
+}
+
+func (s *userService) DeactivateSelf(ctx context.Context, userID int, req model.PasswordConfirmationRequest) error {
+	user, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		log.Error(err)
+		return errors.New(util.ErrUserNotFound)
+	}
+
+	err = util.ValidatePassword(req.Password, user.Passsword)
+	if err != nil {
+		return errors.New(util.ErrInvalidPassword)
+	}
+
+	return s.Deactivate(ctx, userID)
+}
+
+// Erase handles an erasure request, the personal data of the user and its
+// orders is anonymized and the user is soft deleted
+func (s *userService) Erase(ctx context.Context, userID int, req model.PasswordConfirmationRequest) error {
+	user, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		log.Error(err)
+		return errors.New(util.ErrUserNotFound)
+	}
+
+	err = util.ValidatePassword(req.Password, user.Passsword)
+	if err != nil {
+		return errors.New(util.ErrInvalidPassword)
+	}
+
+	// nobody knows this password, so the anonymized user can never log in
+	password, err := util.GenerateRandomString(32)
+	if err != nil {
+		log.Error(err)
+		return errors.New(util.ErrInternalServerError)
+	}
+	hashedPassword, err := util.HashPassword(password)
+	if err != nil {
+		log.Error(err)
+		return errors.New(util.ErrInternalServerError)
+	}
+
+	user.Anonymize(hashedPassword)
+	user.UpdatedAt = util.TimeNow()
+	err = s.repo.Anonymize(ctx, user)
+	if err != nil {
+		return errors.New(util.ErrInternalServerError)
+	}
+
+	err = s.redisRepo.DeleteUserSessions(ctx, user.ID)
+	if err != nil {
+		log.Error(err)
+		return errors.New(util.ErrInternalServerError)
+	}
+	return nil
+}
+
+// Export bundles the profile, addresses, shops and orders of the user
+func (s *userService) Export(ctx context.Context, userID int) (model.UserExport, error) {
+	export := model.UserExport{}
+	user, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		log.Error(err)
+		return export, errors.New(util.ErrUserNotFound)
+	}
+
+	shops, err := s.shopRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		log.Error(err)
+		return export, errors.New(util.ErrInternalServerError)
+	}
+
+	orders, err := s.orderRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		log.Error(err)
+		return export, errors.New(util.ErrInternalServerError)
+	}
+
+	export.ExportedAt = util.TimeNow()
+	export.Profile = user.ToResponse()
+	export.Addresses = user.UserDetail.Addresses
+	export.Shops = shops
+	export.Orders = orders
+	return export, nil
+}
@@ -0,0 +1,816 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"simcomm-monolith/config"
+	"simcomm-monolith/internal/model"
+	"simcomm-monolith/internal/repository"
+	"simcomm-monolith/internal/repository/mocks"
+	"simcomm-monolith/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testPassword = "Secr3t-Passw0rd"
+
+type userServiceMocks struct {
+	repo      *mocks.UserRepository
+	shopRepo  *mocks.ShopRepository
+	orderRepo *mocks.OrderRepository
+	redisRepo *mocks.RedisRepository
+	notifier  *mocks.Notifier
+}
+
+func testConfig() *config.Config {
+	return &config.Config{
+		AuthTokenConfig: config.AuthTokenConfig{
+			Duration:  900,
+			SecretKey: "test-secret",
+		},
+		LoginProtectionConfig: config.LoginProtectionConfig{
+			MaxAttempts:   5,
+			AttemptWindow: 900,
+			LockDuration:  900,
+			BackoffBase:   1,
+			BackoffMax:    60,
+		},
+		VerificationConfig: config.VerificationConfig{
+			CodeDuration:       600,
+			MaxAttempts:        5,
+			ResendCooldown:     60,
+			ResetTokenDuration: 600,
+		},
+		TwoFactorConfig: config.TwoFactorConfig{
+			Issuer:            "simcomm",
+			ChallengeDuration: 300,
+			MaxAttempts:       5,
+			RecoveryCodeCount: 10,
+		},
+	}
+}
+
+func newTestUserService(t *testing.T) (*userService, userServiceMocks) {
+	t.Helper()
+	m := userServiceMocks{
+		repo:      &mocks.UserRepository{},
+		shopRepo:  &mocks.ShopRepository{},
+		orderRepo: &mocks.OrderRepository{},
+		redisRepo: &mocks.RedisRepository{},
+		notifier:  &mocks.Notifier{},
+	}
+	t.Cleanup(func() {
+		m.repo.AssertExpectations(t)
+		m.redisRepo.AssertExpectations(t)
+	})
+	cfg := testConfig()
+	keySet, err := util.NewKeySet(cfg.AuthTokenConfig)
+	require.NoError(t, err)
+	svc := NewUserService(m.repo, m.shopRepo, m.orderRepo, m.redisRepo, m.notifier, nil, keySet, cfg)
+	return svc, m
+}
+
+func testUser(t *testing.T) *model.User {
+	t.Helper()
+	hashedPassword, err := util.HashPassword(testPassword)
+	require.NoError(t, err)
+	return &model.User{
+		ID:        1,
+		Name:      "Jane Doe",
+		Email:     "jane@example.com",
+		Phone:     "+6281234567890",
+		Passsword: hashedPassword,
+		UserDetail: model.UserDetail{
+			Roles:         []string{util.RoleCustomer},
+			EmailVerified: true,
+		},
+	}
+}
+
+func TestUserServiceLogin(t *testing.T) {
+	const clientIP = "203.0.113.7"
+	identifierKey := "identifier:jane@example.com"
+	ipKey := "ip:" + clientIP
+
+	tests := []struct {
+		name     string
+		password string
+		setup    func(m userServiceMocks, user *model.User)
+		wantErr  string
+	}{
+		{
+			name:     "valid password issues a tracked session",
+			password: testPassword,
+			setup: func(m userServiceMocks, user *model.User) {
+				m.repo.On("GetByIdentifier", mock.Anything, "jane@example.com").Return(user, nil)
+				m.redisRepo.On("IsAccountLocked", mock.Anything, user.ID).Return(false, nil)
+				m.redisRepo.On("ResetLoginAttempt", mock.Anything, identifierKey).Return(nil)
+				m.redisRepo.On("ResetLoginAttempt", mock.Anything, ipKey).Return(nil)
+				m.redisRepo.On("StoreSession", mock.Anything, mock.MatchedBy(func(session model.Session) bool {
+					return session.UserID == user.ID && session.TokenID != "" && session.FamilyID != ""
+				})).Return(nil)
+				m.redisRepo.On("StoreTokenFamily", mock.Anything, mock.Anything).Return(nil)
+				m.redisRepo.On("StoreRefreshToken", mock.Anything, mock.Anything).Return(nil)
+			},
+		},
+		{
+			name:     "wrong password is rejected",
+			password: "wrong-password",
+			setup: func(m userServiceMocks, user *model.User) {
+				m.repo.On("GetByIdentifier", mock.Anything, "jane@example.com").Return(user, nil)
+				m.redisRepo.On("IsAccountLocked", mock.Anything, user.ID).Return(false, nil)
+				m.redisRepo.On("IncrLoginAttempt", mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil)
+				m.redisRepo.On("SetLoginBackoff", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			},
+			wantErr: util.ErrInvalidCredential,
+		},
+		{
+			name:     "failure reaching the threshold locks the account",
+			password: "wrong-password",
+			setup: func(m userServiceMocks, user *model.User) {
+				m.repo.On("GetByIdentifier", mock.Anything, "jane@example.com").Return(user, nil)
+				m.redisRepo.On("IsAccountLocked", mock.Anything, user.ID).Return(false, nil)
+				m.redisRepo.On("IncrLoginAttempt", mock.Anything, identifierKey, mock.Anything).Return(int64(5), nil)
+				m.redisRepo.On("IncrLoginAttempt", mock.Anything, ipKey, mock.Anything).Return(int64(5), nil)
+				m.redisRepo.On("SetLoginBackoff", mock.Anything, mock.Anything, 16*time.Second).Return(nil).Twice()
+				m.redisRepo.On("LockAccount", mock.Anything, user.ID, 900*time.Second).Return(nil)
+			},
+			wantErr: util.ErrAccountLocked,
+		},
+		{
+			name:     "locked account is rejected before the password check",
+			password: testPassword,
+			setup: func(m userServiceMocks, user *model.User) {
+				m.repo.On("GetByIdentifier", mock.Anything, "jane@example.com").Return(user, nil)
+				m.redisRepo.On("IsAccountLocked", mock.Anything, user.ID).Return(true, nil)
+			},
+			wantErr: util.ErrAccountLocked,
+		},
+		{
+			name:     "unverified account with a wrong password counts as a failed login",
+			password: "wrong-password",
+			setup: func(m userServiceMocks, user *model.User) {
+				user.UserDetail.EmailVerified = false
+				m.repo.On("GetByIdentifier", mock.Anything, "jane@example.com").Return(user, nil)
+				m.redisRepo.On("IsAccountLocked", mock.Anything, user.ID).Return(false, nil)
+				m.redisRepo.On("IncrLoginAttempt", mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil).Twice()
+				m.redisRepo.On("SetLoginBackoff", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			},
+			wantErr: util.ErrInvalidCredential,
+		},
+		{
+			name:     "unverified account with the right password",
+			password: testPassword,
+			setup: func(m userServiceMocks, user *model.User) {
+				user.UserDetail.EmailVerified = false
+				m.repo.On("GetByIdentifier", mock.Anything, "jane@example.com").Return(user, nil)
+				m.redisRepo.On("IsAccountLocked", mock.Anything, user.ID).Return(false, nil)
+			},
+			wantErr: util.ErrContactNotVerified,
+		},
+		{
+			name:     "unknown identifier is rejected like a wrong password",
+			password: testPassword,
+			setup: func(m userServiceMocks, user *model.User) {
+				m.repo.On("GetByIdentifier", mock.Anything, "jane@example.com").Return(nil, errors.New(util.ErrUserNotFound))
+				m.redisRepo.On("IncrLoginAttempt", mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil)
+				m.redisRepo.On("SetLoginBackoff", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			},
+			wantErr: util.ErrInvalidCredential,
+		},
+		{
+			name:     "session storage failure fails the login",
+			password: testPassword,
+			setup: func(m userServiceMocks, user *model.User) {
+				m.repo.On("GetByIdentifier", mock.Anything, "jane@example.com").Return(user, nil)
+				m.redisRepo.On("IsAccountLocked", mock.Anything, user.ID).Return(false, nil)
+				m.redisRepo.On("ResetLoginAttempt", mock.Anything, identifierKey).Return(nil)
+				m.redisRepo.On("ResetLoginAttempt", mock.Anything, ipKey).Return(nil)
+				m.redisRepo.On("StoreSession", mock.Anything, mock.Anything).Return(errors.New("connection refused"))
+			},
+			wantErr: util.ErrInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newTestUserService(t)
+			user := testUser(t)
+			for _, key := range []string{identifierKey, ipKey} {
+				m.redisRepo.On("GetLoginBackoff", mock.Anything, key).Return(time.Duration(0), nil).Maybe()
+			}
+			tt.setup(m, user)
+
+			loginData, err := svc.Login(context.Background(), model.LoginRequest{
+				Identifier: "jane@example.com",
+				Password:   tt.password,
+				ClientIP:   clientIP,
+			})
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Equal(t, tt.wantErr, err.Error())
+				assert.Empty(t, loginData.Token)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotEmpty(t, loginData.Token)
+			assert.NotEmpty(t, loginData.RefreshToken)
+
+			claims, err := svc.keySet.VerifyToken(loginData.Token)
+			require.NoError(t, err)
+			assert.Equal(t, user.ID, claims.ID)
+		})
+	}
+}
+
+func TestUserServiceLogout(t *testing.T) {
+	svc, m := newTestUserService(t)
+	token, err := svc.keySet.GenerateToken(1, []string{util.RoleCustomer}, "token-1", 900)
+	require.NoError(t, err)
+
+	m.redisRepo.On("GetSession", mock.Anything, "token-1").Return(&model.Session{TokenID: "token-1", FamilyID: "family"}, nil)
+	m.redisRepo.On("DeleteSession", mock.Anything, "token-1").Return(nil)
+	m.redisRepo.On("DeleteTokenFamily", mock.Anything, "family").Return(nil)
+	assert.NoError(t, svc.Logout(context.Background(), token))
+
+	assert.Error(t, svc.Logout(context.Background(), "not-a-token"))
+}
+
+func TestUserServiceRefreshToken(t *testing.T) {
+	const refreshToken = "refresh-token"
+	tokenHash := util.HashToken(refreshToken)
+	stored := &model.RefreshToken{
+		TokenHash: tokenHash,
+		FamilyID:  "family",
+		UserID:    1,
+		ExpiredAt: util.TimeNow().Add(time.Hour),
+	}
+
+	tests := []struct {
+		name    string
+		setup   func(m userServiceMocks, user *model.User)
+		wantErr string
+	}{
+		{
+			name: "unknown refresh token",
+			setup: func(m userServiceMocks, user *model.User) {
+				m.redisRepo.On("GetRefreshToken", mock.Anything, tokenHash).Return(nil, errors.New("redis: nil"))
+			},
+			wantErr: util.ErrInvalidToken,
+		},
+		{
+			name: "reused refresh token revokes the family",
+			setup: func(m userServiceMocks, user *model.User) {
+				m.redisRepo.On("GetRefreshToken", mock.Anything, tokenHash).Return(stored, nil)
+				m.redisRepo.On("MarkRefreshTokenUsed", mock.Anything, *stored).Return(false, nil)
+				m.redisRepo.On("DeleteTokenFamily", mock.Anything, "family").Return(nil).Once()
+			},
+			wantErr: util.ErrInvalidToken,
+		},
+		{
+			name: "revoked family",
+			setup: func(m userServiceMocks, user *model.User) {
+				m.redisRepo.On("GetRefreshToken", mock.Anything, tokenHash).Return(stored, nil)
+				m.redisRepo.On("MarkRefreshTokenUsed", mock.Anything, *stored).Return(true, nil)
+				m.redisRepo.On("GetTokenFamily", mock.Anything, "family").Return(nil, errors.New("redis: nil"))
+			},
+			wantErr: util.ErrInvalidToken,
+		},
+		{
+			name: "locked account revokes the family",
+			setup: func(m userServiceMocks, user *model.User) {
+				m.redisRepo.On("GetRefreshToken", mock.Anything, tokenHash).Return(stored, nil)
+				m.redisRepo.On("MarkRefreshTokenUsed", mock.Anything, *stored).Return(true, nil)
+				m.redisRepo.On("GetTokenFamily", mock.Anything, "family").Return(&model.TokenFamily{ID: "family", UserID: 1, TokenID: "old-token"}, nil)
+				m.repo.On("Get", mock.Anything, 1).Return(user, nil)
+				m.redisRepo.On("IsAccountLocked", mock.Anything, 1).Return(true, nil)
+				m.redisRepo.On("DeleteTokenFamily", mock.Anything, "family").Return(nil).Once()
+			},
+			wantErr: util.ErrAccountLocked,
+		},
+		{
+			name: "deactivated account revokes the family",
+			setup: func(m userServiceMocks, user *model.User) {
+				deactivatedAt := util.TimeNow()
+				user.UserDetail.DeactivatedAt = &deactivatedAt
+				m.redisRepo.On("GetRefreshToken", mock.Anything, tokenHash).Return(stored, nil)
+				m.redisRepo.On("MarkRefreshTokenUsed", mock.Anything, *stored).Return(true, nil)
+				m.redisRepo.On("GetTokenFamily", mock.Anything, "family").Return(&model.TokenFamily{ID: "family", UserID: 1, TokenID: "old-token"}, nil)
+				m.repo.On("Get", mock.Anything, 1).Return(user, nil)
+				m.redisRepo.On("IsAccountLocked", mock.Anything, 1).Return(false, nil)
+				m.redisRepo.On("DeleteTokenFamily", mock.Anything, "family").Return(nil).Once()
+			},
+			wantErr: util.ErrAccountDeactivated,
+		},
+		{
+			name: "first use rotates within the family",
+			setup: func(m userServiceMocks, user *model.User) {
+				m.redisRepo.On("GetRefreshToken", mock.Anything, tokenHash).Return(stored, nil)
+				m.redisRepo.On("MarkRefreshTokenUsed", mock.Anything, *stored).Return(true, nil)
+				m.redisRepo.On("GetTokenFamily", mock.Anything, "family").Return(&model.TokenFamily{ID: "family", UserID: 1, TokenID: "old-token"}, nil)
+				m.repo.On("Get", mock.Anything, 1).Return(user, nil)
+				m.redisRepo.On("IsAccountLocked", mock.Anything, 1).Return(false, nil)
+				m.redisRepo.On("DeleteSession", mock.Anything, "old-token").Return(nil)
+				m.redisRepo.On("StoreSession", mock.Anything, mock.MatchedBy(func(session model.Session) bool {
+					return session.FamilyID == "family" && session.TokenID != "old-token"
+				})).Return(nil)
+				m.redisRepo.On("StoreTokenFamily", mock.Anything, mock.MatchedBy(func(family model.TokenFamily) bool {
+					return family.ID == "family"
+				})).Return(nil)
+				m.redisRepo.On("StoreRefreshToken", mock.Anything, mock.MatchedBy(func(token model.RefreshToken) bool {
+					return token.FamilyID == "family" && token.TokenHash != tokenHash
+				})).Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newTestUserService(t)
+			tt.setup(m, testUser(t))
+
+			loginData, err := svc.RefreshToken(context.Background(), model.RefreshTokenRequest{RefreshToken: refreshToken})
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Equal(t, tt.wantErr, err.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.NotEmpty(t, loginData.Token)
+			assert.NotEqual(t, refreshToken, loginData.RefreshToken)
+		})
+	}
+}
+
+func TestUserServiceSendVerification(t *testing.T) {
+	tests := []struct {
+		name       string
+		identifier string
+		channel    string
+		setup      func(m userServiceMocks, user *model.User)
+		wantErr    string
+	}{
+		{
+			name:       "sends a code to an unverified contact",
+			identifier: "jane@example.com",
+			channel:    util.ChannelEmail,
+			setup: func(m userServiceMocks, user *model.User) {
+				user.UserDetail.EmailVerified = false
+				m.repo.On("GetByIdentifier", mock.Anything, "jane@example.com").Return(user, nil)
+				m.redisRepo.On("AcquireVerificationCooldown", mock.Anything, util.ChannelEmail, "jane@example.com", 60*time.Second).Return(true, nil)
+				m.redisRepo.On("StoreVerificationCode", mock.Anything, mock.MatchedBy(func(code model.VerificationCode) bool {
+					return code.UserID == user.ID && code.Channel == util.ChannelEmail
+				})).Return(nil)
+				m.notifier.On("Send", mock.Anything, util.ChannelEmail, "jane@example.com", mock.Anything).Return(nil)
+			},
+		},
+		{
+			name:       "cooldown is per destination, not per identifier",
+			identifier: "+6281234567890",
+			channel:    util.ChannelEmail,
+			setup: func(m userServiceMocks, user *model.User) {
+				m.repo.On("GetByIdentifier", mock.Anything, "+6281234567890").Return(user, nil)
+				m.redisRepo.On("AcquireVerificationCooldown", mock.Anything, util.ChannelEmail, "jane@example.com", 60*time.Second).Return(false, nil)
+			},
+			wantErr: util.ErrVerificationCooldown,
+		},
+		{
+			name:       "unknown identifier is throttled the same way",
+			identifier: "nobody@example.com",
+			channel:    util.ChannelEmail,
+			setup: func(m userServiceMocks, user *model.User) {
+				m.repo.On("GetByIdentifier", mock.Anything, "nobody@example.com").Return(nil, errors.New(util.ErrUserNotFound))
+				m.redisRepo.On("AcquireVerificationCooldown", mock.Anything, util.ChannelEmail, "nobody@example.com", 60*time.Second).Return(false, nil)
+			},
+			wantErr: util.ErrVerificationCooldown,
+		},
+		{
+			name:       "verified contact gets no code",
+			identifier: "jane@example.com",
+			channel:    util.ChannelEmail,
+			setup: func(m userServiceMocks, user *model.User) {
+				m.repo.On("GetByIdentifier", mock.Anything, "jane@example.com").Return(user, nil)
+				m.redisRepo.On("AcquireVerificationCooldown", mock.Anything, util.ChannelEmail, "jane@example.com", 60*time.Second).Return(true, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newTestUserService(t)
+			tt.setup(m, testUser(t))
+
+			err := svc.SendVerification(context.Background(), model.SendVerificationRequest{
+				Identifier: tt.identifier,
+				Channel:    tt.channel,
+			})
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			m.notifier.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserServiceVerifyContact(t *testing.T) {
+	const code = "123456"
+	stored := &model.VerificationCode{UserID: 1, Channel: util.ChannelPhone, CodeHash: util.HashToken(code)}
+
+	tests := []struct {
+		name    string
+		code    string
+		setup   func(m userServiceMocks, user *model.User)
+		wantErr string
+	}{
+		{
+			name: "right code verifies the contact and resets the attempts",
+			code: code,
+			setup: func(m userServiceMocks, user *model.User) {
+				m.redisRepo.On("GetVerificationAttempts", mock.Anything, util.ChannelPhone, user.ID).Return(int64(2), nil)
+				m.redisRepo.On("GetVerificationCode", mock.Anything, util.ChannelPhone, user.ID).Return(stored, nil)
+				m.repo.On("Update", mock.Anything, mock.MatchedBy(func(user *model.User) bool {
+					return user.UserDetail.PhoneVerified
+				})).Return(nil)
+				m.redisRepo.On("DeleteVerificationCode", mock.Anything, util.ChannelPhone, user.ID).Return(nil)
+				m.redisRepo.On("ResetVerificationAttempt", mock.Anything, util.ChannelPhone, user.ID).Return(nil)
+			},
+		},
+		{
+			name: "wrong code counts an attempt",
+			code: "000000",
+			setup: func(m userServiceMocks, user *model.User) {
+				m.redisRepo.On("GetVerificationAttempts", mock.Anything, util.ChannelPhone, user.ID).Return(int64(0), nil)
+				m.redisRepo.On("GetVerificationCode", mock.Anything, util.ChannelPhone, user.ID).Return(stored, nil)
+				m.redisRepo.On("IncrVerificationAttempt", mock.Anything, util.ChannelPhone, user.ID, 600*time.Second).Return(int64(1), nil)
+			},
+			wantErr: util.ErrInvalidVerificationCode,
+		},
+		{
+			name: "last allowed wrong code discards the code",
+			code: "000000",
+			setup: func(m userServiceMocks, user *model.User) {
+				m.redisRepo.On("GetVerificationAttempts", mock.Anything, util.ChannelPhone, user.ID).Return(int64(4), nil)
+				m.redisRepo.On("GetVerificationCode", mock.Anything, util.ChannelPhone, user.ID).Return(stored, nil)
+				m.redisRepo.On("IncrVerificationAttempt", mock.Anything, util.ChannelPhone, user.ID, 600*time.Second).Return(int64(5), nil)
+				m.redisRepo.On("DeleteVerificationCode", mock.Anything, util.ChannelPhone, user.ID).Return(nil)
+			},
+			wantErr: util.ErrInvalidVerificationCode,
+		},
+		{
+			name: "attempts are kept across resends",
+			code: code,
+			setup: func(m userServiceMocks, user *model.User) {
+				m.redisRepo.On("GetVerificationAttempts", mock.Anything, util.ChannelPhone, user.ID).Return(int64(5), nil)
+			},
+			wantErr: util.ErrInvalidVerificationCode,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newTestUserService(t)
+			user := testUser(t)
+			m.repo.On("GetByIdentifier", mock.Anything, user.Phone).Return(user, nil)
+			tt.setup(m, user)
+
+			err := svc.VerifyContact(context.Background(), model.VerifyContactRequest{
+				Identifier: user.Phone,
+				Channel:    util.ChannelPhone,
+				Code:       tt.code,
+			})
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestUserServicePasswordReset(t *testing.T) {
+	const resetToken = "reset-token"
+
+	tests := []struct {
+		name    string
+		run     func(svc *userService) error
+		setup   func(m userServiceMocks, user *model.User)
+		wantErr string
+	}{
+		{
+			name: "forgot password sends a token to the identifier channel",
+			run: func(svc *userService) error {
+				return svc.ForgotPassword(context.Background(), model.ForgotPasswordRequest{Identifier: "+6281234567890"})
+			},
+			setup: func(m userServiceMocks, user *model.User) {
+				m.repo.On("GetByIdentifier", mock.Anything, "+6281234567890").Return(user, nil)
+				m.redisRepo.On("StorePasswordResetToken", mock.Anything, mock.Anything, user.ID, 600*time.Second).Return(nil)
+				m.notifier.On("Send", mock.Anything, util.ChannelPhone, user.Phone, mock.Anything).Return(nil)
+			},
+		},
+		{
+			name: "forgot password does not reveal unknown users",
+			run: func(svc *userService) error {
+				return svc.ForgotPassword(context.Background(), model.ForgotPasswordRequest{Identifier: "nobody@example.com"})
+			},
+			setup: func(m userServiceMocks, user *model.User) {
+				m.repo.On("GetByIdentifier", mock.Anything, "nobody@example.com").Return(nil, errors.New(util.ErrUserNotFound))
+			},
+		},
+		{
+			name: "reset password consumes the token and revokes the sessions",
+			run: func(svc *userService) error {
+				return svc.ResetPassword(context.Background(), model.ResetPasswordRequest{Token: resetToken, NewPassword: "N3w-Passw0rd!"})
+			},
+			setup: func(m userServiceMocks, user *model.User) {
+				m.redisRepo.On("ConsumePasswordResetToken", mock.Anything, util.HashToken(resetToken)).Return(user.ID, nil)
+				m.repo.On("Get", mock.Anything, user.ID).Return(user, nil)
+				m.repo.On("Update", mock.Anything, mock.MatchedBy(func(user *model.User) bool {
+					return util.ValidatePassword("N3w-Passw0rd!", user.Passsword) == nil
+				})).Return(nil)
+				m.redisRepo.On("DeleteUserSessions", mock.Anything, user.ID).Return(nil)
+			},
+		},
+		{
+			name: "reset password with a used or unknown token",
+			run: func(svc *userService) error {
+				return svc.ResetPassword(context.Background(), model.ResetPasswordRequest{Token: resetToken, NewPassword: "N3w-Passw0rd!"})
+			},
+			setup: func(m userServiceMocks, user *model.User) {
+				m.redisRepo.On("ConsumePasswordResetToken", mock.Anything, util.HashToken(resetToken)).Return(0, errors.New("redis: nil"))
+			},
+			wantErr: util.ErrInvalidResetToken,
+		},
+		{
+			name: "change password requires the old password",
+			run: func(svc *userService) error {
+				return svc.ChangePassword(context.Background(), 1, model.ChangePasswordRequest{OldPassword: "wrong", NewPassword: "N3w-Passw0rd!"})
+			},
+			setup: func(m userServiceMocks, user *model.User) {
+				m.repo.On("Get", mock.Anything, user.ID).Return(user, nil)
+			},
+			wantErr: util.ErrInvalidPassword,
+		},
+		{
+			name: "change password revokes the sessions",
+			run: func(svc *userService) error {
+				return svc.ChangePassword(context.Background(), 1, model.ChangePasswordRequest{OldPassword: testPassword, NewPassword: "N3w-Passw0rd!"})
+			},
+			setup: func(m userServiceMocks, user *model.User) {
+				m.repo.On("Get", mock.Anything, user.ID).Return(user, nil)
+				m.repo.On("Update", mock.Anything, mock.Anything).Return(nil)
+				m.redisRepo.On("DeleteUserSessions", mock.Anything, user.ID).Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newTestUserService(t)
+			tt.setup(m, testUser(t))
+
+			err := tt.run(svc)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			m.notifier.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserServiceOIDCCallback(t *testing.T) {
+	identity := model.Identity{Provider: "stub", Subject: "subject-1"}
+
+	tests := []struct {
+		name         string
+		oidcIdentity model.OIDCIdentity
+		setup        func(m userServiceMocks, user *model.User)
+		wantUserID   int
+		wantErr      string
+	}{
+		{
+			name:         "known identity logs in",
+			oidcIdentity: model.OIDCIdentity{Provider: "stub", Subject: "subject-1", Email: "jane@example.com", EmailVerified: true},
+			setup: func(m userServiceMocks, user *model.User) {
+				m.repo.On("GetByIdentity", mock.Anything, identity).Return(user, nil)
+			},
+			wantUserID: 1,
+		},
+		{
+			name:         "verified email links the existing user",
+			oidcIdentity: model.OIDCIdentity{Provider: "stub", Subject: "subject-1", Email: "Jane@Example.com", EmailVerified: true},
+			setup: func(m userServiceMocks, user *model.User) {
+				m.repo.On("GetByIdentity", mock.Anything, identity).Return(nil, errors.New(util.ErrUserNotFound))
+				m.repo.On("GetByIdentifier", mock.Anything, "jane@example.com").Return(user, nil)
+				m.repo.On("Update", mock.Anything, mock.MatchedBy(func(user *model.User) bool {
+					return len(user.UserDetail.Identities) == 1 && user.UserDetail.Identities[0] == identity
+				})).Return(nil)
+			},
+			wantUserID: 1,
+		},
+		{
+			name:         "new user is created without a phone",
+			oidcIdentity: model.OIDCIdentity{Provider: "stub", Subject: "subject-1", Email: "john@example.com", EmailVerified: true, Name: "John Doe"},
+			setup: func(m userServiceMocks, user *model.User) {
+				m.repo.On("GetByIdentity", mock.Anything, identity).Return(nil, errors.New(util.ErrUserNotFound))
+				m.repo.On("GetByIdentifier", mock.Anything, "john@example.com").Return(nil, errors.New(util.ErrUserNotFound))
+				m.repo.On("Create", mock.Anything, mock.MatchedBy(func(user *model.User) bool {
+					return user.Email == "john@example.com" && user.Phone == "" && user.Name == "John Doe"
+				})).Run(func(args mock.Arguments) {
+					args.Get(1).(*model.User).ID = 2
+				}).Return(nil)
+			},
+			wantUserID: 2,
+		},
+		{
+			name:         "unverified email is never linked",
+			oidcIdentity: model.OIDCIdentity{Provider: "stub", Subject: "subject-1", Email: "jane@example.com"},
+			setup: func(m userServiceMocks, user *model.User) {
+				m.repo.On("GetByIdentity", mock.Anything, identity).Return(nil, errors.New(util.ErrUserNotFound))
+				m.repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+			},
+			wantErr: util.ErrContactNotVerified,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newTestUserService(t)
+			provider := &mocks.OIDCProvider{}
+			svc.oidcProviders = map[string]repository.OIDCProvider{"stub": provider}
+			user := testUser(t)
+			user.Phone = ""
+
+			m.redisRepo.On("ConsumeOIDCState", mock.Anything, "state").Return(&model.OIDCState{
+				Provider:     "stub",
+				CodeVerifier: "verifier",
+				Nonce:        "nonce",
+			}, nil)
+			oidcIdentity := tt.oidcIdentity
+			provider.On("Exchange", mock.Anything, "code", "verifier", "nonce").Return(&oidcIdentity, nil)
+			m.redisRepo.On("IsAccountLocked", mock.Anything, mock.Anything).Return(false, nil).Maybe()
+			m.redisRepo.On("ResetLoginAttempt", mock.Anything, mock.Anything).Return(nil).Maybe()
+			m.redisRepo.On("StoreSession", mock.Anything, mock.Anything).Return(nil).Maybe()
+			m.redisRepo.On("StoreTokenFamily", mock.Anything, mock.Anything).Return(nil).Maybe()
+			m.redisRepo.On("StoreRefreshToken", mock.Anything, mock.Anything).Return(nil).Maybe()
+			tt.setup(m, user)
+
+			loginData, err := svc.OIDCCallback(context.Background(), "stub", "code", "state")
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			claims, err := svc.keySet.VerifyToken(loginData.Token)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantUserID, claims.ID)
+			provider.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserServiceLoginTwoFactor(t *testing.T) {
+	const clientIP = "203.0.113.7"
+	identifierKey := "identifier:jane@example.com"
+	ipKey := "ip:" + clientIP
+	challengeHash := util.HashToken("challenge")
+
+	tests := []struct {
+		name    string
+		code    string
+		setup   func(m userServiceMocks, user *model.User)
+		wantErr string
+	}{
+		{
+			name: "recovery code completes the login",
+			code: "ABCDE-FGHIJ",
+			setup: func(m userServiceMocks, user *model.User) {
+				m.repo.On("Update", mock.Anything, mock.MatchedBy(func(user *model.User) bool {
+					return len(user.UserDetail.TwoFactor.RecoveryCodeHashes) == 0
+				})).Return(nil)
+				m.redisRepo.On("DeleteLoginChallenge", mock.Anything, challengeHash).Return(nil)
+				m.redisRepo.On("ResetLoginAttempt", mock.Anything, identifierKey).Return(nil)
+				m.redisRepo.On("ResetLoginAttempt", mock.Anything, ipKey).Return(nil)
+				m.redisRepo.On("StoreSession", mock.Anything, mock.Anything).Return(nil)
+				m.redisRepo.On("StoreTokenFamily", mock.Anything, mock.Anything).Return(nil)
+				m.redisRepo.On("StoreRefreshToken", mock.Anything, mock.Anything).Return(nil)
+			},
+		},
+		{
+			name: "wrong code counts toward the login lock",
+			code: "000000",
+			setup: func(m userServiceMocks, user *model.User) {
+				m.redisRepo.On("IncrLoginAttempt", mock.Anything, identifierKey, mock.Anything).Return(int64(2), nil)
+				m.redisRepo.On("IncrLoginAttempt", mock.Anything, ipKey, mock.Anything).Return(int64(2), nil)
+				m.redisRepo.On("SetLoginBackoff", mock.Anything, mock.Anything, 2*time.Second).Return(nil).Twice()
+				m.redisRepo.On("IncrLoginChallengeAttempt", mock.Anything, challengeHash, mock.Anything).Return(int64(1), nil)
+			},
+			wantErr: util.ErrInvalidTwoFactorCode,
+		},
+		{
+			name: "last wrong code of the challenge deletes it",
+			code: "000000",
+			setup: func(m userServiceMocks, user *model.User) {
+				m.redisRepo.On("IncrLoginAttempt", mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil).Twice()
+				m.redisRepo.On("SetLoginBackoff", mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+				m.redisRepo.On("IncrLoginChallengeAttempt", mock.Anything, challengeHash, mock.Anything).Return(int64(5), nil)
+				m.redisRepo.On("DeleteLoginChallenge", mock.Anything, challengeHash).Return(nil)
+			},
+			wantErr: util.ErrInvalidTwoFactorCode,
+		},
+		{
+			name: "wrong code reaching the login threshold locks the account",
+			code: "000000",
+			setup: func(m userServiceMocks, user *model.User) {
+				m.redisRepo.On("IncrLoginAttempt", mock.Anything, identifierKey, mock.Anything).Return(int64(5), nil)
+				m.redisRepo.On("IncrLoginAttempt", mock.Anything, ipKey, mock.Anything).Return(int64(5), nil)
+				m.redisRepo.On("SetLoginBackoff", mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+				m.redisRepo.On("LockAccount", mock.Anything, user.ID, 900*time.Second).Return(nil)
+				m.redisRepo.On("IncrLoginChallengeAttempt", mock.Anything, challengeHash, mock.Anything).Return(int64(1), nil)
+				m.redisRepo.On("DeleteLoginChallenge", mock.Anything, challengeHash).Return(nil)
+			},
+			wantErr: util.ErrAccountLocked,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newTestUserService(t)
+			user := testUser(t)
+			secret, err := util.GenerateTOTPSecret()
+			require.NoError(t, err)
+			user.UserDetail.TwoFactor = model.TwoFactor{
+				Enabled:            true,
+				Secret:             secret,
+				RecoveryCodeHashes: []string{util.HashToken(normalizeRecoveryCode("abcde-fghij"))},
+			}
+
+			m.redisRepo.On("GetLoginChallenge", mock.Anything, challengeHash).Return(&model.LoginChallenge{
+				TokenHash:     challengeHash,
+				UserID:        user.ID,
+				IdentifierKey: identifierKey,
+				ExpiredAt:     time.Now().Add(5 * time.Minute),
+			}, nil)
+			m.redisRepo.On("GetLoginBackoff", mock.Anything, mock.Anything).Return(time.Duration(0), nil).Twice()
+			m.repo.On("Get", mock.Anything, user.ID).Return(user, nil)
+			m.redisRepo.On("IsAccountLocked", mock.Anything, user.ID).Return(false, nil)
+			tt.setup(m, user)
+
+			loginData, err := svc.LoginTwoFactor(context.Background(), model.TwoFactorLoginRequest{
+				ChallengeToken: "challenge",
+				Code:           tt.code,
+				ClientIP:       clientIP,
+			})
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotEmpty(t, loginData.Token)
+		})
+	}
+}
+
+func TestUserServiceDelete(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(m userServiceMocks)
+		wantErr string
+	}{
+		{
+			name: "deleted user loses its sessions",
+			setup: func(m userServiceMocks) {
+				m.repo.On("Delete", mock.Anything, 1).Return(nil)
+				m.redisRepo.On("DeleteUserSessions", mock.Anything, 1).Return(nil)
+			},
+		},
+		{
+			name: "unknown user",
+			setup: func(m userServiceMocks) {
+				m.repo.On("Delete", mock.Anything, 1).Return(errors.New(util.ErrUserNotFound))
+			},
+			wantErr: util.ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newTestUserService(t)
+			tt.setup(m)
+
+			err := svc.Delete(context.Background(), 1)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
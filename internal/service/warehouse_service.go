@@ -3,6 +3,9 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"simcomm-monolith/config"
 	"simcomm-monolith/internal/model"
 	"simcomm-monolith/internal/repository"
@@ -10,6 +13,7 @@ import (
 
 	log "github.com/labstack/gommon/log"
 	amqp "github.com/rabbitmq/amqp091-go"
+	"gorm.io/gorm"
 )
 
 // WarehouseService defines the methods for the Warehouse service
@@ -17,8 +21,11 @@ type WarehouseService interface {
 	Create(ctx context.Context, warehouse *model.Warehouse) error
 	Get(ctx context.Context, id int) (*model.Warehouse, error)
 	GetAll(ctx context.Context) ([]model.Warehouse, error)
+	GetByShopID(ctx context.Context, req model.WarehouseListRequest) (*model.WarehouseList, error)
 	Update(ctx context.Context, warehouse *model.Warehouse) error
 	Delete(ctx context.Context, id int) error
+	GetStatusHistory(ctx context.Context, id int) ([]model.StatusChange, error)
+	UploadImage(ctx context.Context, id int, file io.Reader) (*model.ImageData, error)
 
 	WarehouseStoredProductService
 
@@ -28,21 +35,33 @@ type WarehouseService interface {
 
 type warehouseService struct {
 	repo      repository.WarehouseRepository
+	shopRepo  repository.ShopRepository
 	redisRepo repository.RedisRepository
+	storage   repository.Storage
 	queue     repository.Queue
 	cfg       *config.Config
 }
 
-func NewWarehouseService(repo repository.WarehouseRepository, redisRepo repository.RedisRepository, q repository.Queue, cfg *config.Config) *warehouseService {
+func NewWarehouseService(repo repository.WarehouseRepository, shopRepo repository.ShopRepository, redisRepo repository.RedisRepository, storage repository.Storage, q repository.Queue, cfg *config.Config) *warehouseService {
 	return &warehouseService{
 		repo:      repo,
+		shopRepo:  shopRepo,
 		redisRepo: redisRepo,
+		storage:   storage,
 		queue:     q,
 		cfg:       cfg,
 	}
 }
 
+// Create adds a warehouse to a shop the authenticated user manages
 func (s *warehouseService) Create(ctx context.Context, warehouse *model.Warehouse) error {
+	if _, _, err := authorizeShopMember(ctx, s.shopRepo, warehouse.ShopID, shopManagerRoles...); err != nil {
+		return err
+	}
+	if err := applyInitialStatus(ctx, warehouse, warehouse.Status); err != nil {
+		return err
+	}
+
 	timeNow := util.TimeNow()
 	warehouse.CreatedAt = timeNow
 	warehouse.UpdatedAt = timeNow
@@ -53,20 +72,125 @@ func (s *warehouseService) Create(ctx context.Context, warehouse *model.Warehous
 	return err
 }
 
+// Get retrieves a warehouse for any member of its shop
 func (s *warehouseService) Get(ctx context.Context, id int) (*model.Warehouse, error) {
-	return s.repo.Get(ctx, id)
+	return s.authorizeWarehouse(ctx, id, shopMemberRoles...)
 }
 
 func (s *warehouseService) GetAll(ctx context.Context) ([]model.Warehouse, error) {
 	return s.repo.GetAll(ctx)
 }
 
+// GetByShopID lists the warehouses of a shop to any of its members
+func (s *warehouseService) GetByShopID(ctx context.Context, req model.WarehouseListRequest) (*model.WarehouseList, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if _, _, err := authorizeShopMember(ctx, s.shopRepo, req.ShopID, shopMemberRoles...); err != nil {
+		return nil, err
+	}
+
+	warehouses, total, err := s.repo.GetByShopID(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &model.WarehouseList{
+		Warehouses: warehouses,
+		Total:      total,
+		Page:       req.Page,
+		Limit:      req.Limit,
+	}, nil
+}
+
+// Update rejects a status the stored status cannot move to, a warehouse stays in its shop
 func (s *warehouseService) Update(ctx context.Context, warehouse *model.Warehouse) error {
-	return s.repo.Update(ctx, warehouse)
+	existing, err := s.authorizeWarehouse(ctx, warehouse.ID, shopManagerRoles...)
+	if err != nil {
+		return err
+	}
+	if err := applyStatus(ctx, warehouse, existing.Status, warehouse.Status); err != nil {
+		return err
+	}
+
+	warehouse.ShopID = existing.ShopID
+	warehouse.CreatedAt = existing.CreatedAt
+	warehouse.UpdatedAt = util.TimeNow()
+	if err := s.repo.Update(ctx, warehouse); err != nil {
+		return err
+	}
+	// sellable stock only counts the stock of active warehouses
+	invalidateStorefronts(ctx, s.redisRepo, warehouse.ShopID)
+	return nil
 }
 
 func (s *warehouseService) Delete(ctx context.Context, id int) error {
-	return s.repo.Delete(ctx, id)
+	existing, err := s.authorizeWarehouse(ctx, id, shopManagerRoles...)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	invalidateStorefronts(ctx, s.redisRepo, existing.ShopID)
+	return nil
+}
+
+func (s *warehouseService) GetStatusHistory(ctx context.Context, id int) ([]model.StatusChange, error) {
+	if _, err := s.authorizeWarehouse(ctx, id, shopMemberRoles...); err != nil {
+		return nil, err
+	}
+	return s.repo.GetStatusHistory(ctx, id)
+}
+
+// authorizeWarehouse ensures the authenticated user is a member of the shop of the
+// warehouse with one of the roles
+func (s *warehouseService) authorizeWarehouse(ctx context.Context, id int, roles ...string) (*model.Warehouse, error) {
+	warehouse, err := s.getWarehouse(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, err := authorizeShopMember(ctx, s.shopRepo, warehouse.ShopID, roles...); err != nil {
+		return nil, err
+	}
+	return warehouse, nil
+}
+
+func (s *warehouseService) getWarehouse(ctx context.Context, id int) (*model.Warehouse, error) {
+	warehouse, err := s.repo.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(util.ErrWarehouseNotFound)
+		}
+		log.Error(err)
+		return nil, err
+	}
+	return warehouse, nil
+}
+
+// UploadImage replaces the warehouse image and its thumbnail
+func (s *warehouseService) UploadImage(ctx context.Context, id int, file io.Reader) (*model.ImageData, error) {
+	warehouse, err := s.authorizeWarehouse(ctx, id, shopManagerRoles...)
+	if err != nil {
+		return nil, err
+	}
+
+	image, err := uploadImage(ctx, s.storage, s.cfg.StorageConfig, fmt.Sprintf("warehouses/%v", id), file)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := model.ImageData{ImageURL: warehouse.Detail.ImageURL, ThumbnailURL: warehouse.Detail.ThumbnailURL}
+	warehouse.Detail.ImageURL = image.ImageURL
+	warehouse.Detail.ThumbnailURL = image.ThumbnailURL
+	warehouse.UpdatedAt = util.TimeNow()
+	if err := s.repo.Update(ctx, warehouse); err != nil {
+		log.Error(err)
+		deleteImage(ctx, s.storage, *image)
+		return nil, err
+	}
+
+	deleteImage(ctx, s.storage, previous)
+	return image, nil
 }
 
 // WarehouseStoredProductService defines the methods for the WarehouseStoredProduct service
@@ -74,35 +198,127 @@ type WarehouseStoredProductService interface {
 	WSPCreate(ctx context.Context, warehousestoredproduct *model.WarehouseStoredProduct) error
 	WSPGet(ctx context.Context, id int) (*model.WarehouseStoredProduct, error)
 	WSPGetAll(ctx context.Context) ([]model.WarehouseStoredProduct, error)
+	WSPGetByWarehouseID(ctx context.Context, req model.StoredProductListRequest) (*model.StoredProductList, error)
 	WSPUpdate(ctx context.Context, warehousestoredproduct *model.WarehouseStoredProduct) error
 	WSPDelete(ctx context.Context, id int) error
 }
 
+// WSPCreate stores a shop product in a warehouse of the same shop, any member of the
+// shop can store products
 func (s *warehouseService) WSPCreate(ctx context.Context, warehousestoredproduct *model.WarehouseStoredProduct) error {
+	warehouse, err := s.authorizeWarehouse(ctx, warehousestoredproduct.WarehouseID, shopMemberRoles...)
+	if err != nil {
+		return err
+	}
+	shopProduct, err := s.shopRepo.ShopProductRepositoryGet(ctx, warehousestoredproduct.ShopProductID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Error(err)
+		return err
+	}
+	if shopProduct == nil || shopProduct.ShopID != warehouse.ShopID {
+		return errors.New(util.ErrShopProductNotFound)
+	}
+
 	timeNow := util.TimeNow()
 	warehousestoredproduct.CreatedAt = timeNow
 	warehousestoredproduct.UpdatedAt = timeNow
-	err := s.repo.WSPCreate(ctx, warehousestoredproduct)
+	err = s.repo.WSPCreate(ctx, warehousestoredproduct)
 	if err != nil {
 		log.Error(err)
+		return err
 	}
-	return err
+	invalidateStorefronts(ctx, s.redisRepo, warehouse.ShopID)
+	return nil
 }
 
+// WSPGet retrieves a stored product for any member of the shop of its warehouse
 func (s *warehouseService) WSPGet(ctx context.Context, id int) (*model.WarehouseStoredProduct, error) {
-	return s.repo.WSPGet(ctx, id)
+	warehousestoredproduct, err := s.getWSP(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.authorizeWarehouse(ctx, warehousestoredproduct.WarehouseID, shopMemberRoles...); err != nil {
+		return nil, err
+	}
+	return warehousestoredproduct, nil
 }
 
 func (s *warehouseService) WSPGetAll(ctx context.Context) ([]model.WarehouseStoredProduct, error) {
 	return s.repo.WSPGetAll(ctx)
 }
 
+// WSPGetByWarehouseID lists the products stored in a warehouse to any member of its shop
+func (s *warehouseService) WSPGetByWarehouseID(ctx context.Context, req model.StoredProductListRequest) (*model.StoredProductList, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := s.authorizeWarehouse(ctx, req.WarehouseID, shopMemberRoles...); err != nil {
+		return nil, err
+	}
+
+	storedProducts, total, err := s.repo.WSPGetByWarehouseID(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &model.StoredProductList{
+		StoredProducts: storedProducts,
+		Total:          total,
+		Page:           req.Page,
+		Limit:          req.Limit,
+	}, nil
+}
+
+// WSPUpdate keeps the warehouse, shop product and variant of the stored product, stock
+// stays counted per variant and moves between warehouses through transfers
 func (s *warehouseService) WSPUpdate(ctx context.Context, warehousestoredproduct *model.WarehouseStoredProduct) error {
-	return s.repo.WSPUpdate(ctx, warehousestoredproduct)
+	existing, err := s.getWSP(ctx, warehousestoredproduct.ID)
+	if err != nil {
+		return err
+	}
+	warehouse, err := s.authorizeWarehouse(ctx, existing.WarehouseID, shopMemberRoles...)
+	if err != nil {
+		return err
+	}
+
+	warehousestoredproduct.WarehouseID = existing.WarehouseID
+	warehousestoredproduct.ShopProductID = existing.ShopProductID
+	warehousestoredproduct.VariantID = existing.VariantID
+	warehousestoredproduct.CreatedAt = existing.CreatedAt
+	warehousestoredproduct.UpdatedAt = util.TimeNow()
+	if err := s.repo.WSPUpdate(ctx, warehousestoredproduct); err != nil {
+		return err
+	}
+	invalidateStorefronts(ctx, s.redisRepo, warehouse.ShopID)
+	return nil
 }
 
 func (s *warehouseService) WSPDelete(ctx context.Context, id int) error {
-	return s.repo.WSPDelete(ctx, id)
+	existing, err := s.getWSP(ctx, id)
+	if err != nil {
+		return err
+	}
+	warehouse, err := s.authorizeWarehouse(ctx, existing.WarehouseID, shopMemberRoles...)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.WSPDelete(ctx, id); err != nil {
+		return err
+	}
+	invalidateStorefronts(ctx, s.redisRepo, warehouse.ShopID)
+	return nil
+}
+
+// getWSP retrieves a stored product, a missing one is reported like a missing warehouse
+func (s *warehouseService) getWSP(ctx context.Context, id int) (*model.WarehouseStoredProduct, error) {
+	warehousestoredproduct, err := s.repo.WSPGet(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(util.ErrWarehouseNotFound)
+		}
+		log.Error(err)
+		return nil, err
+	}
+	return warehousestoredproduct, nil
 }
 
 func (s *warehouseService) WSPGetByShopProductID(ctx context.Context, shopProductID int, warehouseID int) (*model.WarehouseStoredProduct, error) {
@@ -145,5 +361,8 @@ func (s *warehouseService) ProcessTPQueue(ctx context.Context, msg amqp.Delivery
 		log.Error(err)
 		return err
 	}
+	if warehouse, err := s.repo.Get(ctx, tp.WarehouseIDSource); err == nil {
+		invalidateStorefronts(ctx, s.redisRepo, warehouse.ShopID)
+	}
 	return nil
 }
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"simcomm-monolith/internal/model"
+	"simcomm-monolith/internal/repository/mocks"
+	"simcomm-monolith/util"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type warehouseServiceMocks struct {
+	repo     *mocks.WarehouseRepository
+	shopRepo *mocks.ShopRepository
+}
+
+func newTestWarehouseService(t *testing.T) (*warehouseService, warehouseServiceMocks) {
+	t.Helper()
+	m := warehouseServiceMocks{
+		repo:     &mocks.WarehouseRepository{},
+		shopRepo: &mocks.ShopRepository{},
+	}
+	t.Cleanup(func() {
+		m.repo.AssertExpectations(t)
+		m.shopRepo.AssertExpectations(t)
+	})
+	return NewWarehouseService(m.repo, m.shopRepo, &mocks.RedisRepository{}, &mocks.Storage{}, &mocks.Queue{}, testConfig()), m
+}
+
+func TestWarehouseServiceWSPGet(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		setup   func(m warehouseServiceMocks)
+		wantErr string
+	}{
+		{
+			name: "owner of the shop reads the stock",
+			ctx:  sellerContext(5),
+		},
+		{
+			name: "staff of the shop reads the stock",
+			ctx:  customerContext(6),
+			setup: func(m warehouseServiceMocks) {
+				m.shopRepo.On("ShopMemberRepositoryGet", mock.Anything, 7, 6).Return(&model.ShopMember{ShopID: 7, UserID: 6, Role: model.ShopRoleStaff}, nil)
+			},
+		},
+		{
+			name: "user outside the shop cannot read the stock",
+			ctx:  customerContext(1),
+			setup: func(m warehouseServiceMocks) {
+				m.shopRepo.On("ShopMemberRepositoryGet", mock.Anything, 7, 1).Return(nil, errors.New(util.ErrShopMemberNotFound))
+			},
+			wantErr: util.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newTestWarehouseService(t)
+			m.repo.On("WSPGet", mock.Anything, 4).Return(&model.WarehouseStoredProduct{ID: 4, WarehouseID: 2, ShopProductID: 3}, nil)
+			m.repo.On("Get", mock.Anything, 2).Return(&model.Warehouse{ID: 2, ShopID: 7}, nil)
+			m.shopRepo.On("Get", mock.Anything, 7).Return(&model.Shop{ID: 7, UserID: 5}, nil)
+			if tt.setup != nil {
+				tt.setup(m)
+			}
+
+			storedProduct, err := svc.WSPGet(tt.ctx, 4)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 4, storedProduct.ID)
+		})
+	}
+
+	svc, m := newTestWarehouseService(t)
+	m.repo.On("WSPGet", mock.Anything, 5).Return(nil, gorm.ErrRecordNotFound)
+	_, err := svc.WSPGet(sellerContext(5), 5)
+	assert.EqualError(t, err, util.ErrWarehouseNotFound)
+}
+
+func TestWarehouseServiceGet(t *testing.T) {
+	svc, m := newTestWarehouseService(t)
+	m.repo.On("Get", mock.Anything, 2).Return(&model.Warehouse{ID: 2, ShopID: 7}, nil)
+	m.shopRepo.On("Get", mock.Anything, 7).Return(&model.Shop{ID: 7, UserID: 5}, nil)
+	m.shopRepo.On("ShopMemberRepositoryGet", mock.Anything, 7, 1).Return(nil, errors.New(util.ErrShopMemberNotFound))
+
+	warehouse, err := svc.Get(sellerContext(5), 2)
+	require.NoError(t, err)
+	assert.Equal(t, 7, warehouse.ShopID)
+
+	_, err = svc.Get(customerContext(1), 2)
+	assert.EqualError(t, err, util.ErrForbidden)
+}
@@ -0,0 +1,89 @@
+-- Tables of design/ERD.png, databases created before the migrations already have them
+
+CREATE TABLE IF NOT EXISTS users (
+	id SERIAL PRIMARY KEY,
+	name VARCHAR(255) NOT NULL,
+	email VARCHAR(255) NOT NULL,
+	phone VARCHAR(32) NOT NULL,
+	password VARCHAR(255) NOT NULL,
+	detail JSONB NOT NULL DEFAULT '{}',
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE UNIQUE INDEX IF NOT EXISTS users_email_key ON users (email);
+CREATE UNIQUE INDEX IF NOT EXISTS users_phone_key ON users (phone);
+
+CREATE TABLE IF NOT EXISTS shops (
+	id SERIAL PRIMARY KEY,
+	user_id INTEGER NOT NULL REFERENCES users (id),
+	name VARCHAR(255) NOT NULL,
+	status VARCHAR(32) NOT NULL,
+	location VARCHAR(255) NOT NULL DEFAULT '',
+	detail JSONB NOT NULL DEFAULT '{}',
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS products (
+	id SERIAL PRIMARY KEY,
+	code VARCHAR(255) NOT NULL,
+	name VARCHAR(255) NOT NULL,
+	status VARCHAR(32) NOT NULL,
+	detail JSONB NOT NULL DEFAULT '{}',
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS shop_products (
+	id SERIAL PRIMARY KEY,
+	product_id INTEGER NOT NULL REFERENCES products (id),
+	shop_id INTEGER NOT NULL REFERENCES shops (id),
+	status VARCHAR(32) NOT NULL,
+	stock INTEGER NOT NULL DEFAULT 0,
+	detail JSONB NOT NULL DEFAULT '{}',
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS warehouses (
+	id SERIAL PRIMARY KEY,
+	shop_id INTEGER NOT NULL REFERENCES shops (id),
+	name VARCHAR(255) NOT NULL,
+	status VARCHAR(32) NOT NULL,
+	location VARCHAR(255) NOT NULL DEFAULT '',
+	detail JSONB NOT NULL DEFAULT '{}',
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS warehouse_stored_products (
+	id SERIAL PRIMARY KEY,
+	warehouse_id INTEGER NOT NULL REFERENCES warehouses (id),
+	shop_product_id INTEGER NOT NULL REFERENCES shop_products (id),
+	shop_product_name VARCHAR(255) NOT NULL DEFAULT '',
+	stock INTEGER NOT NULL DEFAULT 0,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS transferred_products (
+	id SERIAL PRIMARY KEY,
+	shop_product_id INTEGER NOT NULL REFERENCES shop_products (id),
+	warehouse_id_source INTEGER NOT NULL REFERENCES warehouses (id),
+	warehouse_id_destination INTEGER NOT NULL REFERENCES warehouses (id),
+	stock_to_transfer INTEGER NOT NULL,
+	status VARCHAR(32) NOT NULL,
+	detail JSONB NOT NULL DEFAULT '{}',
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS orders (
+	id SERIAL PRIMARY KEY,
+	user_id INTEGER NOT NULL REFERENCES users (id),
+	shop_id INTEGER NOT NULL REFERENCES shops (id),
+	status VARCHAR(32) NOT NULL,
+	detail JSONB NOT NULL DEFAULT '{}',
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
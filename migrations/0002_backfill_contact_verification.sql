@@ -0,0 +1,9 @@
+-- Users created before sign-up verification could never verify their contacts,
+-- they are treated as verified so they are not locked out of their accounts
+
+UPDATE users
+SET detail = jsonb_set(
+	jsonb_set(COALESCE(detail, '{}'), '{email_verified}', 'true'),
+	'{phone_verified}', 'true'
+)
+WHERE detail->>'email_verified' IS NULL AND detail->>'phone_verified' IS NULL;
@@ -0,0 +1,36 @@
+-- Contacts stored before sign-up validation are normalized the way util.NormalizeEmail
+-- and util.NormalizePhone do, +62 being util.DefaultPhoneCountryCode, so users can
+-- log in with any spelling of them.
+-- A contact whose normalized value is already taken by another user is left as is
+-- and has to be resolved by hand.
+
+UPDATE users
+SET email = lower(trim(email))
+WHERE email <> lower(trim(email))
+	AND NOT EXISTS (
+		SELECT 1 FROM users other
+		WHERE other.id <> users.id AND other.email = lower(trim(users.email))
+	);
+
+WITH stripped AS (
+	SELECT id, regexp_replace(phone, '[ .()-]', '', 'g') AS phone
+	FROM users
+), normalized AS (
+	SELECT id,
+		CASE
+			WHEN phone LIKE '00%' THEN '+' || substr(phone, 3)
+			WHEN phone LIKE '0%' THEN '+62' || substr(phone, 2)
+			ELSE phone
+		END AS phone
+	FROM stripped
+)
+UPDATE users
+SET phone = normalized.phone
+FROM normalized
+WHERE users.id = normalized.id
+	AND users.phone <> normalized.phone
+	AND normalized.phone ~ '^\+[1-9][0-9]{7,14}$'
+	AND NOT EXISTS (
+		SELECT 1 FROM users other
+		WHERE other.id <> users.id AND other.phone = normalized.phone
+	);
@@ -0,0 +1,5 @@
+-- Users signing up through an identity provider have no phone number yet and are
+-- stored with an empty phone, so only phone numbers that are set have to be unique.
+
+DROP INDEX IF EXISTS users_phone_key;
+CREATE UNIQUE INDEX users_phone_key ON users (phone) WHERE phone <> '';
@@ -0,0 +1,13 @@
+-- model.User.DeletedAt makes deleting a user a soft delete. The row is kept but its
+-- email and phone are free again, so the contacts are only unique among users that
+-- are not deleted.
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+DROP INDEX IF EXISTS users_email_key;
+CREATE UNIQUE INDEX users_email_key ON users (email) WHERE deleted_at IS NULL;
+
+DROP INDEX IF EXISTS users_phone_key;
+CREATE UNIQUE INDEX users_phone_key ON users (phone) WHERE phone <> '' AND deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS users_deleted_at_idx ON users (deleted_at);
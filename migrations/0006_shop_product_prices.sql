@@ -0,0 +1,29 @@
+-- Shop product prices are integer minor units of their currency. shop_products keeps
+-- the price in effect, shop_product_prices every change including the scheduled ones.
+
+ALTER TABLE shop_products ADD COLUMN IF NOT EXISTS price BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE shop_products ADD COLUMN IF NOT EXISTS currency VARCHAR(3) NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS shop_product_prices (
+	id SERIAL PRIMARY KEY,
+	shop_product_id INTEGER NOT NULL REFERENCES shop_products (id) ON DELETE CASCADE,
+	price BIGINT NOT NULL CHECK (price >= 0),
+	currency VARCHAR(3) NOT NULL,
+	effective_at TIMESTAMPTZ NOT NULL,
+	applied_at TIMESTAMPTZ,
+	created_by INTEGER NOT NULL DEFAULT 0,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS shop_product_prices_current_idx ON shop_product_prices (shop_product_id, effective_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS shop_product_prices_scheduled_idx ON shop_product_prices (effective_at) WHERE applied_at IS NULL;
+
+-- Orders are priced from the history, a listing that already has a price gets it
+-- as its initial price change. Listings without a currency never had a price and
+-- cannot be ordered until the seller sets one.
+INSERT INTO shop_product_prices (shop_product_id, price, currency, effective_at, applied_at, created_at)
+SELECT id, price, currency, created_at, now(), now()
+FROM shop_products
+WHERE currency <> ''
+	AND NOT EXISTS (
+		SELECT 1 FROM shop_product_prices prices WHERE prices.shop_product_id = shop_products.id
+	);
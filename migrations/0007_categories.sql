@@ -0,0 +1,17 @@
+-- Category tree of model.Category, a product belongs to at most one category.
+-- A category with sub categories or products cannot be deleted.
+
+CREATE TABLE IF NOT EXISTS categories (
+	id SERIAL PRIMARY KEY,
+	parent_id INTEGER REFERENCES categories (id),
+	name VARCHAR(255) NOT NULL,
+	slug VARCHAR(255) NOT NULL,
+	detail JSONB NOT NULL DEFAULT '{}',
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE UNIQUE INDEX IF NOT EXISTS categories_slug_key ON categories (slug);
+CREATE INDEX IF NOT EXISTS categories_parent_id_idx ON categories (parent_id);
+
+ALTER TABLE products ADD COLUMN IF NOT EXISTS category_id INTEGER REFERENCES categories (id);
+CREATE INDEX IF NOT EXISTS products_category_id_idx ON products (category_id);
@@ -0,0 +1,22 @@
+-- Product variants of model.ProductVariant, a SKU identifies one variant across
+-- every product. Listings, stored stock and order lines of a product with variants
+-- point at the variant they hold.
+
+CREATE TABLE IF NOT EXISTS product_variants (
+	id SERIAL PRIMARY KEY,
+	product_id INTEGER NOT NULL REFERENCES products (id),
+	sku VARCHAR(255) NOT NULL,
+	name VARCHAR(255) NOT NULL DEFAULT '',
+	status VARCHAR(32) NOT NULL DEFAULT '',
+	detail JSONB NOT NULL DEFAULT '{}',
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE UNIQUE INDEX IF NOT EXISTS product_variants_sku_key ON product_variants (sku);
+CREATE INDEX IF NOT EXISTS product_variants_product_id_idx ON product_variants (product_id);
+
+ALTER TABLE shop_products ADD COLUMN IF NOT EXISTS variant_id INTEGER REFERENCES product_variants (id);
+CREATE INDEX IF NOT EXISTS shop_products_variant_id_idx ON shop_products (variant_id);
+
+ALTER TABLE warehouse_stored_products ADD COLUMN IF NOT EXISTS variant_id INTEGER REFERENCES product_variants (id);
+CREATE INDEX IF NOT EXISTS warehouse_stored_products_variant_id_idx ON warehouse_stored_products (variant_id);
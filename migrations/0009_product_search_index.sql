@@ -0,0 +1,10 @@
+-- Full-text search index of the products, the expression must stay the same as
+-- productSearchDocument in the product repository or the planner ignores it.
+
+CREATE INDEX IF NOT EXISTS products_search_idx ON products USING GIN ((
+	setweight(to_tsvector('simple', coalesce(products.name, '')), 'A') ||
+	setweight(to_tsvector('simple', coalesce(products.code, '')), 'A') ||
+	setweight(jsonb_to_tsvector('simple', coalesce(products.detail->'attributes', '{}'::jsonb), '["string", "numeric"]'), 'B')
+));
+
+CREATE INDEX IF NOT EXISTS shop_products_status_idx ON shop_products (status, shop_id);
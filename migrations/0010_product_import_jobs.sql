@@ -0,0 +1,40 @@
+-- Bulk product imports of model.ProductImportJob. Imports upsert products by their
+-- code, which is made unique so concurrent imports cannot create the same product
+-- twice. Codes are trimmed first and products without a code get one made of
+-- their id. Codes that are still duplicated have to be resolved by hand, so the
+-- migration stops with the list of them instead of failing on the index.
+
+CREATE TABLE IF NOT EXISTS product_import_jobs (
+	id SERIAL PRIMARY KEY,
+	user_id INTEGER NOT NULL REFERENCES users (id),
+	format VARCHAR(16) NOT NULL,
+	status VARCHAR(32) NOT NULL,
+	detail JSONB NOT NULL DEFAULT '{}',
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	finished_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS product_import_jobs_user_id_idx ON product_import_jobs (user_id);
+
+UPDATE products SET code = trim(code) WHERE code <> trim(code);
+UPDATE products SET code = 'PRODUCT-' || id WHERE code = '';
+
+DO $$
+DECLARE
+	duplicates TEXT;
+BEGIN
+	SELECT string_agg(format('%s (ids %s)', code, ids), ', ')
+	INTO duplicates
+	FROM (
+		SELECT code, string_agg(id::TEXT, ', ' ORDER BY id) AS ids
+		FROM products
+		GROUP BY code
+		HAVING count(*) > 1
+	) duplicated;
+
+	IF duplicates IS NOT NULL THEN
+		RAISE EXCEPTION 'products share a code, resolve them before making codes unique: %', duplicates;
+	END IF;
+END $$;
+
+CREATE UNIQUE INDEX IF NOT EXISTS products_code_key ON products (code);
@@ -0,0 +1,12 @@
+-- History of model.StatusChange, written in the transaction that saves the entity
+
+CREATE TABLE IF NOT EXISTS status_changes (
+	id SERIAL PRIMARY KEY,
+	entity_type VARCHAR(32) NOT NULL,
+	entity_id INTEGER NOT NULL,
+	from_status VARCHAR(32) NOT NULL DEFAULT '',
+	to_status VARCHAR(32) NOT NULL,
+	changed_by INTEGER NOT NULL DEFAULT 0,
+	changed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS status_changes_entity_idx ON status_changes (entity_type, entity_id, changed_at);
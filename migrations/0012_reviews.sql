@@ -0,0 +1,33 @@
+-- Reviews of completed order lines and their moderation flags. The unique indexes
+-- back util.ErrReviewAlreadyExists and util.ErrReviewAlreadyFlagged
+
+CREATE TABLE IF NOT EXISTS reviews (
+	id SERIAL PRIMARY KEY,
+	order_id INTEGER NOT NULL REFERENCES orders (id),
+	shop_product_id INTEGER NOT NULL REFERENCES shop_products (id),
+	product_id INTEGER NOT NULL REFERENCES products (id),
+	variant_id INTEGER REFERENCES product_variants (id),
+	shop_id INTEGER NOT NULL REFERENCES shops (id),
+	user_id INTEGER NOT NULL REFERENCES users (id),
+	rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+	comment TEXT NOT NULL DEFAULT '',
+	reply TEXT NOT NULL DEFAULT '',
+	replied_by INTEGER REFERENCES users (id),
+	replied_at TIMESTAMPTZ,
+	moderation_status VARCHAR(32) NOT NULL,
+	flag_count INTEGER NOT NULL DEFAULT 0,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE UNIQUE INDEX IF NOT EXISTS reviews_order_line_key ON reviews (order_id, shop_product_id);
+CREATE INDEX IF NOT EXISTS reviews_product_id_idx ON reviews (product_id, moderation_status);
+CREATE INDEX IF NOT EXISTS reviews_shop_id_idx ON reviews (shop_id, moderation_status);
+
+CREATE TABLE IF NOT EXISTS review_flags (
+	id SERIAL PRIMARY KEY,
+	review_id INTEGER NOT NULL REFERENCES reviews (id) ON DELETE CASCADE,
+	user_id INTEGER NOT NULL REFERENCES users (id),
+	reason TEXT NOT NULL DEFAULT '',
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE UNIQUE INDEX IF NOT EXISTS review_flags_review_user_key ON review_flags (review_id, user_id);
@@ -0,0 +1,37 @@
+-- Shop memberships and the invitations to them. A user is a member of a shop once,
+-- which backs util.ErrShopMemberAlreadyExists. Shops created before memberships
+-- get the membership of their owner.
+
+CREATE TABLE IF NOT EXISTS shop_members (
+	id SERIAL PRIMARY KEY,
+	shop_id INTEGER NOT NULL REFERENCES shops (id) ON DELETE CASCADE,
+	user_id INTEGER NOT NULL REFERENCES users (id),
+	role VARCHAR(32) NOT NULL,
+	invited_by INTEGER REFERENCES users (id),
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE UNIQUE INDEX IF NOT EXISTS shop_members_shop_user_key ON shop_members (shop_id, user_id);
+CREATE INDEX IF NOT EXISTS shop_members_user_id_idx ON shop_members (user_id);
+
+INSERT INTO shop_members (shop_id, user_id, role, created_at, updated_at)
+SELECT id, user_id, 'owner', created_at, updated_at FROM shops
+ON CONFLICT (shop_id, user_id) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS shop_invitations (
+	id SERIAL PRIMARY KEY,
+	shop_id INTEGER NOT NULL REFERENCES shops (id) ON DELETE CASCADE,
+	email VARCHAR(255) NOT NULL DEFAULT '',
+	phone VARCHAR(32) NOT NULL DEFAULT '',
+	role VARCHAR(32) NOT NULL,
+	token_hash VARCHAR(255) NOT NULL,
+	status VARCHAR(32) NOT NULL,
+	invited_by INTEGER NOT NULL REFERENCES users (id),
+	accepted_by INTEGER REFERENCES users (id),
+	expires_at TIMESTAMPTZ NOT NULL,
+	accepted_at TIMESTAMPTZ,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE UNIQUE INDEX IF NOT EXISTS shop_invitations_token_hash_key ON shop_invitations (token_hash);
+CREATE INDEX IF NOT EXISTS shop_invitations_shop_id_idx ON shop_invitations (shop_id, status);
@@ -0,0 +1,16 @@
+-- Owners of model.Product, the user who created the product and optionally the shop
+-- it belongs to. The creators of existing products are unknown, a product listed by a
+-- single shop gets that shop as its owner and any other product stays admin only.
+
+ALTER TABLE products ADD COLUMN IF NOT EXISTS user_id INTEGER REFERENCES users (id);
+ALTER TABLE products ADD COLUMN IF NOT EXISTS shop_id INTEGER REFERENCES shops (id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS products_user_id_idx ON products (user_id);
+CREATE INDEX IF NOT EXISTS products_shop_id_idx ON products (shop_id);
+
+UPDATE products SET shop_id = listings.shop_id
+FROM (
+	SELECT product_id, MIN(shop_id) AS shop_id FROM shop_products
+	GROUP BY product_id
+	HAVING COUNT(DISTINCT shop_id) = 1
+) AS listings
+WHERE products.id = listings.product_id AND products.user_id IS NULL AND products.shop_id IS NULL;
@@ -0,0 +1,21 @@
+-- Statuses stored before model.Status was enforced are free-form, such as "Active"
+-- or "enabled". They are mapped to the closest valid status so their entities take
+-- part in the status transitions again, anything not recognized becomes draft.
+
+DO $$
+DECLARE
+	entity TEXT;
+BEGIN
+	FOREACH entity IN ARRAY ARRAY['products', 'shops', 'shop_products', 'warehouses'] LOOP
+		EXECUTE format($sql$
+			UPDATE %I
+			SET status = CASE
+				WHEN lower(trim(status)) IN ('active', 'enabled', 'published', 'live') THEN 'active'
+				WHEN lower(trim(status)) IN ('suspended', 'disabled', 'inactive', 'blocked') THEN 'suspended'
+				WHEN lower(trim(status)) IN ('archived', 'deleted', 'removed', 'closed') THEN 'archived'
+				ELSE 'draft'
+			END
+			WHERE status NOT IN ('draft', 'active', 'suspended', 'archived')
+		$sql$, entity);
+	END LOOP;
+END $$;
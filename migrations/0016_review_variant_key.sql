@@ -0,0 +1,5 @@
+-- An order line is its shop product and variant, so reviews are unique per order,
+-- shop product and variant. Lines without a variant are compared as variant 0.
+
+DROP INDEX IF EXISTS reviews_order_line_key;
+CREATE UNIQUE INDEX reviews_order_line_key ON reviews (order_id, shop_product_id, COALESCE(variant_id, 0));
@@ -0,0 +1,63 @@
+// Package migrations applies the SQL migrations embedded in the binary. Files are
+// applied once each, in file name order, within a transaction and are recorded in
+// the schema_migrations table. Applied files must never be edited, add a new one.
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+
+	"gorm.io/gorm"
+)
+
+//go:embed *.sql
+var files embed.FS
+
+const createSchemaMigrations = `CREATE TABLE IF NOT EXISTS schema_migrations (
+	version VARCHAR(255) PRIMARY KEY,
+	applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+)`
+
+// Migrate applies the migrations that have not been applied yet
+func Migrate(db *gorm.DB) error {
+	if err := db.Exec(createSchemaMigrations).Error; err != nil {
+		return err
+	}
+
+	var applied []string
+	if err := db.Table("schema_migrations").Pluck("version", &applied).Error; err != nil {
+		return err
+	}
+	appliedVersions := make(map[string]bool, len(applied))
+	for _, version := range applied {
+		appliedVersions[version] = true
+	}
+
+	// ReadDir returns the entries sorted by file name
+	entries, err := fs.ReadDir(files, ".")
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		version := entry.Name()
+		if appliedVersions[version] {
+			continue
+		}
+
+		statements, err := files.ReadFile(version)
+		if err != nil {
+			return err
+		}
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(string(statements)).Error; err != nil {
+				return err
+			}
+			return tx.Exec("INSERT INTO schema_migrations (version) VALUES (?)", version).Error
+		})
+		if err != nil {
+			return fmt.Errorf("migration %v : %w", version, err)
+		}
+	}
+	return nil
+}
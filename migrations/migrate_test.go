@@ -0,0 +1,85 @@
+package migrations
+
+import (
+	"io/fs"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func newMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	require.NoError(t, err)
+	return db, mock
+}
+
+func migrationVersions(t *testing.T) []string {
+	t.Helper()
+	entries, err := fs.ReadDir(files, ".")
+	require.NoError(t, err)
+	var versions []string
+	for _, entry := range entries {
+		versions = append(versions, entry.Name())
+	}
+	return versions
+}
+
+func TestMigrationsAreNumbered(t *testing.T) {
+	numbered := regexp.MustCompile(`^\d{4}_[a-z0-9_]+\.sql$`)
+	seen := map[string]bool{}
+	for _, version := range migrationVersions(t) {
+		assert.Regexp(t, numbered, version)
+		assert.False(t, seen[version[:4]], "duplicate migration number %v", version[:4])
+		seen[version[:4]] = true
+	}
+}
+
+func TestMigrateAppliesPendingMigrations(t *testing.T) {
+	db, mock := newMockDB(t)
+	versions := migrationVersions(t)
+	require.NotEmpty(t, versions)
+
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE IF NOT EXISTS schema_migrations")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "version" FROM "schema_migrations"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(versions[0]))
+	for _, version := range versions[1:] {
+		mock.ExpectBegin()
+		mock.ExpectExec(".+").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations (version) VALUES ($1)")).
+			WithArgs(version).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+	}
+
+	require.NoError(t, Migrate(db))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrateRollsBackAFailedMigration(t *testing.T) {
+	db, mock := newMockDB(t)
+	versions := migrationVersions(t)
+
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE IF NOT EXISTS schema_migrations")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "version" FROM "schema_migrations"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}))
+	mock.ExpectBegin()
+	mock.ExpectExec(".+").WillReturnError(assert.AnError)
+	mock.ExpectRollback()
+
+	err := Migrate(db)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), versions[0])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
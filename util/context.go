@@ -0,0 +1,33 @@
+package util
+
+import "context"
+
+type authUserKey struct{}
+
+// AuthUser is the authenticated user resolved from the bearer token
+type AuthUser struct {
+	ID      int
+	Roles   []string
+	TokenID string
+}
+
+func SetAuthUser(ctx context.Context, authUser AuthUser) context.Context {
+	return context.WithValue(ctx, authUserKey{}, authUser)
+}
+
+func GetAuthUser(ctx context.Context) (AuthUser, bool) {
+	authUser, ok := ctx.Value(authUserKey{}).(AuthUser)
+	return authUser, ok
+}
+
+// HasRole reports whether the user holds at least one of the given roles
+func (au AuthUser) HasRole(roles ...string) bool {
+	for _, role := range roles {
+		for _, userRole := range au.Roles {
+			if userRole == role {
+				return true
+			}
+		}
+	}
+	return false
+}
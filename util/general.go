@@ -1,6 +1,11 @@
 package util
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"math/big"
 	"time"
 
 	"golang.org/x/crypto/bcrypt"
@@ -10,6 +15,79 @@ const ErrUserAlreadyExists = "user already exists"
 const ErrUserNotFound = "user not found"
 const ErrInternalServerError = "internal server error"
 const ErrWarehouseStockNotEnough = "Stock Not Enough at Warehouse"
+const ErrInvalidCredential = "invalid identifier or password"
+const ErrInvalidToken = "invalid token"
+const ErrForbidden = "forbidden"
+const ErrShopNotFound = "shop not found"
+const ErrShopProductNotFound = "shop product not found"
+const ErrInvalidRole = "invalid role"
+const ErrTooManyLoginAttempts = "too many login attempts, please try again later"
+const ErrAccountLocked = "account is locked"
+const ErrContactNotVerified = "email or phone is not verified"
+const ErrInvalidVerificationCode = "invalid verification code"
+const ErrVerificationCooldown = "a verification code was sent recently, please try again later"
+const ErrInvalidResetToken = "invalid or expired reset token"
+const ErrInvalidPassword = "invalid password"
+const ErrAddressNotFound = "address not found"
+const ErrShippingAddressRequired = "shipping address is required"
+const ErrUnknownOIDCProvider = "unknown identity provider"
+const ErrInvalidOIDCLogin = "invalid identity provider login"
+const ErrInvalidTwoFactorCode = "invalid two-factor code"
+const ErrInvalidLoginChallenge = "invalid or expired login challenge"
+const ErrTwoFactorAlreadyEnabled = "two-factor authentication is already enabled"
+const ErrTwoFactorNotEnabled = "two-factor authentication is not enabled"
+const ErrAccountDeactivated = "account is deactivated"
+const ErrInvalidPrice = "price must not be negative"
+const ErrUnsupportedCurrency = "unsupported currency"
+const ErrPriceNotFound = "price not found"
+const ErrPriceNotSet = "shop product has no price"
+const ErrCurrencyMismatch = "order items must share the same currency"
+const ErrOrderItemsRequired = "order must have at least one item"
+const ErrInvalidOrderItem = "invalid order item"
+const ErrOrderTotalTooLarge = "order total is too large"
+const ErrShopProductNotAvailable = "shop product is not available"
+const ErrCategoryNotFound = "category not found"
+const ErrCategoryInUse = "category still has sub categories or products"
+const ErrCategorySlugExists = "category slug already exists"
+const ErrInvalidCategoryParent = "category parent would create a cycle"
+const ErrProductNotFound = "product not found"
+const ErrVariantNotFound = "product variant not found"
+const ErrVariantRequired = "a variant is required for a product with variants"
+const ErrSKUAlreadyExists = "sku already exists"
+const ErrDuplicateVariantOptions = "a variant with the same options already exists"
+const ErrVariantInUse = "product variant is still listed in a shop"
+const ErrProductListedWithoutVariant = "product is listed without a variant, delist it before adding variants"
+const ErrUnsupportedImageType = "image must be a JPEG, PNG or GIF"
+const ErrInvalidImage = "invalid image"
+const ErrImageTooLarge = "image is too large"
+const ErrImageRequired = "image file is required"
+const ErrWarehouseNotFound = "warehouse not found"
+const ErrImportFileRequired = "import file is required"
+const ErrInvalidImportFormat = "import format must be csv or jsonl"
+const ErrImportTooLarge = "import file has too many rows or is too large"
+const ErrImportJobNotFound = "import job not found"
+const ErrInvalidStatus = "status must be draft, active, suspended or archived"
+const ErrInvalidInitialStatus = "status must be draft or active when created"
+const ErrInvalidStatusTransition = "status transition is not allowed"
+const ErrOrderNotFound = "order not found"
+const ErrOrderNotCompleted = "only a completed order can be reviewed"
+const ErrReviewNotFound = "review not found"
+const ErrReviewAlreadyExists = "order line is already reviewed"
+const ErrReviewAlreadyFlagged = "review is already flagged"
+const ErrShopMemberNotFound = "shop member not found"
+const ErrShopMemberAlreadyExists = "user is already a member of the shop"
+const ErrShopOwnerMembership = "the membership of the shop owner cannot be changed"
+const ErrShopInvitationNotFound = "shop invitation not found"
+const ErrInvalidShopInvitation = "invalid or expired shop invitation"
+const ErrShopInvitationMismatch = "shop invitation was sent to another email or phone"
+
+const RoleCustomer = "customer"
+const RoleSeller = "seller"
+const RoleWarehouseStaff = "warehouse-staff"
+const RoleAdmin = "admin"
+
+const ChannelEmail = "email"
+const ChannelPhone = "phone"
 
 const DateFormatYYYYMMDD = "2006-01-02"
 const DateFormatYYYYMMDDTHHmmss = "2006-01-02T15:04:05"
@@ -18,6 +96,14 @@ var TimeNow = func() time.Time {
 	return time.Now()
 }
 
+func IsValidRole(role string) bool {
+	switch role {
+	case RoleCustomer, RoleSeller, RoleWarehouseStaff, RoleAdmin:
+		return true
+	}
+	return false
+}
+
 func ToDateTimeYYYYMMDD(dateString string) (dt time.Time, err error) {
 	return time.Parse(DateFormatYYYYMMDD, dateString)
 }
@@ -49,3 +135,40 @@ func ValidatePassword(givenPlainTextPassword string, storedHashedPassword string
 	// Comparing the password with the hash
 	return bcrypt.CompareHashAndPassword(hashedPassword, password)
 }
+
+func GenerateRandomString(length int) (string, error) {
+	bytes := make([]byte, length)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+func HashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+func GenerateNumericCode(length int) (string, error) {
+	code := make([]byte, length)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		code[i] = byte('0' + n.Int64())
+	}
+	return string(code), nil
+}
+
+// GeneratePKCE returns a PKCE code verifier with its S256 code challenge
+func GeneratePKCE() (verifier string, challenge string, err error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", "", err
+	}
+	verifier = base64.RawURLEncoding.EncodeToString(bytes)
+	hash := sha256.Sum256([]byte(verifier))
+	challenge = base64.RawURLEncoding.EncodeToString(hash[:])
+	return verifier, challenge, nil
+}
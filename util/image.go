@@ -0,0 +1,114 @@
+package util
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+)
+
+// maxImagePixels rejects images that would take too much memory to decode
+const maxImagePixels = 50_000_000
+
+// imageTypes are the sniffed content types of the supported images, their
+// decoders are registered by the image format imports
+var imageTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+// DecodeImage checks the content of an uploaded image, not its file name or
+// declared type, and returns the decoded image with its content type
+func DecodeImage(data []byte) (image.Image, string, error) {
+	contentType := http.DetectContentType(data)
+	if !imageTypes[contentType] {
+		return nil, "", errors.New(ErrUnsupportedImageType)
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || config.Width*config.Height > maxImagePixels {
+		return nil, "", errors.New(ErrInvalidImage)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", errors.New(ErrInvalidImage)
+	}
+	return img, contentType, nil
+}
+
+// ImageExtension returns the file extension of a supported content type
+func ImageExtension(contentType string) string {
+	switch contentType {
+	case "image/jpeg":
+		return ".jpg"
+	case "image/gif":
+		return ".gif"
+	}
+	return ".png"
+}
+
+// Thumbnail scales the image down so its longest side is at most size pixels,
+// each pixel is the average of the source pixels it covers
+func Thumbnail(img image.Image, size int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= size && height <= size {
+		return img
+	}
+
+	thumbWidth, thumbHeight := size, size
+	if width > height {
+		thumbHeight = max(1, height*size/width)
+	} else {
+		thumbWidth = max(1, width*size/height)
+	}
+
+	thumb := image.NewRGBA(image.Rect(0, 0, thumbWidth, thumbHeight))
+	for y := 0; y < thumbHeight; y++ {
+		srcY0 := bounds.Min.Y + y*height/thumbHeight
+		srcY1 := max(srcY0+1, bounds.Min.Y+(y+1)*height/thumbHeight)
+		for x := 0; x < thumbWidth; x++ {
+			srcX0 := bounds.Min.X + x*width/thumbWidth
+			srcX1 := max(srcX0+1, bounds.Min.X+(x+1)*width/thumbWidth)
+
+			var r, g, b, a, n uint64
+			for sy := srcY0; sy < srcY1; sy++ {
+				for sx := srcX0; sx < srcX1; sx++ {
+					pr, pg, pb, pa := img.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa)
+					n++
+				}
+			}
+			thumb.Set(x, y, color.RGBA64{
+				R: uint16(r / n),
+				G: uint16(g / n),
+				B: uint16(b / n),
+				A: uint16(a / n),
+			})
+		}
+	}
+	return thumb
+}
+
+// EncodeThumbnail encodes a thumbnail as JPEG for photos and as PNG otherwise,
+// so transparency is kept, and returns it with its content type
+func EncodeThumbnail(img image.Image, contentType string) ([]byte, string, error) {
+	var buf bytes.Buffer
+	if contentType == "image/jpeg" {
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85}); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), contentType, nil
+	}
+
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), "image/png", nil
+}
@@ -1,7 +1,17 @@
 package util
 
 import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
 	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"simcomm-monolith/config"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
@@ -11,19 +21,168 @@ var TokenDuration = time.Hour * 24 * 7
 
 type Claims struct {
 	jwt.StandardClaims
-	ID   int    `json:"id"`
-	Role string `json:"role"`
-	Exp  int64  `json:"exp"`
+	ID    int      `json:"id"`
+	Roles []string `json:"roles"`
+	Exp   int64    `json:"exp"`
 }
 
-func GenerateToken(id int, tokenDuration time.Duration, secretKey string) (string, error) {
+type signingKey struct {
+	method     jwt.SigningMethod
+	privateKey crypto.PrivateKey
+	publicKey  crypto.PublicKey
+}
+
+// KeySet signs tokens with the active key and verifies tokens with any of the
+// configured keys, selected by the kid header. When no asymmetric key is
+// active, tokens are signed with HS256 using the configured secret key.
+// Once an asymmetric key is active, HS256 tokens are only accepted until legacyUntil.
+type KeySet struct {
+	activeKeyID string
+	secretKey   string
+	keys        map[string]signingKey
+	legacyUntil time.Time
+}
+
+type JWK struct {
+	KeyID     string `json:"kid"`
+	KeyType   string `json:"kty"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+func GetKeySet(cfg *config.Config) *KeySet {
+	keySet, err := NewKeySet(cfg.AuthTokenConfig)
+	if err != nil {
+		log.Fatalf("failed to load token keys : %v", err)
+	}
+	return keySet
+}
+
+func NewKeySet(cfg config.AuthTokenConfig) (*KeySet, error) {
+	keySet := &KeySet{
+		activeKeyID: cfg.ActiveKeyID,
+		secretKey:   cfg.SecretKey,
+		keys:        make(map[string]signingKey),
+	}
+
+	if cfg.LegacyHS256Until != "" {
+		legacyUntil, err := time.Parse(time.RFC3339, cfg.LegacyHS256Until)
+		if err != nil {
+			return nil, fmt.Errorf("legacy-hs256-until : %w", err)
+		}
+		keySet.legacyUntil = legacyUntil
+	}
+
+	for _, keyCfg := range cfg.Keys {
+		key, err := loadSigningKey(keyCfg)
+		if err != nil {
+			return nil, fmt.Errorf("key %v : %w", keyCfg.ID, err)
+		}
+		keySet.keys[keyCfg.ID] = key
+	}
+
+	if keySet.activeKeyID != "" {
+		key, ok := keySet.keys[keySet.activeKeyID]
+		if !ok {
+			return nil, fmt.Errorf("active key %v is not configured", keySet.activeKeyID)
+		}
+		if key.privateKey == nil {
+			return nil, fmt.Errorf("active key %v has no private key", keySet.activeKeyID)
+		}
+	}
+
+	return keySet, nil
+}
+
+func loadSigningKey(cfg config.SigningKeyConfig) (signingKey, error) {
+	var key signingKey
+	var err error
+
+	var privatePEM, publicPEM []byte
+	if cfg.PrivateKeyPath != "" {
+		privatePEM, err = os.ReadFile(cfg.PrivateKeyPath)
+		if err != nil {
+			return key, err
+		}
+	}
+	if cfg.PublicKeyPath != "" {
+		publicPEM, err = os.ReadFile(cfg.PublicKeyPath)
+		if err != nil {
+			return key, err
+		}
+	}
+	if privatePEM == nil && publicPEM == nil {
+		return key, errors.New("either private or public key path is required")
+	}
+
+	switch cfg.Algorithm {
+	case jwt.SigningMethodRS256.Alg():
+		key.method = jwt.SigningMethodRS256
+		if privatePEM != nil {
+			privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(privatePEM)
+			if err != nil {
+				return key, err
+			}
+			key.privateKey = privateKey
+			key.publicKey = &privateKey.PublicKey
+		}
+		if publicPEM != nil {
+			key.publicKey, err = jwt.ParseRSAPublicKeyFromPEM(publicPEM)
+			if err != nil {
+				return key, err
+			}
+		}
+	case jwt.SigningMethodEdDSA.Alg():
+		key.method = jwt.SigningMethodEdDSA
+		if privatePEM != nil {
+			privateKey, err := jwt.ParseEdPrivateKeyFromPEM(privatePEM)
+			if err != nil {
+				return key, err
+			}
+			key.privateKey = privateKey
+			key.publicKey = privateKey.(ed25519.PrivateKey).Public()
+		}
+		if publicPEM != nil {
+			key.publicKey, err = jwt.ParseEdPublicKeyFromPEM(publicPEM)
+			if err != nil {
+				return key, err
+			}
+		}
+	default:
+		return key, fmt.Errorf("unsupported algorithm %v", cfg.Algorithm)
+	}
+
+	return key, nil
+}
+
+func (ks *KeySet) GenerateToken(id int, roles []string, tokenID string, tokenDuration time.Duration) (string, error) {
 	claims := Claims{
-		ID:  id,
-		Exp: time.Now().Add(tokenDuration * time.Second).Unix(),
+		StandardClaims: jwt.StandardClaims{
+			Id: tokenID,
+		},
+		ID:    id,
+		Roles: roles,
+		Exp:   TimeNow().Add(tokenDuration * time.Second).Unix(),
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
-	tokenString, err := token.SignedString([]byte(secretKey))
+	if ks.activeKeyID == "" {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		return token.SignedString([]byte(ks.secretKey))
+	}
+
+	key := ks.keys[ks.activeKeyID]
+	token := jwt.NewWithClaims(key.method, claims)
+	token.Header["kid"] = ks.activeKeyID
+
+	tokenString, err := token.SignedString(key.privateKey)
 	if err != nil {
 		return "", err
 	}
@@ -31,20 +190,77 @@ func GenerateToken(id int, tokenDuration time.Duration, secretKey string) (strin
 	return tokenString, nil
 }
 
-func VerifyToken(tokenString string, secretKey string) (*Claims, error) {
+func (ks *KeySet) VerifyToken(tokenString string) (*Claims, error) {
 
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		keyID, _ := token.Header["kid"].(string)
+		if keyID == "" {
+			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok || !ks.acceptsHS256() {
+				return nil, errors.New("unexpected signing method")
+			}
+			return []byte(ks.secretKey), nil
+		}
+
+		key, ok := ks.keys[keyID]
+		if !ok {
+			return nil, errors.New("unknown key id")
+		}
+		if token.Method.Alg() != key.method.Alg() {
 			return nil, errors.New("unexpected signing method")
 		}
-		return []byte(secretKey), nil
+		return key.publicKey, nil
 	})
 
 	if err != nil || !token.Valid {
-		return nil, errors.New("invalid token")
+		return nil, errors.New(ErrInvalidToken)
 	}
 
 	claims := token.Claims.(*Claims)
+	if claims.Exp < TimeNow().Unix() {
+		return nil, errors.New(ErrInvalidToken)
+	}
 	return claims, nil
 }
 
+// acceptsHS256 reports whether tokens signed with the secret key are still valid,
+// they are only accepted during the migration window once an asymmetric key is active
+func (ks *KeySet) acceptsHS256() bool {
+	if ks.secretKey == "" {
+		return false
+	}
+	return ks.activeKeyID == "" || TimeNow().Before(ks.legacyUntil)
+}
+
+// JWKS returns the public part of every asymmetric key so other services can verify tokens
+func (ks *KeySet) JWKS() JWKS {
+	jwks := JWKS{Keys: []JWK{}}
+	for keyID, key := range ks.keys {
+		jwk := JWK{
+			KeyID:     keyID,
+			Algorithm: key.method.Alg(),
+			Use:       "sig",
+		}
+		switch publicKey := key.publicKey.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(publicKey)
+		default:
+			continue
+		}
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+	return jwks
+}
+
+func ExtractBearerToken(authorization string) (string, error) {
+	tokenString, found := strings.CutPrefix(authorization, "Bearer ")
+	if !found || tokenString == "" {
+		return "", errors.New(ErrInvalidToken)
+	}
+	return tokenString, nil
+}
@@ -0,0 +1,140 @@
+package util
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"simcomm-monolith/config"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeEd25519Key writes a PKCS #8 private key and returns its path
+func writeEd25519Key(t *testing.T) string {
+	t.Helper()
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "ed.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+	return path
+}
+
+func TestKeySetVerifyToken(t *testing.T) {
+	keyPath := writeEd25519Key(t)
+	now := time.Now()
+
+	hs256, err := NewKeySet(config.AuthTokenConfig{SecretKey: "secret"})
+	require.NoError(t, err)
+	legacyToken, err := hs256.GenerateToken(1, []string{RoleCustomer}, "legacy", 900)
+	require.NoError(t, err)
+
+	asymmetric := func(legacyUntil string) config.AuthTokenConfig {
+		return config.AuthTokenConfig{
+			SecretKey:        "secret",
+			ActiveKeyID:      "ed",
+			LegacyHS256Until: legacyUntil,
+			Keys:             []config.SigningKeyConfig{{ID: "ed", Algorithm: "EdDSA", PrivateKeyPath: keyPath}},
+		}
+	}
+
+	noneToken, err := jwt.NewWithClaims(jwt.SigningMethodNone, Claims{ID: 1, Exp: now.Add(time.Hour).Unix()}).
+		SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		cfg     config.AuthTokenConfig
+		token   func(ks *KeySet) string
+		wantErr bool
+	}{
+		{
+			name:  "HS256 token while HS256 is active",
+			cfg:   config.AuthTokenConfig{SecretKey: "secret"},
+			token: func(ks *KeySet) string { return legacyToken },
+		},
+		{
+			name: "token signed with the active asymmetric key",
+			cfg:  asymmetric(""),
+			token: func(ks *KeySet) string {
+				token, err := ks.GenerateToken(1, nil, "new", 900)
+				require.NoError(t, err)
+				return token
+			},
+		},
+		{
+			name:    "kid-less HS256 token once an asymmetric key is active",
+			cfg:     asymmetric(""),
+			token:   func(ks *KeySet) string { return legacyToken },
+			wantErr: true,
+		},
+		{
+			name:  "kid-less HS256 token within the migration window",
+			cfg:   asymmetric(now.Add(time.Hour).Format(time.RFC3339)),
+			token: func(ks *KeySet) string { return legacyToken },
+		},
+		{
+			name:    "kid-less HS256 token after the migration window",
+			cfg:     asymmetric(now.Add(-time.Hour).Format(time.RFC3339)),
+			token:   func(ks *KeySet) string { return legacyToken },
+			wantErr: true,
+		},
+		{
+			name:    "unsigned token",
+			cfg:     config.AuthTokenConfig{SecretKey: "secret"},
+			token:   func(ks *KeySet) string { return noneToken },
+			wantErr: true,
+		},
+		{
+			name: "HS256 token claiming the asymmetric kid",
+			cfg:  asymmetric(""),
+			token: func(ks *KeySet) string {
+				token := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{ID: 1, Exp: now.Add(time.Hour).Unix()})
+				token.Header["kid"] = "ed"
+				tokenString, err := token.SignedString([]byte("secret"))
+				require.NoError(t, err)
+				return tokenString
+			},
+			wantErr: true,
+		},
+		{
+			name: "expired token",
+			cfg:  config.AuthTokenConfig{SecretKey: "secret"},
+			token: func(ks *KeySet) string {
+				token, err := ks.GenerateToken(1, nil, "expired", -60)
+				require.NoError(t, err)
+				return token
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ks, err := NewKeySet(tt.cfg)
+			require.NoError(t, err)
+
+			claims, err := ks.VerifyToken(tt.token(ks))
+			if tt.wantErr {
+				assert.EqualError(t, err, ErrInvalidToken)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 1, claims.ID)
+		})
+	}
+}
+
+func TestNewKeySetInvalidLegacyWindow(t *testing.T) {
+	_, err := NewKeySet(config.AuthTokenConfig{SecretKey: "secret", LegacyHS256Until: "tomorrow"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,42 @@
+package util
+
+import (
+	"math"
+	"strings"
+)
+
+// supportedCurrencies lists the accepted ISO 4217 codes, prices are stored as
+// integer minor units of the currency (e.g. cents) to avoid rounding errors
+var supportedCurrencies = map[string]bool{
+	"IDR": true,
+	"USD": true,
+	"EUR": true,
+	"SGD": true,
+	"MYR": true,
+	"JPY": true,
+}
+
+// NormalizeCurrency returns the upper case currency code and whether it is supported
+func NormalizeCurrency(currency string) (string, bool) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	return currency, supportedCurrencies[currency]
+}
+
+// MultiplyAmount multiplies an amount in minor units, ok is false on overflow
+func MultiplyAmount(amount int64, quantity int64) (int64, bool) {
+	if amount < 0 || quantity < 0 {
+		return 0, false
+	}
+	if quantity != 0 && amount > math.MaxInt64/quantity {
+		return 0, false
+	}
+	return amount * quantity, true
+}
+
+// AddAmount adds two amounts in minor units, ok is false on overflow
+func AddAmount(a int64, b int64) (int64, bool) {
+	if a < 0 || b < 0 || a > math.MaxInt64-b {
+		return 0, false
+	}
+	return a + b, true
+}
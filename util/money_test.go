@@ -0,0 +1,55 @@
+package util
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiplyAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   int64
+		quantity int64
+		want     int64
+		wantOK   bool
+	}{
+		{"regular subtotal", 15000, 3, 45000, true},
+		{"zero quantity", 15000, 0, 0, true},
+		{"largest amount", math.MaxInt64, 1, math.MaxInt64, true},
+		{"overflow", math.MaxInt64/2 + 1, 2, 0, false},
+		{"negative amount", -1, 2, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := MultiplyAmount(tt.amount, tt.quantity)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAddAmount(t *testing.T) {
+	tests := []struct {
+		name   string
+		a      int64
+		b      int64
+		want   int64
+		wantOK bool
+	}{
+		{"regular total", 45000, 15000, 60000, true},
+		{"largest total", math.MaxInt64 - 1, 1, math.MaxInt64, true},
+		{"overflow", math.MaxInt64, 1, 0, false},
+		{"negative amount", 1, -1, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := AddAmount(tt.a, tt.b)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
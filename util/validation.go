@@ -0,0 +1,116 @@
+package util
+
+import (
+	"errors"
+	"net/mail"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultPhoneCountryCode is used for local phone numbers starting with a trunk prefix 0
+const DefaultPhoneCountryCode = "62"
+
+const MinNameLength = 2
+const MaxNameLength = 100
+const MaxEmailLength = 254
+const MinPasswordLength = 8
+
+// MaxPasswordLength is bounded by bcrypt which ignores anything after 72 bytes
+const MaxPasswordLength = 72
+
+var e164Regex = regexp.MustCompile(`^\+[1-9][0-9]{7,14}$`)
+
+// NormalizeEmail validates an address in RFC 5322 addr-spec form and lowercases it
+func NormalizeEmail(email string) (string, error) {
+	email = strings.TrimSpace(email)
+	if email == "" || len(email) > MaxEmailLength {
+		return "", errors.New("must be a valid email address")
+	}
+
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Address != email || address.Name != "" {
+		return "", errors.New("must be a valid email address")
+	}
+
+	domain := email[strings.LastIndex(email, "@")+1:]
+	if !strings.Contains(domain, ".") || strings.HasPrefix(domain, ".") || strings.HasSuffix(domain, ".") {
+		return "", errors.New("must be a valid email address")
+	}
+
+	return strings.ToLower(email), nil
+}
+
+// NormalizePhone converts a phone number to E.164, e.g. "0812-3456 789" becomes "+628123456789".
+// A number must start with +, 00 or the trunk prefix 0, a bare number is rejected since
+// it is ambiguous whether it starts with a country code
+func NormalizePhone(phone string) (string, error) {
+	phone = strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '.', '(', ')':
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(phone))
+
+	switch {
+	case strings.HasPrefix(phone, "+"):
+	case strings.HasPrefix(phone, "00"):
+		phone = "+" + phone[2:]
+	case strings.HasPrefix(phone, "0"):
+		phone = "+" + DefaultPhoneCountryCode + phone[1:]
+	default:
+		return "", errors.New("must be a valid phone number starting with +, 00 or 0")
+	}
+
+	if !e164Regex.MatchString(phone) {
+		return "", errors.New("must be a valid phone number")
+	}
+	return phone, nil
+}
+
+// NormalizeIdentifier normalizes an email or phone used to look up an user,
+// the identifier is returned as is when it is neither
+func NormalizeIdentifier(identifier string) string {
+	if strings.Contains(identifier, "@") {
+		if email, err := NormalizeEmail(identifier); err == nil {
+			return email
+		}
+		return identifier
+	}
+	if phone, err := NormalizePhone(identifier); err == nil {
+		return phone
+	}
+	return identifier
+}
+
+func CheckName(name string) error {
+	length := utf8.RuneCountInString(strings.TrimSpace(name))
+	if length < MinNameLength || length > MaxNameLength {
+		return errors.New("must be between 2 and 100 characters")
+	}
+	return nil
+}
+
+func CheckPasswordStrength(password string) error {
+	if len(password) < MinPasswordLength || len(password) > MaxPasswordLength {
+		return errors.New("must be between 8 and 72 characters")
+	}
+
+	var hasUpper, hasLower, hasDigit bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		}
+	}
+	if !hasUpper || !hasLower || !hasDigit {
+		return errors.New("must contain an uppercase letter, a lowercase letter and a digit")
+	}
+	return nil
+}
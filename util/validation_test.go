@@ -0,0 +1,107 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeEmail(t *testing.T) {
+	tests := []struct {
+		email   string
+		want    string
+		wantErr bool
+	}{
+		{email: "Jane@Example.com", want: "jane@example.com"},
+		{email: "  jane@example.com ", want: "jane@example.com"},
+		{email: "jane.doe+shop@mail.example.co.id", want: "jane.doe+shop@mail.example.co.id"},
+		{email: "", wantErr: true},
+		{email: "jane", wantErr: true},
+		{email: "jane@localhost", wantErr: true},
+		{email: "Jane <jane@example.com>", wantErr: true},
+		{email: "jane@example.com.", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.email, func(t *testing.T) {
+			got, err := NormalizeEmail(tt.email)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		phone   string
+		want    string
+		wantErr bool
+	}{
+		{phone: "+6281234567890", want: "+6281234567890"},
+		{phone: "0812-3456 789", want: "+628123456789"},
+		{phone: "(0812) 3456.7890", want: "+6281234567890"},
+		{phone: "006581234567", want: "+6581234567"},
+		{phone: "81234567890", wantErr: true},
+		{phone: "6281234567890", wantErr: true},
+		{phone: "+0812345678", wantErr: true},
+		{phone: "+62812", wantErr: true},
+		{phone: "+62812345678901234", wantErr: true},
+		{phone: "0812abc4567", wantErr: true},
+		{phone: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.phone, func(t *testing.T) {
+			got, err := NormalizePhone(tt.phone)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNormalizeIdentifier(t *testing.T) {
+	tests := []struct {
+		identifier string
+		want       string
+	}{
+		{"Jane@Example.com", "jane@example.com"},
+		{"0812-3456-7890", "+6281234567890"},
+		{"81234567890", "81234567890"},
+		{"not an email@", "not an email@"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.identifier, func(t *testing.T) {
+			assert.Equal(t, tt.want, NormalizeIdentifier(tt.identifier))
+		})
+	}
+}
+
+func TestCheckPasswordStrength(t *testing.T) {
+	tests := []struct {
+		password string
+		wantErr  bool
+	}{
+		{"Secr3t-Passw0rd", false},
+		{"Sh0rt", true},
+		{"alllowercase1", true},
+		{"ALLUPPERCASE1", true},
+		{"NoDigitsHere", true},
+		{"L0ng" + string(make([]byte, 72)), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.password, func(t *testing.T) {
+			err := CheckPasswordStrength(tt.password)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}